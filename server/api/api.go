@@ -25,6 +25,13 @@ func Init(h *httputils.Handler, env mscalendar.Env, notificationProcessor mscale
 
 	dialogRouter := h.Router.PathPrefix(config.PathDialogs).Subrouter()
 	dialogRouter.HandleFunc(config.PathSetAutoRespondMessage, api.setAutoRespondMessage).Methods("POST")
+	dialogRouter.HandleFunc(config.PathRespond, api.submitEventResponse).Methods("POST")
+	dialogRouter.HandleFunc(config.PathEditEvent, api.submitEditEvent).Methods("POST")
+	dialogRouter.HandleFunc(config.PathCancelEvent, api.submitCancelEvent).Methods("POST")
+	dialogRouter.HandleFunc(config.PathCreateEventFromPost, api.submitCreateEventFromPost).Methods("POST")
+	dialogRouter.HandleFunc(config.PathEditMuted, api.submitEditMuted).Methods("POST")
+
+	h.Router.HandleFunc(config.PathJoinCall+"/{channelID:[A-Za-z0-9_]+}", api.joinCall).Methods("GET")
 
 	notificationRouter := h.Router.PathPrefix(config.PathNotification).Subrouter()
 	notificationRouter.HandleFunc(config.PathEvent, api.notification).Methods("POST")
@@ -35,4 +42,16 @@ func Init(h *httputils.Handler, env mscalendar.Env, notificationProcessor mscale
 	postActionRouter.HandleFunc(config.PathTentative, api.postActionTentative).Methods("POST")
 	postActionRouter.HandleFunc(config.PathRespond, api.postActionRespond).Methods("POST")
 	postActionRouter.HandleFunc(config.PathConfirmStatusChange, api.postActionConfirmStatusChange).Methods("POST")
+	postActionRouter.HandleFunc(config.PathSnoozeReminder, api.postActionSnoozeReminder).Methods("POST")
+	postActionRouter.HandleFunc(config.PathDismissReminder, api.postActionDismissReminder).Methods("POST")
+	postActionRouter.HandleFunc(config.PathRespondToConflict, api.postActionRespondToConflict).Methods("POST")
+	postActionRouter.HandleFunc(config.PathRescheduleEvent, api.postActionRescheduleEvent).Methods("POST")
+	postActionRouter.HandleFunc(config.PathCancelEvent, api.postActionCancelEvent).Methods("POST")
+	postActionRouter.HandleFunc(config.PathDelegation, api.postActionDelegation).Methods("POST")
+	postActionRouter.HandleFunc(config.PathRespondAsDelegate, api.postActionRespondAsDelegate).Methods("POST")
+	postActionRouter.HandleFunc(config.PathSearchEvents, api.postActionSearchEvents).Methods("POST")
+	postActionRouter.HandleFunc(config.PathImportICS, api.postActionImportICS).Methods("POST")
+	postActionRouter.HandleFunc(config.PathNudgeAttendees, api.postActionNudgeAttendees).Methods("POST")
+	postActionRouter.HandleFunc(config.PathAcceptTeamInvites, api.postActionAcceptTeamInvites).Methods("POST")
+	postActionRouter.HandleFunc(config.PathEditMuted, api.postActionEditMuted).Methods("POST")
 }
@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+)
+
+// postActionDelegation approves or denies a request to manage the calendar of
+// the user who clicked.
+func (api *api) postActionDelegation(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	request := model.PostActionIntegrationRequestFromJson(req.Body)
+	if request == nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	delegateID, _ := request.Context[config.DelegateIDKey].(string)
+	option, _ := request.Context[config.OptionKey].(string)
+	if delegateID == "" || (option != mscalendar.DelegationApprove && option != mscalendar.DelegationDeny) {
+		utils.SlackAttachmentError(w, "Error: invalid delegation request")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	approve := option == mscalendar.DelegationApprove
+	err := m.RespondToDelegationRequest(mscalendar.NewUser(mattermostUserID), delegateID, approve)
+	if err != nil {
+		api.Logger.Warnf("Failed to respond to the delegation request. err=%v", err)
+		utils.SlackAttachmentError(w, "Error: Failed to respond to the delegation request: "+err.Error())
+		return
+	}
+
+	status := "You have denied this request."
+	if approve {
+		status = "You have approved this request. You can remove the access with `/" + config.CommandTrigger + " delegate remove`."
+	}
+	err = api.updateEventPost(request.PostId, "Status", status, true)
+	if err != nil {
+		api.Logger.Warnf("Failed to update the delegation request post. err=%v", err)
+	}
+
+	postResponse := model.PostActionIntegrationResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
+
+// postActionRespondAsDelegate responds to an invitation of the delegator with
+// the selected option, without a dialog.
+func (api *api) postActionRespondAsDelegate(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, request, eventID := api.parseEventPostAction(w, req)
+	if eventID == "" {
+		return
+	}
+	delegatorID, _ := request.Context[config.DelegatorIDKey].(string)
+	if delegatorID == "" {
+		utils.SlackAttachmentError(w, "Error: missing delegator ID")
+		return
+	}
+	option, _ := request.Context["selected_option"].(string)
+	if option != mscalendar.OptionYes && option != mscalendar.OptionNo && option != mscalendar.OptionMaybe {
+		utils.SlackAttachmentError(w, "Error: Please select a response.")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	err := m.RespondToDelegatorEvent(mscalendar.NewUser(mattermostUserID), delegatorID, eventID, option)
+	if err != nil {
+		switch {
+		case isCanceledError(err):
+			utils.SlackAttachmentError(w, "Error: Cannot respond to the event because it is already canceled.")
+		case isNotFoundError(err):
+			utils.SlackAttachmentError(w, "Error: Event has changed since this message, or their calendar is not shared with you.")
+		default:
+			utils.SlackAttachmentError(w, "Error: Failed to respond to event: "+err.Error())
+		}
+		return
+	}
+
+	delegatorName := delegatorID
+	if delegator, appErr := api.PluginAPI.GetMattermostUser(delegatorID); appErr == nil {
+		delegatorName = "@" + delegator.Username
+	}
+	err = api.updateEventPost(request.PostId, "Response", fmt.Sprintf("You have %s this event on behalf of %s", prettyOption(option), delegatorName), true)
+	if err != nil {
+		api.Logger.Warnf("Failed to update the event notification post. err=%v", err)
+	}
+
+	postResponse := model.PostActionIntegrationResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+)
+
+func (api *api) postActionRescheduleEvent(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, request, eventID := api.parseEventPostAction(w, req)
+	if eventID == "" {
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	err := m.OpenEditEventDialog(mscalendar.NewUser(mattermostUserID), request.TriggerId, eventID, request.PostId, true)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to open the reschedule dialog: "+err.Error())
+		return
+	}
+
+	postResponse := model.PostActionIntegrationResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
+
+func (api *api) postActionCancelEvent(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, request, eventID := api.parseEventPostAction(w, req)
+	if eventID == "" {
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	err := m.OpenCancelEventDialog(mscalendar.NewUser(mattermostUserID), request.TriggerId, eventID, request.PostId)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to open the cancel dialog: "+err.Error())
+		return
+	}
+
+	postResponse := model.PostActionIntegrationResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
+
+func (api *api) submitEditEvent(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, v, state := api.parseEventDialogSubmission(w, req)
+	if state == nil {
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	user := mscalendar.NewUser(mattermostUserID)
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		dialogResponseError(w, "Failed to get the time zone of your calendar")
+		return
+	}
+
+	event, fieldErrors := parseEventDialogTimes(v.Submission, timezone)
+	event.ID = state.EventID
+	if !state.RescheduleOnly {
+		subject, _ := v.Submission[mscalendar.EventDialogSubjectField].(string)
+		location, _ := v.Submission[mscalendar.EventDialogLocationField].(string)
+		event.Subject = strings.TrimSpace(subject)
+		event.Location = &remote.Location{
+			DisplayName:  strings.TrimSpace(location),
+			LocationType: "default",
+		}
+	}
+	if len(fieldErrors) > 0 {
+		response := model.SubmitDialogResponse{
+			Errors: fieldErrors,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response.ToJson())
+		return
+	}
+
+	updated, err := m.UpdateEvent(user, event)
+	if err != nil {
+		dialogResponseError(w, "Failed to update the event: "+err.Error())
+		return
+	}
+
+	start := updated.Start.In(timezone).Time()
+	end := updated.End.In(timezone).Time()
+	status := fmt.Sprintf("You have moved this meeting to %s - %s", start.Format("Monday, January 02 · "+time.Kitchen), end.Format(time.Kitchen))
+	api.updateOrganizerPost(mattermostUserID, v.ChannelId, state.PostID, status, false)
+
+	response := model.SubmitDialogResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response.ToJson())
+}
+
+func (api *api) submitCancelEvent(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, v, state := api.parseEventDialogSubmission(w, req)
+	if state == nil {
+		return
+	}
+
+	message, _ := v.Submission[mscalendar.EventDialogMessageField].(string)
+	m := mscalendar.New(api.Env, mattermostUserID)
+	err := m.CancelEvent(mscalendar.NewUser(mattermostUserID), state.EventID, strings.TrimSpace(message))
+	if err != nil {
+		dialogResponseError(w, "Failed to cancel the event: "+err.Error())
+		return
+	}
+
+	api.updateOrganizerPost(mattermostUserID, v.ChannelId, state.PostID, "You have cancelled this meeting", true)
+
+	response := model.SubmitDialogResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response.ToJson())
+}
+
+// submitCreateEventFromPost creates the event scheduled from a post, with the
+// attendees given by username.
+func (api *api) submitCreateEventFromPost(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, v, state := api.parseEventDialogSubmission(w, req)
+	if state == nil {
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	user := mscalendar.NewUser(mattermostUserID)
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		dialogResponseError(w, "Failed to get the time zone of your calendar")
+		return
+	}
+
+	event, fieldErrors := parseEventDialogTimes(v.Submission, timezone)
+	subject, _ := v.Submission[mscalendar.EventDialogSubjectField].(string)
+	location, _ := v.Submission[mscalendar.EventDialogLocationField].(string)
+	event.Subject = strings.TrimSpace(subject)
+	if strings.TrimSpace(location) != "" {
+		event.Location = &remote.Location{
+			DisplayName:  strings.TrimSpace(location),
+			LocationType: "default",
+		}
+	}
+
+	attendees, _ := v.Submission[mscalendar.EventDialogAttendeesField].(string)
+	mattermostUserIDs := []string{}
+	unknown := []string{}
+	for _, username := range strings.Fields(strings.ReplaceAll(attendees, ",", " ")) {
+		u, err := api.PluginAPI.GetMattermostUserByUsername(strings.TrimPrefix(username, "@"))
+		if err != nil {
+			unknown = append(unknown, username)
+			continue
+		}
+		mattermostUserIDs = append(mattermostUserIDs, u.Id)
+	}
+	if len(unknown) > 0 {
+		fieldErrors[mscalendar.EventDialogAttendeesField] = "Unknown users: " + strings.Join(unknown, ", ")
+	}
+	if len(fieldErrors) > 0 {
+		response := model.SubmitDialogResponse{
+			Errors: fieldErrors,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response.ToJson())
+		return
+	}
+
+	meetingType, _ := v.Submission[mscalendar.EventDialogOnlineMeetingField].(string)
+	_, err = m.CreateEventFromPost(user, state.PostID, event, mattermostUserIDs, meetingType)
+	if err != nil {
+		dialogResponseError(w, "Failed to create the event: "+err.Error())
+		return
+	}
+
+	response := model.SubmitDialogResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response.ToJson())
+}
+
+// parseEventDialogTimes reads the start and end of an event dialog, returning
+// the errors by field.
+func parseEventDialogTimes(submission map[string]interface{}, timezone string) (*remote.Event, map[string]string) {
+	fieldErrors := map[string]string{}
+	parse := func(field string) *remote.DateTime {
+		value, _ := submission[field].(string)
+		t, err := mscalendar.ParseEventTime(value, timezone)
+		if err != nil {
+			fieldErrors[field] = "Invalid time: " + err.Error() + "."
+			return nil
+		}
+		return remote.NewDateTime(t.UTC(), "UTC")
+	}
+
+	event := &remote.Event{
+		Start: parse(mscalendar.EventDialogStartField),
+		End:   parse(mscalendar.EventDialogEndField),
+	}
+	if len(fieldErrors) == 0 && !event.End.Time().After(event.Start.Time()) {
+		fieldErrors[mscalendar.EventDialogEndField] = "The end of the event must be after its start."
+	}
+	return event, fieldErrors
+}
+
+func (api *api) parseEventDialogSubmission(w http.ResponseWriter, req *http.Request) (string, *model.SubmitDialogRequest, *mscalendar.EventDialogState) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		dialogResponseError(w, "Not authorized.")
+		return "", nil, nil
+	}
+
+	v := &model.SubmitDialogRequest{}
+	err := json.NewDecoder(req.Body).Decode(v)
+	if err != nil {
+		api.Logger.Warnf("Failed to unmarshal event dialog request. err=%v", err)
+		dialogResponseError(w, "Failed to process submit dialog response")
+		return "", nil, nil
+	}
+
+	state := &mscalendar.EventDialogState{}
+	err = json.Unmarshal([]byte(v.State), state)
+	if err != nil || (state.EventID == "" && state.PostID == "") {
+		dialogResponseError(w, "Failed to process submit dialog response")
+		return "", nil, nil
+	}
+	return mattermostUserID, v, state
+}
+
+// updateOrganizerPost reflects the change on the event card the dialog was
+// opened from, or tells the user when the dialog was opened from a command.
+func (api *api) updateOrganizerPost(mattermostUserID, channelID, postID, status string, clearActions bool) {
+	if postID == "" {
+		api.Poster.Ephemeral(mattermostUserID, channelID, status)
+		return
+	}
+
+	err := api.updateEventPost(postID, "Meeting", status, clearActions)
+	if err != nil {
+		api.Logger.Warnf("Failed to update the event post. err=%v", err)
+	}
+}
+
+func (api *api) updateEventPost(postID, title, value string, clearActions bool) error {
+	return api.updateEventAttachment(postID, "", title, value, clearActions)
+}
+
+// updateEventAttachment adds a field to the attachment of the post whose
+// actions are for the event, as digests hold several events, or to the first
+// attachment when eventID is empty or not found.
+func (api *api) updateEventAttachment(postID, eventID, title, value string, clearActions bool) error {
+	p, err := api.PluginAPI.GetPost(postID)
+	if err != nil {
+		return err
+	}
+
+	sas := p.Attachments()
+	if len(sas) == 0 {
+		return errors.New("no attachments found")
+	}
+
+	sa := sas[0]
+	for _, candidate := range sas {
+		if eventID != "" && hasEventAction(candidate, eventID) {
+			sa = candidate
+			break
+		}
+	}
+	sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+		Title: title,
+		Value: value,
+		Short: false,
+	})
+	if clearActions {
+		sa.Actions = []*model.PostAction{}
+	}
+	model.ParseSlackAttachment(p, sas)
+
+	_, err = api.PluginAPI.UpdatePost(p)
+	return err
+}
+
+func hasEventAction(sa *model.SlackAttachment, eventID string) bool {
+	for _, a := range sa.Actions {
+		if a.Integration != nil && a.Integration.Context[config.EventIDKey] == eventID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+const (
+	eventResponseCommentField          = "comment"
+	eventResponseDontSendResponseField = "dont_send_response"
+	eventResponseProposedStartField    = "proposed_start"
+	eventResponseProposedEndField      = "proposed_end"
+)
+
+type eventResponseDialogState struct {
+	EventID string
+	Option  string
+	PostID  string
+}
+
+func (api *api) newEventResponseDialog(triggerID, eventID, option, postID string) (model.OpenDialogRequest, error) {
+	state, err := json.Marshal(&eventResponseDialogState{
+		EventID: eventID,
+		Option:  option,
+		PostID:  postID,
+	})
+	if err != nil {
+		return model.OpenDialogRequest{}, err
+	}
+
+	elements := []model.DialogElement{
+		{
+			DisplayName: "Comment",
+			Name:        eventResponseCommentField,
+			Type:        "textarea",
+			Optional:    true,
+			Placeholder: "Add a message for the organizer",
+		},
+		{
+			DisplayName: "Response",
+			Name:        eventResponseDontSendResponseField,
+			Type:        "bool",
+			Optional:    true,
+			Placeholder: "Don't send a response to the organizer",
+		},
+	}
+	if option != mscalendar.OptionYes {
+		helpText := fmt.Sprintf("For example tomorrow 3pm or %s, in the time zone of your calendar.", mscalendar.EventTimeExample)
+		elements = append(elements, model.DialogElement{
+			DisplayName: "Propose a new start time",
+			Name:        eventResponseProposedStartField,
+			Type:        "text",
+			Optional:    true,
+			HelpText:    helpText,
+		}, model.DialogElement{
+			DisplayName: "Propose a new end time",
+			Name:        eventResponseProposedEndField,
+			Type:        "text",
+			Optional:    true,
+			HelpText:    helpText,
+		})
+	}
+
+	return model.OpenDialogRequest{
+		TriggerId: triggerID,
+		URL:       api.Config.PluginURL + config.PathDialogs + config.PathRespond,
+		Dialog: model.Dialog{
+			Title:       "Microsoft Calendar",
+			Elements:    elements,
+			SubmitLabel: responseLabel(option),
+			State:       string(state),
+		},
+	}, nil
+}
+
+// submitEventResponse responds to the event with the options of the dialog,
+// and updates the notification post to reflect the response.
+func (api *api) submitEventResponse(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		dialogResponseError(w, "Not authorized.")
+		return
+	}
+
+	v := model.SubmitDialogRequest{}
+	err := json.NewDecoder(req.Body).Decode(&v)
+	if err != nil {
+		api.Logger.Warnf("Failed to unmarshal event response dialog request. err=%v", err)
+		dialogResponseError(w, "Failed to process submit dialog response")
+		return
+	}
+
+	state := eventResponseDialogState{}
+	err = json.Unmarshal([]byte(v.State), &state)
+	if err != nil {
+		dialogResponseError(w, "Failed to process submit dialog response")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	user := mscalendar.NewUser(mattermostUserID)
+
+	comment, _ := v.Submission[eventResponseCommentField].(string)
+	dontSendResponse, _ := v.Submission[eventResponseDontSendResponseField].(bool)
+	options := &remote.EventResponseOptions{
+		Comment:      strings.TrimSpace(comment),
+		SendResponse: !dontSendResponse,
+	}
+
+	timezone := ""
+	proposedStart, _ := v.Submission[eventResponseProposedStartField].(string)
+	proposedEnd, _ := v.Submission[eventResponseProposedEndField].(string)
+	if proposedStart != "" || proposedEnd != "" {
+		timezone, err = m.GetTimezone(user)
+		if err != nil {
+			dialogResponseError(w, "Failed to get the time zone of your calendar")
+			return
+		}
+
+		slot, fieldErrors := parseProposedNewTime(proposedStart, proposedEnd, timezone)
+		if len(fieldErrors) > 0 {
+			response := model.SubmitDialogResponse{
+				Errors: fieldErrors,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(response.ToJson())
+			return
+		}
+		options.ProposedNewTime = slot
+	}
+
+	err = m.RespondToEvent(user, state.EventID, state.Option, options)
+	if err != nil {
+		switch {
+		case isCanceledError(err):
+			dialogResponseError(w, "Cannot respond to the event because it is already canceled.")
+		case isNotFoundError(err):
+			dialogResponseError(w, "Event has changed since this message. Please change your status directly on MS Calendar.")
+		default:
+			dialogResponseError(w, "Failed to respond to event: "+err.Error())
+		}
+		return
+	}
+
+	err = api.updateEventResponsePost(state.PostID, state.EventID, state.Option, options, timezone)
+	if err != nil {
+		api.Logger.Warnf("Failed to update the event notification post. err=%v", err)
+	}
+
+	response := model.SubmitDialogResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response.ToJson())
+}
+
+func (api *api) updateEventResponsePost(postID, eventID, option string, options *remote.EventResponseOptions, timezone string) error {
+	lines := []string{fmt.Sprintf("You have %s this event", prettyOption(option))}
+	if options.Comment != "" {
+		lines = append(lines, "Comment: "+options.Comment)
+	}
+	if slot := options.ProposedNewTime; slot != nil {
+		start := slot.Start.In(timezone).Time()
+		end := slot.End.In(timezone).Time()
+		lines = append(lines, fmt.Sprintf("Proposed new time: %s - %s", start.Format("Monday, January 02 · "+time.Kitchen), end.Format(time.Kitchen)))
+	}
+	if !options.SendResponse {
+		lines = append(lines, "The organizer was not notified")
+	}
+
+	return api.updateEventAttachment(postID, eventID, "Response", strings.Join(lines, "\n"), true)
+}
+
+func parseProposedNewTime(startStr, endStr, timezone string) (*remote.TimeSlot, map[string]string) {
+	fieldErrors := map[string]string{}
+	parse := func(field, value string) time.Time {
+		if value == "" {
+			fieldErrors[field] = "Please enter both the start and the end of the proposed time."
+			return time.Time{}
+		}
+		t, err := mscalendar.ParseEventTime(value, timezone)
+		if err != nil {
+			fieldErrors[field] = "Invalid time: " + err.Error() + "."
+			return time.Time{}
+		}
+		return t
+	}
+
+	start := parse(eventResponseProposedStartField, startStr)
+	end := parse(eventResponseProposedEndField, endStr)
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+	if !end.After(start) {
+		fieldErrors[eventResponseProposedEndField] = "The end of the proposed time must be after its start."
+		return nil, fieldErrors
+	}
+
+	return &remote.TimeSlot{
+		Start: remote.NewDateTime(start.UTC(), "UTC"),
+		End:   remote.NewDateTime(end.UTC(), "UTC"),
+	}, nil
+}
+
+func responseLabel(option string) string {
+	switch option {
+	case mscalendar.OptionYes:
+		return "Accept"
+	case mscalendar.OptionNo:
+		return "Decline"
+	case mscalendar.OptionMaybe:
+		return "Tentative"
+	default:
+		return "Respond"
+	}
+}
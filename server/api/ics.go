@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+)
+
+// postActionImportICS adds the events of an iCalendar file posted in a
+// channel to the calendar of the user who clicked the button.
+func (api *api) postActionImportICS(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	request := model.PostActionIntegrationRequestFromJson(req.Body)
+	if request == nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+	fileID, _ := request.Context[mscalendar.ICSFileIDKey].(string)
+	if fileID == "" {
+		utils.SlackAttachmentError(w, "Error: missing file")
+		return
+	}
+
+	if _, err := api.Store.LoadUser(mattermostUserID); err == store.ErrNotFound {
+		utils.SlackAttachmentError(w, "Please connect your Microsoft account with `/mscalendar connect` to add events to your calendar.")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	result, err := m.ImportICSFile(mscalendar.NewUser(mattermostUserID), fileID)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to add the events to your calendar: "+err.Error())
+		return
+	}
+
+	messages := []string{}
+	switch len(result.Created) {
+	case 0:
+	case 1:
+		messages = append(messages, "1 event has been added to your calendar.")
+	default:
+		messages = append(messages, fmt.Sprintf("%d events have been added to your calendar.", len(result.Created)))
+	}
+	if len(result.Duplicates) > 0 {
+		messages = append(messages, "Already in your calendar: "+strings.Join(result.Duplicates, ", ")+".")
+	}
+	if len(result.Cancelled) > 0 {
+		messages = append(messages, "Cancelled, so not added: "+strings.Join(result.Cancelled, ", ")+".")
+	}
+	if len(messages) == 0 {
+		messages = append(messages, "There were no events to add.")
+	}
+
+	postResponse := model.PostActionIntegrationResponse{
+		EphemeralText: strings.Join(messages, " "),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/httputils"
+)
+
+// joinCall redirects the call links of the events to their channel.
+func (api *api) joinCall(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+
+	channel, err := api.PluginAPI.GetMattermostChannel(mux.Vars(r)["channelID"])
+	if err != nil {
+		httputils.WriteNotFoundError(w, err)
+		return
+	}
+	team, err := api.PluginAPI.GetMattermostTeam(channel.TeamId)
+	if err != nil {
+		httputils.WriteNotFoundError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("%s/%s/channels/%s", strings.TrimRight(api.Config.MattermostSiteURL, "/"), team.Name, channel.Name), http.StatusFound)
+}
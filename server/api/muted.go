@@ -0,0 +1,81 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+)
+
+// postActionEditMuted opens the dialog to edit the muted organizers and
+// keywords from the settings panel.
+func (api *api) postActionEditMuted(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	request := model.PostActionIntegrationRequestFromJson(req.Body)
+	if request == nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	err := m.OpenMutedDialog(mscalendar.NewUser(mattermostUserID), request.TriggerId, request.PostId)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to open the dialog: "+err.Error())
+		return
+	}
+
+	postResponse := model.PostActionIntegrationResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
+
+func (api *api) submitEditMuted(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		dialogResponseError(w, "Not authorized.")
+		return
+	}
+
+	v := model.SubmitDialogRequest{}
+	err := json.NewDecoder(req.Body).Decode(&v)
+	if err != nil {
+		api.Logger.Warnf("Failed to unmarshal muted notifications dialog request. err=%v", err)
+		dialogResponseError(w, "Failed to process submit dialog response")
+		return
+	}
+
+	organizers, _ := v.Submission[mscalendar.MutedDialogOrganizersField].(string)
+	keywords, _ := v.Submission[mscalendar.MutedDialogKeywordsField].(string)
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	err = m.SetMuted(mscalendar.NewUser(mattermostUserID), strings.Split(organizers, "\n"), strings.Split(keywords, "\n"))
+	if err != nil {
+		response := model.SubmitDialogResponse{
+			Errors: map[string]string{
+				mscalendar.MutedDialogOrganizersField: err.Error(),
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response.ToJson())
+		return
+	}
+
+	response := model.SubmitDialogResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response.ToJson())
+	if v.State != "" {
+		m.UpdateSettingsPost(mattermostUserID, v.State)
+	}
+}
@@ -38,6 +38,10 @@ func (api *api) preprocessAction(w http.ResponseWriter, req *http.Request) (msca
 		return nil, nil, "", "", ""
 	}
 	option, _ = request.Context["selected_option"].(string)
+	if option == "" {
+		// Buttons carry their option in the context instead of a selection
+		option, _ = request.Context[config.OptionKey].(string)
+	}
 	mscal = mscalendar.New(api.Env, mattermostUserID)
 
 	return mscal, mscalendar.NewUser(mattermostUserID), eventID, option, request.PostId
@@ -80,19 +84,67 @@ func (api *api) postActionTentative(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// parseEventPostAction reads a post action on an event, for the actions that
+// need more than preprocessAction provides, like opening a dialog.
+func (api *api) parseEventPostAction(w http.ResponseWriter, req *http.Request) (mattermostUserID string, request *model.PostActionIntegrationRequest, eventID string) {
+	mattermostUserID = req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return "", nil, ""
+	}
+
+	request = model.PostActionIntegrationRequestFromJson(req.Body)
+	if request == nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return "", nil, ""
+	}
+
+	eventID, ok := request.Context[config.EventIDKey].(string)
+	if !ok {
+		utils.SlackAttachmentError(w, "Error: missing event ID")
+		return "", nil, ""
+	}
+	return mattermostUserID, request, eventID
+}
+
+// postActionRespond opens the dialog to respond to the event with the
+// selected option.
 func (api *api) postActionRespond(w http.ResponseWriter, req *http.Request) {
-	calendar, user, eventID, option, postID := api.preprocessAction(w, req)
+	_, request, eventID := api.parseEventPostAction(w, req)
 	if eventID == "" {
 		return
 	}
-	err := calendar.RespondToEvent(user, eventID, option)
-	if err != nil && !isAcceptedError(err) && !isNotFoundError(err) && !isCanceledError(err) {
-		utils.SlackAttachmentError(w, "Error: Failed to respond to event: "+err.Error())
+	option, _ := request.Context["selected_option"].(string)
+	if option != mscalendar.OptionYes && option != mscalendar.OptionNo && option != mscalendar.OptionMaybe {
+		utils.SlackAttachmentError(w, "Error: Please select a response.")
+		return
+	}
+
+	dialog, err := api.newEventResponseDialog(request.TriggerId, eventID, option, request.PostId)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to open the response dialog: "+err.Error())
 		return
 	}
 
-	if err != nil && isCanceledError(err) {
-		utils.SlackAttachmentError(w, "Error: Cannot respond to the event because it is already canceled.")
+	err = api.PluginAPI.OpenInteractiveDialog(dialog)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to open the response dialog: "+err.Error())
+		return
+	}
+
+	postResponse := model.PostActionIntegrationResponse{}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
+
+func (api *api) postActionRespondToConflict(w http.ResponseWriter, req *http.Request) {
+	calendar, user, eventID, option, postID := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+	err := calendar.RespondToEvent(user, eventID, option, nil)
+	if err != nil && !isNotFoundError(err) {
+		utils.SlackAttachmentError(w, "Error: Failed to respond to event: "+err.Error())
 		return
 	}
 
@@ -109,23 +161,89 @@ func (api *api) postActionRespond(w http.ResponseWriter, req *http.Request) {
 	}
 
 	sa := sas[0]
+	sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+		Title: "Conflict",
+		Value: fmt.Sprintf("You have %s the event to resolve the conflict", prettyOption(option)),
+		Short: false,
+	})
+
+	actions := []*model.PostAction{}
+	for _, a := range sa.Actions {
+		if a.Integration == nil || !strings.HasSuffix(a.Integration.URL, config.PathRespondToConflict) {
+			actions = append(actions, a)
+		}
+	}
+	sa.Actions = actions
+	model.ParseSlackAttachment(p, []*model.SlackAttachment{sa})
+
+	postResponse := model.PostActionIntegrationResponse{
+		Update: p,
+	}
+	if err != nil && isNotFoundError(err) {
+		postResponse.EphemeralText = "Event has changed since this message. Please change your status directly on MS Calendar."
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
 
-	if err == nil || isAcceptedError(err) {
-		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
-			Title: "Response",
-			Value: fmt.Sprintf("You have %s this event", prettyOption(option)),
-			Short: false,
-		})
+func (api *api) postActionSnoozeReminder(w http.ResponseWriter, req *http.Request) {
+	calendar, user, eventID, _, postID := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+
+	remindAt, err := calendar.SnoozeReminder(user, eventID)
+	if err != nil {
+		api.Logger.Warnf("Failed to snooze reminder. err=%v", err)
+		utils.SlackAttachmentError(w, "Error: Failed to snooze reminder: "+err.Error())
+		return
 	}
 
+	api.updateReminderPost(w, postID, fmt.Sprintf("Snoozed. You will be reminded again in %d minutes.", int(time.Until(remindAt).Round(time.Minute).Minutes())))
+}
+
+func (api *api) postActionDismissReminder(w http.ResponseWriter, req *http.Request) {
+	calendar, user, eventID, _, postID := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+
+	err := calendar.DismissReminder(user, eventID)
+	if err != nil {
+		api.Logger.Warnf("Failed to dismiss reminder. err=%v", err)
+		utils.SlackAttachmentError(w, "Error: Failed to dismiss reminder: "+err.Error())
+		return
+	}
+
+	api.updateReminderPost(w, postID, "Dismissed.")
+}
+
+// updateReminderPost removes the actions from the reminder post, keeping the
+// join link, and records what the user did with it.
+func (api *api) updateReminderPost(w http.ResponseWriter, postID, status string) {
+	p, appErr := api.PluginAPI.GetPost(postID)
+	if appErr != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to update the post: "+appErr.Error())
+		return
+	}
+
+	sas := p.Attachments()
+	if len(sas) == 0 {
+		utils.SlackAttachmentError(w, "Error: Failed to update the post: No attachments found")
+		return
+	}
+
+	sa := sas[0]
+	sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+		Title: "Reminder",
+		Value: status,
+		Short: false,
+	})
 	sa.Actions = []*model.PostAction{}
-	postResponse := model.PostActionIntegrationResponse{}
 	model.ParseSlackAttachment(p, []*model.SlackAttachment{sa})
 
-	postResponse.Update = p
-
-	if err != nil && isNotFoundError(err) {
-		postResponse.EphemeralText = "Event has changed since this message. Please change your status directly on MS Calendar."
+	postResponse := model.PostActionIntegrationResponse{
+		Update: p,
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(postResponse.ToJson())
@@ -258,10 +376,6 @@ func getEventInfo(ctx map[string]interface{}) (string, error) {
 	return views.RenderEventWillStartLine(subject, weblink, startTime), nil
 }
 
-func isAcceptedError(err error) bool {
-	return strings.Contains(err.Error(), "202 Accepted")
-}
-
 func isNotFoundError(err error) bool {
 	return strings.Contains(err.Error(), "404 Not Found")
 }
@@ -269,3 +383,73 @@ func isNotFoundError(err error) bool {
 func isCanceledError(err error) bool {
 	return strings.Contains(err.Error(), "You can't respond to a meeting that's been canceled.")
 }
+
+// postActionNudgeAttendees reminds the attendees who have not responded to an
+// event the user organized.
+func (api *api) postActionNudgeAttendees(w http.ResponseWriter, req *http.Request) {
+	mscalendar, user, eventID, _, _ := api.preprocessAction(w, req)
+	if eventID == "" {
+		return
+	}
+	nudged, err := mscalendar.NudgeNonResponders(user, eventID)
+	if err != nil {
+		api.Logger.Warnf("Failed to nudge attendees. err=%v", err)
+		utils.SlackAttachmentError(w, "Error: Failed to nudge the attendees: "+err.Error())
+		return
+	}
+
+	text := fmt.Sprintf("Nudged %d attendees.", nudged)
+	switch nudged {
+	case 0:
+		text = "None of the attendees who have not responded are connected to Mattermost."
+	case 1:
+		text = "Nudged 1 attendee."
+	}
+	postResponse := model.PostActionIntegrationResponse{
+		EphemeralText: text,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
+
+// postActionAcceptTeamInvites accepts the pending invitations organized by
+// members of the team the invitations were listed from.
+func (api *api) postActionAcceptTeamInvites(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	request := model.PostActionIntegrationRequestFromJson(req.Body)
+	if request == nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+	teamID, _ := request.Context[config.TeamIDKey].(string)
+	if teamID == "" {
+		utils.SlackAttachmentError(w, "Error: missing team ID")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	accepted, err := m.AcceptInvitationsFromTeam(mscalendar.NewUser(mattermostUserID), teamID)
+	if err != nil {
+		api.Logger.Warnf("Failed to accept the invitations from the team. err=%v", err)
+		utils.SlackAttachmentError(w, "Error: Failed to accept the invitations: "+err.Error())
+		return
+	}
+
+	text := fmt.Sprintf("Accepted %d invitations.", accepted)
+	switch accepted {
+	case 0:
+		text = "There are no pending invitations from your team."
+	case 1:
+		text = "Accepted 1 invitation."
+	}
+	postResponse := model.PostActionIntegrationResponse{
+		EphemeralText: text,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+)
+
+// postActionSearchEvents replaces the search results with another page.
+func (api *api) postActionSearchEvents(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	request := model.PostActionIntegrationRequestFromJson(req.Body)
+	if request == nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	query, _ := request.Context[mscalendar.SearchQueryKey].(string)
+	fromStr, _ := request.Context[mscalendar.SearchFromKey].(string)
+	toStr, _ := request.Context[mscalendar.SearchToKey].(string)
+	page, _ := request.Context[mscalendar.SearchPageKey].(float64)
+	from, fromErr := time.Parse(time.RFC3339, fromStr)
+	to, toErr := time.Parse(time.RFC3339, toStr)
+	if query == "" || fromErr != nil || toErr != nil || page < 0 {
+		utils.SlackAttachmentError(w, "Error: invalid search")
+		return
+	}
+
+	m := mscalendar.New(api.Env, mattermostUserID)
+	user := mscalendar.NewUser(mattermostUserID)
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to get the time zone of your calendar")
+		return
+	}
+
+	results, err := m.SearchEvents(user, query, from, to, int(page))
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to search events: "+err.Error())
+		return
+	}
+	sa, err := m.NewSearchResultsAttachment(results, timezone)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to render the search results: "+err.Error())
+		return
+	}
+
+	post := &model.Post{}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{sa})
+	postResponse := model.PostActionIntegrationResponse{
+		Update: post,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(postResponse.ToJson())
+}
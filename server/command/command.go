@@ -38,7 +38,18 @@ var cmds = []*model.AutocompleteData{
 	model.NewAutocompleteData("connect", "", "Connect to your Microsoft account"),
 	model.NewAutocompleteData("disconnect", "", "Disconnect from your Microsoft Account"),
 	model.NewAutocompleteData("summary", "", "View your events for today, or edit the settings for your daily summary."),
-	model.NewAutocompleteData("viewcal", "", "View your events for the upcoming week."),
+	model.NewAutocompleteData("viewcal", "[today|tomorrow|week|next week|<date>..<date>] [--calendar name] [--include-declined] [--for @user]", "View your events for the upcoming week, or for a range."),
+	model.NewAutocompleteData("event", "[list|edit|move|room|cancel|thread|unthread|threads]", "Edit, reschedule or cancel the events you organize, or post their threads in a channel."),
+	model.NewAutocompleteData("schedule", "[post ID or permalink]", "Schedule a meeting from a post."),
+	model.NewAutocompleteData("export", "[today|tomorrow|week|next week|<date>..<date>]", "Get your calendar as an iCalendar (.ics) file."),
+	model.NewAutocompleteData("invites", "", "List the invitations you have not responded to."),
+	model.NewAutocompleteData("focus", "[duration|until <time>|stop]", "Block focus time in your calendar, set to Do Not Disturb."),
+	model.NewAutocompleteData("search", "<text> [--from date] [--to date]", "Search your events by subject, organizer or attendee."),
+	model.NewAutocompleteData("free", "@user... [today|tomorrow]", "See when other users are free or busy."),
+	model.NewAutocompleteData("rooms", "[building] [time] [capacity]", "List the meeting rooms that are free."),
+	model.NewAutocompleteData("delegate", "[add|remove|list] [@user]", "Manage the calendar of another user, with their approval."),
+	model.NewAutocompleteData("mute", "[organizer|keyword|list] [value]", "Stop notifications of the events of an organizer, or with a keyword."),
+	model.NewAutocompleteData("unmute", "[organizer|keyword] [value]", "Resume notifications muted with mute."),
 	model.NewAutocompleteData("settings", "", "Edit your user personal settings."),
 	model.NewAutocompleteData("subscribe", "", "Enable notifications for event invitations and updates."),
 	model.NewAutocompleteData("unsubscribe", "", "Disable notifications for event invitations and updates."),
@@ -109,6 +120,28 @@ func (c *Command) Handle() (string, bool, error) {
 		handler = c.requireConnectedUser(c.autoRespond)
 	case "settings":
 		handler = c.requireConnectedUser(c.settings)
+	case "event":
+		handler = c.requireConnectedUser(c.event)
+	case "schedule":
+		handler = c.requireConnectedUser(c.schedule)
+	case "export":
+		handler = c.requireConnectedUser(c.export)
+	case "invites":
+		handler = c.requireConnectedUser(c.invites)
+	case "focus":
+		handler = c.requireConnectedUser(c.focus)
+	case "search":
+		handler = c.requireConnectedUser(c.search)
+	case "free":
+		handler = c.requireConnectedUser(c.free)
+	case "rooms":
+		handler = c.requireConnectedUser(c.rooms)
+	case "delegate":
+		handler = c.requireConnectedUser(c.delegate)
+	case "mute":
+		handler = c.requireConnectedUser(c.mute)
+	case "unmute":
+		handler = c.requireConnectedUser(c.unmute)
 	}
 	out, mustRedirectToDM, err := handler(parameters...)
 	if err != nil {
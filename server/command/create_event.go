@@ -9,8 +9,10 @@ import (
 	"github.com/pkg/errors"
 	flag "github.com/spf13/pflag"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
 )
 
 func getCreateEventFlagSet() *flag.FlagSet {
@@ -19,11 +21,14 @@ func getCreateEventFlagSet() *flag.FlagSet {
 	flagSet.String("test-subject", "", "Subject of the event (no spaces for now)")
 	flagSet.String("test-body", "", "Body of the event (no spaces for now)")
 	flagSet.StringSlice("test-location", nil, "Location of the event <displayName,street,city,state,postalcode,country> (comma separated; no spaces)")
-	flagSet.String("starttime", time.Now().Format(time.RFC3339), "Start time for the event")
+	flagSet.String("starttime", time.Now().Format(time.RFC3339), "Start time for the event, in RFC3339 or like \"3pm\" or \"tomorrow\". The start and end can also be given after the flags, like \"tomorrow 3pm for 45m\"")
 	flagSet.Bool("allday", false, "Set as all day event (starttime/endtime must be set to midnight on different days - 2019-12-19T00:00:00-00:00)")
 	flagSet.Int("reminder", 15, "Reminder (in minutes)")
-	flagSet.String("endtime", time.Now().Add(time.Hour).Format(time.RFC3339), "End time for the event")
+	flagSet.String("endtime", time.Now().Add(time.Hour).Format(time.RFC3339), "End time for the event, in RFC3339 or like \"4pm\"")
 	flagSet.StringSlice("attendees", nil, "A comma separated list of Mattermost UserIDs")
+	flagSet.String("meeting", "", "Add an online meeting: teams, calls (a call in this channel) or custom (the link configured by the admin)")
+	flagSet.String("room", "", "Name of a meeting room to book for the event (see `/mscalendar rooms`)")
+	flagSet.String("for", "", "Create the event on the calendar of a user you are a delegate of, like @user")
 
 	return flagSet
 }
@@ -54,7 +59,42 @@ func (c *Command) createEvent(parameters ...string) (string, bool, error) {
 		return "", false, err
 	}
 
-	calEvent, err := c.MSCalendar.CreateEvent(c.user(), event, mattermostUserIDs)
+	roomName, err := createFlagSet.GetString("room")
+	if err != nil {
+		return "", false, err
+	}
+	if roomName != "" {
+		room, roomErr := c.MSCalendar.GetRoomByName(c.user(), roomName)
+		if roomErr != nil {
+			return roomErr.Error(), false, nil
+		}
+		mscalendar.AddRoomToEvent(event, room)
+	}
+
+	meetingType, err := createFlagSet.GetString("meeting")
+	if err != nil {
+		return "", false, err
+	}
+	err = c.MSCalendar.AddOnlineMeeting(c.user(), event, meetingType, c.Args.ChannelId)
+	if err != nil {
+		return err.Error(), false, nil
+	}
+
+	delegatorName, err := createFlagSet.GetString("for")
+	if err != nil {
+		return "", false, err
+	}
+
+	var calEvent *remote.Event
+	if delegatorName != "" {
+		delegatorID, idErr := c.MSCalendar.GetDelegatorID(c.user(), delegatorName)
+		if idErr != nil {
+			return idErr.Error(), false, nil
+		}
+		calEvent, err = c.MSCalendar.CreateDelegatorEvent(c.user(), delegatorID, event, mattermostUserIDs)
+	} else {
+		calEvent, err = c.MSCalendar.CreateEvent(c.user(), event, mattermostUserIDs)
+	}
 	if err != nil {
 		return "", false, err
 	}
@@ -63,6 +103,23 @@ func (c *Command) createEvent(parameters ...string) (string, bool, error) {
 	return resp, false, nil
 }
 
+// parseCreateTime reads a start or end flag, in RFC3339 or as a time
+// expression.
+func parseCreateTime(value, timeZone string) (*remote.DateTime, error) {
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return &remote.DateTime{
+			DateTime: value,
+			TimeZone: timeZone,
+		}, nil
+	}
+
+	e, err := timeexpr.Parse(value, timeexpr.NowIn(timeZone))
+	if err != nil {
+		return nil, err
+	}
+	return remote.NewDateTime(e.Start, timeZone), nil
+}
+
 func parseCreateArgs(args []string, timeZone string) (*remote.Event, error) {
 	event := &remote.Event{}
 
@@ -123,9 +180,9 @@ func parseCreateArgs(args []string, timeZone string) (*remote.Event, error) {
 	if strings.HasPrefix(startTime, "--") {
 		return nil, errors.New("starttime flag requires an argument")
 	}
-	event.Start = &remote.DateTime{
-		DateTime: startTime,
-		TimeZone: timeZone,
+	event.Start, err = parseCreateTime(startTime, timeZone)
+	if err != nil {
+		return nil, err
 	}
 
 	endTime, err := createFlagSet.GetString("endtime")
@@ -135,9 +192,22 @@ func parseCreateArgs(args []string, timeZone string) (*remote.Event, error) {
 	if strings.HasPrefix(endTime, "--") {
 		return nil, errors.New("endtime flag requires an argument")
 	}
-	event.End = &remote.DateTime{
-		DateTime: endTime,
-		TimeZone: timeZone,
+	event.End, err = parseCreateTime(endTime, timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	if when := createFlagSet.Args(); len(when) > 0 {
+		e, err := timeexpr.Parse(strings.Join(when, " "), timeexpr.NowIn(timeZone))
+		if err != nil {
+			return nil, err
+		}
+		end := e.End
+		if end.IsZero() {
+			end = e.Start.Add(time.Hour)
+		}
+		event.Start = remote.NewDateTime(e.Start, timeZone)
+		event.End = remote.NewDateTime(end, timeZone)
 	}
 
 	allday, err := createFlagSet.GetBool("allday")
@@ -2,16 +2,21 @@ package command
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
 )
 
 const dailySummaryHelp = "### Daily summary commands:\n" +
 	"`/mscalendar summary view` - View your daily summary\n" +
 	"`/mscalendar summary settings` - View your settings for the daily summary\n" +
-	"`/mscalendar summary time 8:00AM` - Set the time you would like to receive your daily summary\n" +
+	"`/mscalendar summary time 8:00AM` - Set the time you would like to receive your daily summary, like `8am` or `17:30`\n" +
 	"`/mscalendar summary enable` - Enable your daily summary\n" +
-	"`/mscalendar summary disable` - Disable your daily summary"
+	"`/mscalendar summary disable` - Disable your daily summary\n" +
+	"`/mscalendar summary weekly [view|enable|disable]` - View, enable or disable your weekly summary, posted on Mondays\n" +
+	"`/mscalendar summary preview [view|enable|disable]` - View, enable or disable your next week preview, posted on Fridays"
 
 const dailySummarySetTimeErrorMessage = "Please enter a time, for example:\n`/mscalendar summary time 8:00AM`"
 
@@ -28,10 +33,14 @@ func (c *Command) dailySummary(parameters ...string) (string, bool, error) {
 		}
 		return postStr, false, nil
 	case "time":
-		if len(parameters) != 2 {
+		if len(parameters) < 2 {
 			return dailySummarySetTimeErrorMessage, false, nil
 		}
-		val := parameters[1]
+		hour, minute, err := timeexpr.ParseTimeOfDay(strings.Join(parameters[1:], " "))
+		if err != nil {
+			return err.Error() + "\n" + dailySummarySetTimeErrorMessage, false, nil
+		}
+		val := time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC).Format(time.Kitchen)
 
 		dsum, err := c.MSCalendar.SetDailySummaryPostTime(c.user(), val)
 		if err != nil {
@@ -59,6 +68,8 @@ func (c *Command) dailySummary(parameters ...string) (string, bool, error) {
 			return err.Error(), false, err
 		}
 		return dailySummaryResponse(dsum), false, nil
+	case "weekly", "preview":
+		return c.weeklySummary(parameters[0] == "preview", parameters[1:]...)
 	default:
 		return "Invalid command. Please try again\n\n" + dailySummaryHelp, false, nil
 	}
@@ -75,3 +86,43 @@ func dailySummaryResponse(dsum *store.DailySummaryUserSettings) string {
 	}
 	return fmt.Sprintf("Your daily summary is configured to show at %s %s%s.", dsum.PostTime, dsum.Timezone, enableStr)
 }
+
+func (c *Command) weeklySummary(nextWeek bool, parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 {
+		return dailySummaryHelp, false, nil
+	}
+
+	setEnabled := c.MSCalendar.SetWeeklySummaryEnabled
+	if nextWeek {
+		setEnabled = c.MSCalendar.SetNextWeekPreviewEnabled
+	}
+
+	switch parameters[0] {
+	case "view":
+		postStr, err := c.MSCalendar.GetWeeklySummaryForUser(c.user(), nextWeek)
+		if err != nil {
+			return err.Error(), false, err
+		}
+		return postStr, false, nil
+	case "enable", "disable":
+		dsum, err := setEnabled(c.user(), parameters[0] == "enable")
+		if err != nil {
+			return err.Error(), false, err
+		}
+		return weeklySummaryResponse(dsum, nextWeek), false, nil
+	default:
+		return "Invalid command. Please try again\n\n" + dailySummaryHelp, false, nil
+	}
+}
+
+func weeklySummaryResponse(dsum *store.DailySummaryUserSettings, nextWeek bool) string {
+	name, day, enabled := "weekly summary", "Mondays", dsum.WeeklyEnable
+	if nextWeek {
+		name, day, enabled = "next week preview", "Fridays", dsum.NextWeekPreviewEnable
+	}
+
+	if !enabled {
+		return fmt.Sprintf("Your %s is disabled.", name)
+	}
+	return fmt.Sprintf("Your %s is configured to show on %s at %s %s.", name, day, dsum.PostTime, dsum.Timezone)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+const delegateHelp = "### Delegate commands:\n" +
+	"`/mscalendar delegate add @user` - Ask the user to let you manage their calendar\n" +
+	"`/mscalendar delegate remove @user` - Stop managing the user's calendar, or stop them managing yours\n" +
+	"`/mscalendar delegate list` - List your delegates, and the users whose calendars you manage\n" +
+	"Once approved, use `--for @user` with `viewcal` and `createevent` to act on their calendar."
+
+func (c *Command) delegate(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return delegateHelp, false, nil
+	}
+
+	switch parameters[0] {
+	case "add":
+		if len(parameters) != 2 {
+			return delegateHelp, false, nil
+		}
+		err := c.MSCalendar.RequestDelegation(c.user(), parameters[1])
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("Your request has been sent to %s. You will be notified once they respond.", parameters[1]), false, nil
+	case "remove":
+		if len(parameters) != 2 {
+			return delegateHelp, false, nil
+		}
+		err := c.MSCalendar.RemoveDelegation(c.user(), parameters[1])
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("The delegate access between you and %s has been removed.", parameters[1]), false, nil
+	case "list":
+		delegates, delegators, err := c.MSCalendar.ListDelegations(c.user())
+		if err != nil {
+			return "", false, err
+		}
+		return "Your delegates: " + formatUsernames(delegates) + "\n" +
+			"You manage the calendars of: " + formatUsernames(delegators), false, nil
+	default:
+		return "Invalid command. Please try again\n\n" + delegateHelp, false, nil
+	}
+}
+
+func formatUsernames(usernames []string) string {
+	if len(usernames) == 0 {
+		return "_none_"
+	}
+	return "@" + strings.Join(usernames, ", @")
+}
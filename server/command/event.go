@@ -0,0 +1,203 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+const eventHelp = "### Event commands:\n" +
+	"`/mscalendar event list` - List the events you organize in the next 7 days, with their IDs\n" +
+	"`/mscalendar event edit <event ID>` - Edit the subject, location and time of an event\n" +
+	"`/mscalendar event move <event ID> <time> [--room <name>]` - Reschedule an event, like `tomorrow 3pm` or `fri 10-11:30am`, keeping its duration unless an end is given\n" +
+	"`/mscalendar event room <event ID> <room name>` - Book a meeting room for an event\n" +
+	"`/mscalendar event cancel <event ID> [message]` - Cancel an event, sending the message to the attendees\n" +
+	"`/mscalendar event thread <event ID> [minutes]` - Post a thread in this channel before each occurrence of an event, 15 minutes before unless given\n" +
+	"`/mscalendar event unthread <event ID>` - Stop posting threads for an event\n" +
+	"`/mscalendar event threads` - List the events with threads posted in channels"
+
+func (c *Command) event(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return eventHelp, false, nil
+	}
+
+	switch parameters[0] {
+	case "list":
+		return c.listOrganizedEvents()
+	case "edit":
+		if len(parameters) != 2 {
+			return eventHelp, false, nil
+		}
+		err := c.MSCalendar.OpenEditEventDialog(c.user(), c.Args.TriggerId, parameters[1], "", false)
+		if err != nil {
+			return "", false, err
+		}
+		return "", false, nil
+	case "move":
+		return c.moveEvent(parameters[1:]...)
+	case "room":
+		if len(parameters) < 3 {
+			return eventHelp, false, nil
+		}
+		event, err := c.MSCalendar.BookRoom(c.user(), parameters[1], strings.Join(parameters[2:], " "))
+		if err == remote.ErrRoomsNotPermitted {
+			return "Error: " + err.Error() + ".", false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if event.Location == nil {
+			return "The room has been booked for the event.", false, nil
+		}
+		return fmt.Sprintf("%s has been booked for the event.", event.Location.DisplayName), false, nil
+	case "cancel":
+		if len(parameters) < 2 {
+			return eventHelp, false, nil
+		}
+		message := strings.Join(parameters[2:], " ")
+		err := c.MSCalendar.CancelEvent(c.user(), parameters[1], message)
+		if err != nil {
+			return "", false, err
+		}
+		return "The event has been cancelled.", false, nil
+	case "thread":
+		return c.bindEventToChannel(parameters[1:]...)
+	case "unthread":
+		if len(parameters) != 2 {
+			return eventHelp, false, nil
+		}
+		binding, err := c.MSCalendar.UnbindEvent(c.user(), parameters[1])
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("Threads will no longer be posted for %s.", binding.Subject), false, nil
+	case "threads":
+		return c.listChannelBindings()
+	default:
+		return "Invalid command. Please try again\n\n" + eventHelp, false, nil
+	}
+}
+
+func (c *Command) bindEventToChannel(parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 && len(parameters) != 2 {
+		return eventHelp, false, nil
+	}
+	leadTime := mscalendar.DefaultMeetingThreadLeadTime
+	if len(parameters) == 2 {
+		minutes, err := strconv.Atoi(parameters[1])
+		if err != nil {
+			return "Invalid number of minutes.\n\n" + eventHelp, false, nil
+		}
+		leadTime = time.Duration(minutes) * time.Minute
+	}
+
+	binding, err := c.MSCalendar.BindEventToChannel(c.user(), parameters[0], c.Args.ChannelId, leadTime)
+	if err != nil {
+		return "", false, err
+	}
+	return fmt.Sprintf("A thread will be posted in this channel %d minutes before each occurrence of %s, with its agenda, attendees and join link. "+
+		"You will be asked for the notes in the thread after the meeting.", int(binding.LeadTime.Minutes()), binding.Subject), false, nil
+}
+
+func (c *Command) listChannelBindings() (string, bool, error) {
+	bindings, err := c.MSCalendar.GetChannelBindings(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	if len(bindings) == 0 {
+		return "No threads are posted for your events. Use `/mscalendar event thread <event ID>` in a channel to post them there.", false, nil
+	}
+
+	resp := "#### Events with threads posted in channels\n"
+	for _, b := range bindings {
+		resp += fmt.Sprintf("- %s: ~%s, %d minutes before\n", b.Subject, b.ChannelName, int(b.LeadTime.Minutes()))
+	}
+	return resp, false, nil
+}
+
+func (c *Command) listOrganizedEvents() (string, bool, error) {
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+
+	events, err := c.MSCalendar.ViewCalendar(c.user(), time.Now(), time.Now().Add(7*24*time.Hour))
+	if err != nil {
+		return "", false, err
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+
+	resp := ""
+	for _, e := range events {
+		if !e.IsOrganizer || e.IsCancelled {
+			continue
+		}
+		start := e.Start.In(timezone).Time().Format(mscalendar.EventTimeLayout)
+		resp += fmt.Sprintf("- %s: %s\n  `%s`\n", start, e.Subject, e.ID)
+	}
+	if resp == "" {
+		return "You are not organizing any events in the next 7 days.", false, nil
+	}
+	return "#### Events you organize in the next 7 days\n" + resp, false, nil
+}
+
+func (c *Command) moveEvent(parameters ...string) (string, bool, error) {
+	roomName := ""
+	for i, p := range parameters {
+		if p == "--room" {
+			roomName = strings.Join(parameters[i+1:], " ")
+			if roomName == "" {
+				return eventHelp, false, nil
+			}
+			parameters = parameters[:i]
+			break
+		}
+	}
+	if len(parameters) < 2 {
+		return eventHelp, false, nil
+	}
+
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+
+	e, err := timeexpr.Parse(strings.Join(parameters[1:], " "), timeexpr.NowIn(timezone))
+	if err != nil {
+		return "Invalid time: " + err.Error() + ".", false, nil
+	}
+	if !e.HasTime {
+		return "Please give the time of day the event moves to, like `tomorrow 3pm`.", false, nil
+	}
+	start, end := e.Start, e.End
+
+	event, err := c.MSCalendar.MoveEvent(c.user(), parameters[0], start, end)
+	if err != nil {
+		return "", false, err
+	}
+	if roomName != "" {
+		event, err = c.MSCalendar.BookRoom(c.user(), parameters[0], roomName)
+		if err != nil {
+			return "The event has been moved, but the room could not be booked: " + err.Error(), false, nil
+		}
+	}
+
+	newStart := event.Start.In(timezone).Time()
+	newEnd := event.End.In(timezone).Time()
+	resp := fmt.Sprintf("The event has been moved to %s - %s.", newStart.Format("Monday, January 02 · "+time.Kitchen), newEnd.Format(time.Kitchen))
+	if roomName != "" && event.Location != nil {
+		resp += fmt.Sprintf(" %s has been booked.", event.Location.DisplayName)
+	}
+	return resp, false, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+const exportHelp = "Please use `/mscalendar export [today|tomorrow|week|next week|<date>..<date>]`, like `/mscalendar export next week`."
+
+func (c *Command) export(parameters ...string) (string, bool, error) {
+	from, to := time.Now().Add(-24*time.Hour), time.Now().Add(14*24*time.Hour)
+	if len(parameters) > 0 {
+		timezone, err := c.MSCalendar.GetTimezone(c.user())
+		if err != nil {
+			return "Error: No timezone found", false, err
+		}
+		e, err := timeexpr.Parse(strings.Join(parameters, " "), timeexpr.NowIn(timezone))
+		if err != nil {
+			return err.Error() + "\n" + exportHelp, false, nil
+		}
+		from, to = e.Start, e.End
+		if to.IsZero() {
+			to = timeexpr.AtClock(from, 0, 0).AddDate(0, 0, 1)
+		}
+	}
+
+	n, err := c.MSCalendar.ExportCalendar(c.user(), from, to)
+	if err != nil {
+		return "", false, err
+	}
+	if n == 0 {
+		return "There are no events to export in this range, so an empty calendar file has been sent to you in a direct message.", true, nil
+	}
+	return "Your calendar file has been sent to you in a direct message.", true, nil
+}
@@ -9,15 +9,46 @@ import (
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
 )
 
 func (c *Command) findMeetings(parameters ...string) (string, bool, error) {
 	meetingParams := &remote.FindMeetingTimesParameters{}
 
 	var attendees []remote.Attendee
-	for a := range parameters {
-		s := strings.Split(parameters[a], ":")
-		t, email := s[0], s[1]
+	var when []string
+	for _, p := range parameters {
+		if p == "--rooms" {
+			suggestLocation := true
+			meetingParams.LocationConstraint = &remote.LocationConstraint{SuggestLocation: &suggestLocation}
+			continue
+		}
+		if strings.HasPrefix(p, "room:") {
+			room, err := c.MSCalendar.GetRoomByName(c.user(), strings.TrimPrefix(p, "room:"))
+			if err != nil {
+				return err.Error(), false, nil
+			}
+			isRequired, resolveAvailability := true, true
+			meetingParams.LocationConstraint = &remote.LocationConstraint{
+				IsRequired: &isRequired,
+				Locations: []remote.LocationConstraintItem{{
+					Location: &remote.Location{
+						DisplayName:          room.DisplayName,
+						LocationEmailAddress: room.EmailAddress,
+					},
+					ResolveAvailability: &resolveAvailability,
+				}},
+			}
+			continue
+		}
+		if !strings.Contains(p, "@") {
+			when = append(when, p)
+			continue
+		}
+		t, email := "required", p
+		if s := strings.SplitN(p, ":", 2); len(s) == 2 {
+			t, email = s[0], s[1]
+		}
 		attendee := remote.Attendee{
 			Type: t,
 			EmailAddress: &remote.EmailAddress{
@@ -28,12 +59,51 @@ func (c *Command) findMeetings(parameters ...string) (string, bool, error) {
 	}
 	meetingParams.Attendees = attendees
 
+	// The duration is the length of the meeting, not of the range to search in
+	for i, token := range when {
+		if strings.ToLower(token) != "for" {
+			continue
+		}
+		duration, err := timeexpr.ParseDuration(timeexpr.Tokenize(strings.Join(when[i+1:], " ")))
+		if err != nil {
+			return err.Error(), false, nil
+		}
+		meetingParams.MeetingDuration = &duration
+		when = when[:i]
+		break
+	}
+
+	timeZone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+	if len(when) > 0 {
+		e, err := timeexpr.Parse(strings.Join(when, " "), timeexpr.NowIn(timeZone))
+		if err != nil {
+			return err.Error(), false, nil
+		}
+		if e.HasTime && e.End.IsZero() {
+			return "Please give a range to find meeting times in, like `tomorrow 9am-5pm` or `fri`, optionally with a duration like `for 45m`.", false, nil
+		}
+
+		activityDomain := "work"
+		if e.HasTime {
+			activityDomain = "unrestricted"
+		}
+		meetingParams.TimeConstraint = &remote.TimeConstraint{
+			ActivityDomain: activityDomain,
+			TimeSlots: []remote.TimeSlot{{
+				Start: remote.NewDateTime(e.Start.UTC(), "UTC"),
+				End:   remote.NewDateTime(e.End.UTC(), "UTC"),
+			}},
+		}
+	}
+
 	meetings, err := c.MSCalendar.FindMeetingTimes(c.user(), meetingParams)
 	if err != nil {
 		return "", false, err
 	}
 
-	timeZone, _ := c.MSCalendar.GetTimezone(c.user())
 	resp := ""
 	for _, m := range meetings.MeetingTimeSuggestions {
 		if timeZone != "" {
@@ -49,5 +119,15 @@ func (c *Command) findMeetings(parameters ...string) (string, bool, error) {
 func renderMeetingTime(m *remote.MeetingTimeSuggestion) string {
 	start := m.MeetingTimeSlot.Start.PrettyString()
 	end := m.MeetingTimeSlot.End.PrettyString()
-	return fmt.Sprintf("%s - %s (%s)", start, end, m.MeetingTimeSlot.Start.TimeZone)
+	out := fmt.Sprintf("%s - %s (%s)", start, end, m.MeetingTimeSlot.Start.TimeZone)
+	rooms := []string{}
+	for _, l := range m.Locations {
+		if l != nil && l.DisplayName != "" {
+			rooms = append(rooms, l.DisplayName)
+		}
+	}
+	if len(rooms) > 0 {
+		out += " in " + strings.Join(rooms, ", ")
+	}
+	return out
 }
@@ -0,0 +1,74 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+const focusHelp = "Please use `/mscalendar focus [duration|until <time>]`, like `/mscalendar focus 90m` or `/mscalendar focus until 3pm`, or `/mscalendar focus stop` to end it early. Focus time lasts an hour by default.\nYou can have focus time booked every day in `/mscalendar settings`."
+
+func (c *Command) focus(parameters ...string) (string, bool, error) {
+	if len(parameters) == 1 && parameters[0] == "stop" {
+		stopped, err := c.MSCalendar.StopFocusTime(c.user())
+		if err != nil {
+			return "", false, err
+		}
+		if !stopped {
+			return "You are not in focus time.", false, nil
+		}
+		return "Your focus time has ended.", false, nil
+	}
+
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+	now := timeexpr.NowIn(timezone)
+
+	end, err := parseFocusEnd(parameters, now)
+	if err != nil {
+		return err.Error() + "\n" + focusHelp, false, nil
+	}
+
+	_, err = c.MSCalendar.StartFocusTime(c.user(), now, end)
+	if err != nil {
+		return "", false, err
+	}
+	return fmt.Sprintf("You are in focus time until %s. Your status is set to Do Not Disturb, and DMs are answered automatically until then.", end.Format(time.Kitchen)), false, nil
+}
+
+// parseFocusEnd reads when the focus time starting now ends, from a duration
+// like "90m" or a time like "until 3pm".
+func parseFocusEnd(parameters []string, now time.Time) (time.Time, error) {
+	if len(parameters) == 0 {
+		return now.Add(mscalendar.DefaultFocusDuration), nil
+	}
+	if parameters[0] != "until" {
+		d, err := timeexpr.ParseDuration(parameters)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+
+	e, err := timeexpr.Parse(strings.Join(parameters[1:], " "), now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !e.HasTime {
+		return time.Time{}, errors.New("please give a time of day, like `until 3pm`")
+	}
+	if !e.Start.After(now) {
+		return time.Time{}, errors.New("please give a time in the future")
+	}
+	return e.Start, nil
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFocusEnd(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	now := time.Date(2020, 3, 11, 10, 17, 30, 0, loc)
+
+	tcs := []struct {
+		expr          string
+		end           time.Time
+		expectedError string
+	}{
+		{expr: "", end: now.Add(time.Hour)},
+		{expr: "90m", end: now.Add(90 * time.Minute)},
+		{expr: "2 hours", end: now.Add(2 * time.Hour)},
+		{expr: "until 3pm", end: time.Date(2020, 3, 11, 15, 0, 0, 0, loc)},
+		{expr: "until 9am", expectedError: "please give a time in the future"},
+		{expr: "until fri", expectedError: "please give a time of day, like `until 3pm`"},
+		{expr: "soon", expectedError: `"soon" is not a valid duration, please use a duration like 45m or 1h30m`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.expr, func(t *testing.T) {
+			end, err := parseFocusEnd(strings.Fields(tc.expr), now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tc.end.Equal(end), "expected %v, got %v", tc.end, end)
+		})
+	}
+}
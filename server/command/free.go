@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+const freeHelp = "Please use `/mscalendar free @user... [today|tomorrow|<day>|<time range>]`, like `/mscalendar free @alice @bob tomorrow` or `/mscalendar free @alice fri 1pm-5pm`."
+
+// The timeline of a day covers the working hours.
+const (
+	freeDayStartHour = 8
+	freeDayEndHour   = 18
+)
+
+func (c *Command) free(parameters ...string) (string, bool, error) {
+	usernames := []string{}
+	when := []string{}
+	for _, p := range parameters {
+		if strings.HasPrefix(p, "@") {
+			usernames = append(usernames, p)
+		} else {
+			when = append(when, p)
+		}
+	}
+	if len(usernames) == 0 {
+		return freeHelp, false, nil
+	}
+
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+	now := timeexpr.NowIn(timezone)
+
+	start, end, err := parseFreeRange(strings.Join(when, " "), now)
+	if err != nil {
+		return err.Error() + "\n" + freeHelp, false, nil
+	}
+
+	rows, err := c.MSCalendar.GetFreeBusy(c.user(), usernames, start, end)
+	if err != nil {
+		return "", false, err
+	}
+
+	numBlocks := int(end.Sub(start) / mscalendar.FreeBusyInterval)
+	resp := "#### Availability on " + start.Format("Monday, January 02") + "\n" +
+		views.RenderFreeBusy(rows, start, mscalendar.FreeBusyInterval, numBlocks, timezone)
+
+	slotStart, slotEnd, found := mscalendar.NextCommonFreeSlot(rows, start, mscalendar.FreeBusyInterval, now)
+	if found {
+		resp += "\n\n**Next common free slot:** " + slotStart.Format(time.Kitchen) + " - " + slotEnd.Format(time.Kitchen)
+	} else {
+		resp += "\n\nThere is no common free slot in this range."
+	}
+	return resp, false, nil
+}
+
+// parseFreeRange reads the range of the timeline, which must be within a
+// day. Days cover the working hours, and times are rounded to whole blocks.
+func parseFreeRange(expr string, now time.Time) (start, end time.Time, err error) {
+	if expr == "" {
+		expr = "today"
+	}
+	e, err := timeexpr.Parse(expr, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if !e.HasTime {
+		if !e.End.Equal(e.Start.AddDate(0, 0, 1)) {
+			return time.Time{}, time.Time{}, errors.New("please choose a single day")
+		}
+		return timeexpr.AtClock(e.Start, freeDayStartHour, 0), timeexpr.AtClock(e.Start, freeDayEndHour, 0), nil
+	}
+	if e.End.IsZero() {
+		return time.Time{}, time.Time{}, errors.New("please give a range of times, like `1pm-5pm`")
+	}
+	if e.End.Sub(e.Start) > 24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("please choose a range within a day")
+	}
+
+	start = e.Start.Truncate(mscalendar.FreeBusyInterval)
+	end = e.End.Truncate(mscalendar.FreeBusyInterval)
+	if end.Before(e.End) {
+		end = end.Add(mscalendar.FreeBusyInterval)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"time"
+)
+
+func (c *Command) invites(parameters ...string) (string, bool, error) {
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+
+	events, err := c.MSCalendar.GetPendingInvitations(c.user(), time.Now())
+	if err != nil {
+		return "", false, err
+	}
+	if len(events) == 0 {
+		return "You have no pending invitations for the upcoming week.", false, nil
+	}
+
+	// The response selectors need a post of the bot, so the invitations are
+	// sent in a direct message
+	err = c.MSCalendar.PostPendingInvitations(c.user(), events, c.Args.TeamId, timezone)
+	if err != nil {
+		return "", false, err
+	}
+	return "Your pending invitations have been sent to you in a direct message.", true, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+const muteHelp = "### Mute commands:\n" +
+	"`/mscalendar mute organizer <email>` - Stop notifications of the events organized by this address\n" +
+	"`/mscalendar mute keyword <keyword>` - Stop notifications of the events with this keyword in their subject\n" +
+	"`/mscalendar unmute organizer <email>` or `/mscalendar unmute keyword <keyword>` - Resume these notifications\n" +
+	"`/mscalendar mute list` - List the muted organizers and keywords"
+
+func (c *Command) mute(parameters ...string) (string, bool, error) {
+	return c.setMuted(true, parameters...)
+}
+
+func (c *Command) unmute(parameters ...string) (string, bool, error) {
+	return c.setMuted(false, parameters...)
+}
+
+func (c *Command) setMuted(muted bool, parameters ...string) (string, bool, error) {
+	if len(parameters) == 1 && parameters[0] == "list" {
+		filters, err := c.MSCalendar.GetNotificationFilters(c.user())
+		if err != nil {
+			return "", false, err
+		}
+		return filters.MutedString(), false, nil
+	}
+	if len(parameters) < 2 {
+		return muteHelp, false, nil
+	}
+
+	value := strings.Join(parameters[1:], " ")
+	var err error
+	switch parameters[0] {
+	case "organizer":
+		err = c.MSCalendar.MuteOrganizer(c.user(), value, muted)
+	case "keyword":
+		err = c.MSCalendar.MuteKeyword(c.user(), value, muted)
+	default:
+		return "Invalid command. Please try again\n\n" + muteHelp, false, nil
+	}
+	if err != nil {
+		return fmt.Sprintf("Failed to update the muted notifications: %v", err), false, nil
+	}
+
+	if muted {
+		return fmt.Sprintf("You will no longer be notified about the events of %s `%s`.", parameters[0], value), false, nil
+	}
+	return fmt.Sprintf("You will be notified again about the events of %s `%s`.", parameters[0], value), false, nil
+}
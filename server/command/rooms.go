@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+const roomsHelp = "Please use `/mscalendar rooms [building] [time] [capacity]`, like `/mscalendar rooms HQ tomorrow 2pm-3pm 6`. " +
+	"Without a time, the rooms free for the next 30 minutes are listed.\n" +
+	"Book a room with `/mscalendar createevent --room <name>` or `/mscalendar event room <event ID> <name>`."
+
+// defaultRoomDuration is how long a room must be free when only a start time
+// is given.
+const defaultRoomDuration = 30 * time.Minute
+
+func (c *Command) rooms(parameters ...string) (string, bool, error) {
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+
+	building, e, capacity, err := parseRoomsArgs(parameters, timeexpr.NowIn(timezone))
+	if err != nil {
+		return err.Error() + "\n" + roomsHelp, false, nil
+	}
+
+	rooms, err := c.MSCalendar.FindFreeRooms(c.user(), building, e.Start, e.End, capacity)
+	if err == remote.ErrRoomsNotPermitted {
+		return "Error: " + err.Error() + ".", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	when := timeexpr.Format(e)
+	if len(rooms) == 0 {
+		return fmt.Sprintf("No rooms are free on %s.", when), false, nil
+	}
+
+	resp := fmt.Sprintf("#### Rooms free on %s\n", when)
+	for _, room := range rooms {
+		details := []string{}
+		if room.Capacity > 0 {
+			details = append(details, fmt.Sprintf("%d seats", room.Capacity))
+		}
+		if room.Building != "" {
+			details = append(details, room.Building)
+		}
+		if room.FloorLabel != "" {
+			details = append(details, "floor "+room.FloorLabel)
+		}
+		line := "- **" + room.DisplayName + "**"
+		if len(details) > 0 {
+			line += " · " + strings.Join(details, " · ")
+		}
+		resp += line + "\n"
+	}
+	return resp, false, nil
+}
+
+// parseRoomsArgs reads the optional building, time and capacity of the rooms
+// command. A trailing number is the capacity, and the building is whatever
+// comes before the longest time expression at the end.
+func parseRoomsArgs(parameters []string, now time.Time) (building string, e *timeexpr.Expression, capacity int, err error) {
+	tokens := parameters
+	if len(tokens) > 0 {
+		if n, convErr := strconv.Atoi(tokens[len(tokens)-1]); convErr == nil {
+			if n <= 0 {
+				return "", nil, 0, errors.New("the capacity must be a positive number")
+			}
+			capacity = n
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+
+	e = &timeexpr.Expression{Start: now, End: now.Add(defaultRoomDuration), HasTime: true}
+	buildingTokens := tokens
+	for i := 0; i < len(tokens); i++ {
+		parsed, parseErr := timeexpr.Parse(strings.Join(tokens[i:], " "), now)
+		if parseErr != nil {
+			continue
+		}
+		e = parsed
+		buildingTokens = tokens[:i]
+		break
+	}
+	if e.End.IsZero() {
+		e.End = e.Start.Add(defaultRoomDuration)
+	}
+
+	building = strings.Trim(strings.Join(buildingTokens, " "), `"`)
+	return building, e, capacity, nil
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoomsArgs(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// A Wednesday
+	now := time.Date(2020, 3, 11, 10, 17, 30, 0, loc)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2020, 3, day, hour, minute, 0, 0, loc)
+	}
+
+	tcs := []struct {
+		name          string
+		parameters    []string
+		building      string
+		start         time.Time
+		end           time.Time
+		capacity      int
+		expectedError string
+	}{
+		{name: "No arguments", start: now, end: now.Add(30 * time.Minute)},
+		{name: "Building only", parameters: []string{"HQ"}, building: "HQ", start: now, end: now.Add(30 * time.Minute)},
+		{name: "Building and capacity", parameters: []string{"HQ", "8"}, building: "HQ", start: now, end: now.Add(30 * time.Minute), capacity: 8},
+		{name: "Building with spaces", parameters: []string{"Building", "A", "tomorrow", "2pm-3pm"}, building: "Building A", start: at(12, 14, 0), end: at(12, 15, 0)},
+		{name: "Time without end", parameters: []string{"tomorrow", "2pm", "4"}, start: at(12, 14, 0), end: at(12, 14, 30), capacity: 4},
+		{name: "Whole day", parameters: []string{"HQ", "fri"}, building: "HQ", start: at(13, 0, 0), end: at(14, 0, 0)},
+		{name: "Invalid capacity", parameters: []string{"HQ", "0"}, expectedError: "the capacity must be a positive number"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			building, e, capacity, err := parseRoomsArgs(tc.parameters, now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.building, building)
+			require.Equal(t, tc.start, e.Start)
+			require.Equal(t, tc.end, e.End)
+			require.Equal(t, tc.capacity, capacity)
+		})
+	}
+}
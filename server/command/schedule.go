@@ -0,0 +1,27 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"strings"
+)
+
+// schedule opens the dialog to schedule a meeting about a post. It backs the
+// "Schedule meeting from this post" post menu action.
+func (c *Command) schedule(parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 {
+		return "Please specify the post to schedule a meeting from, for example:\n`/mscalendar schedule <post ID or permalink>`", false, nil
+	}
+
+	postID := parameters[0]
+	if i := strings.LastIndex(postID, "/pl/"); i >= 0 {
+		postID = postID[i+len("/pl/"):]
+	}
+
+	err := c.MSCalendar.OpenCreateEventFromPostDialog(c.user(), c.Args.TriggerId, postID)
+	if err != nil {
+		return "", false, err
+	}
+	return "", false, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+const searchHelp = "Please use `/mscalendar search <text> [--from <date>] [--to <date>]`, like `/mscalendar search planning --from today --to next week`. " +
+	"The text is searched in the subject, description, location, organizer and attendees of your events."
+
+// By default, events from a month ago to three months ahead are searched.
+const (
+	searchDefaultPast   = 30 * 24 * time.Hour
+	searchDefaultFuture = 90 * 24 * time.Hour
+)
+
+func (c *Command) search(parameters ...string) (string, bool, error) {
+	timezone, err := c.MSCalendar.GetTimezone(c.user())
+	if err != nil {
+		return "Error: No timezone found", false, err
+	}
+
+	query, from, to, err := parseSearchArgs(parameters, timeexpr.NowIn(timezone))
+	if err != nil {
+		return err.Error() + "\n" + searchHelp, false, nil
+	}
+
+	results, err := c.MSCalendar.SearchEvents(c.user(), query, from, to, 0)
+	if err != nil {
+		return "", false, err
+	}
+	if len(results.Events) == 0 {
+		out := fmt.Sprintf("No events matching \"%s\" were found.", query)
+		if results.Incomplete {
+			out += " " + mscalendar.SearchIncompleteNote
+		}
+		return out, false, nil
+	}
+	if !results.HasMore {
+		out, err := views.RenderCalendarView(results.Events, timezone)
+		if err != nil {
+			return "", false, err
+		}
+		if results.Incomplete {
+			out += "\n" + mscalendar.SearchIncompleteNote
+		}
+		return out, false, nil
+	}
+
+	// Pages of results need buttons, so they are sent in a direct message
+	err = c.MSCalendar.PostSearchResults(c.user(), results, timezone)
+	if err != nil {
+		return "", false, err
+	}
+	return "The search results have been sent to you in a direct message.", true, nil
+}
+
+// parseSearchArgs reads the search text and the optional --from and --to
+// dates, which take time expressions.
+func parseSearchArgs(parameters []string, now time.Time) (query string, from, to time.Time, err error) {
+	from, to = now.Add(-searchDefaultPast), now.Add(searchDefaultFuture)
+
+	text := []string{}
+	for i := 0; i < len(parameters); i++ {
+		flag := parameters[i]
+		if flag != "--from" && flag != "--to" {
+			if strings.HasPrefix(flag, "--") {
+				return "", time.Time{}, time.Time{}, errors.Errorf("unknown option `%s`", flag)
+			}
+			text = append(text, flag)
+			continue
+		}
+
+		expr := []string{}
+		for i+1 < len(parameters) && !strings.HasPrefix(parameters[i+1], "--") {
+			i++
+			expr = append(expr, parameters[i])
+		}
+		if len(expr) == 0 {
+			return "", time.Time{}, time.Time{}, errors.Errorf("please enter a date after `%s`", flag)
+		}
+		e, parseErr := timeexpr.Parse(strings.Join(expr, " "), now)
+		if parseErr != nil {
+			return "", time.Time{}, time.Time{}, parseErr
+		}
+		if flag == "--from" {
+			from = e.Start
+		} else {
+			to = e.End
+			if to.IsZero() {
+				to = e.Start
+			}
+		}
+	}
+
+	query = strings.Trim(strings.Join(text, " "), `"`)
+	if query == "" {
+		return "", time.Time{}, time.Time{}, errors.New("please enter the text to search for")
+	}
+	if !to.After(from) {
+		return "", time.Time{}, time.Time{}, errors.New("the end of the search range must be after its start")
+	}
+	return query, from, to, nil
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchArgs(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// A Wednesday
+	now := time.Date(2020, 3, 11, 10, 17, 30, 0, loc)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2020, 3, day, hour, minute, 0, 0, loc)
+	}
+
+	tcs := []struct {
+		name          string
+		parameters    []string
+		query         string
+		from          time.Time
+		to            time.Time
+		expectedError string
+	}{
+		{name: "Text only", parameters: []string{"planning"}, query: "planning", from: now.Add(-searchDefaultPast), to: now.Add(searchDefaultFuture)},
+		{name: "Quoted text", parameters: []string{`"sprint`, `review"`}, query: "sprint review", from: now.Add(-searchDefaultPast), to: now.Add(searchDefaultFuture)},
+		{name: "From and to days", parameters: []string{"planning", "--from", "2020-03-01", "--to", "fri"}, query: "planning", from: time.Date(2020, 3, 1, 0, 0, 0, 0, loc), to: at(14, 0, 0)},
+		{name: "To a time", parameters: []string{"planning", "--from", "today", "--to", "tomorrow", "2pm"}, query: "planning", from: at(11, 0, 0), to: at(12, 14, 0)},
+		{name: "To next week", parameters: []string{"planning", "--to", "next", "week"}, query: "planning", from: now.Add(-searchDefaultPast), to: at(23, 0, 0)},
+		{name: "Missing text", parameters: []string{"--from", "mon"}, expectedError: "please enter the text to search for"},
+		{name: "Missing date", parameters: []string{"planning", "--to"}, expectedError: "please enter a date after `--to`"},
+		{name: "Unknown option", parameters: []string{"planning", "--at", "mon"}, expectedError: "unknown option `--at`"},
+		{name: "Range in the wrong order", parameters: []string{"planning", "--from", "mon", "--to", "fri"}, expectedError: "the end of the search range must be after its start"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			query, from, to, err := parseSearchArgs(tc.parameters, now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.query, query)
+			require.Equal(t, tc.from, from)
+			require.Equal(t, tc.to, to)
+		})
+	}
+}
@@ -4,22 +4,118 @@
 package command
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
 )
 
+const viewCalendarHelp = "Please use `/mscalendar viewcal [today|tomorrow|week|next week|<date>..<date>] [--calendar name] [--include-declined] [--for @user]`."
+
 func (c *Command) viewCalendar(parameters ...string) (string, bool, error) {
 	tz, err := c.MSCalendar.GetTimezone(c.user())
 	if err != nil {
 		return "Error: No timezone found", false, err
 	}
 
-	events, err := c.MSCalendar.ViewCalendar(c.user(), time.Now().Add(-24*time.Hour), time.Now().Add(14*24*time.Hour))
+	when := []string{}
+	calendarName := ""
+	delegatorName := ""
+	includeDeclined := false
+	for i := 0; i < len(parameters); i++ {
+		switch parameters[i] {
+		case "--include-declined":
+			includeDeclined = true
+		case "--for":
+			if i+1 >= len(parameters) {
+				return "Please enter the user whose calendar you manage.\n" + viewCalendarHelp, false, nil
+			}
+			i++
+			delegatorName = parameters[i]
+		case "--calendar":
+			name := []string{}
+			for i+1 < len(parameters) && !strings.HasPrefix(parameters[i+1], "--") {
+				i++
+				name = append(name, parameters[i])
+			}
+			calendarName = strings.Trim(strings.Join(name, " "), `"`)
+			if calendarName == "" {
+				return "Please enter the name of the calendar.\n" + viewCalendarHelp, false, nil
+			}
+		default:
+			if strings.HasPrefix(parameters[i], "--") {
+				return fmt.Sprintf("Unknown option `%s`.\n%s", parameters[i], viewCalendarHelp), false, nil
+			}
+			when = append(when, parameters[i])
+		}
+	}
+
+	from, to := time.Now().Add(-24*time.Hour), time.Now().Add(14*24*time.Hour)
+	if len(when) > 0 {
+		e, err := timeexpr.Parse(strings.Join(when, " "), timeexpr.NowIn(tz))
+		if err != nil {
+			return err.Error() + "\n" + viewCalendarHelp, false, nil
+		}
+		from, to = e.Start, e.End
+		if to.IsZero() {
+			to = timeexpr.AtClock(from, 0, 0).AddDate(0, 0, 1)
+		}
+	}
+
+	var events []*remote.Event
+	switch {
+	case delegatorName != "":
+		if calendarName != "" {
+			return "`--calendar` cannot be used with `--for`.\n" + viewCalendarHelp, false, nil
+		}
+		delegatorID, idErr := c.MSCalendar.GetDelegatorID(c.user(), delegatorName)
+		if idErr != nil {
+			return idErr.Error(), false, nil
+		}
+		events, err = c.MSCalendar.ViewDelegatorCalendar(c.user(), delegatorID, from, to)
+	case calendarName == "":
+		events, err = c.MSCalendar.ViewCalendar(c.user(), from, to)
+	default:
+		calendarID, found, findErr := c.findCalendarID(calendarName)
+		if findErr != nil {
+			return "", false, findErr
+		}
+		if !found {
+			return fmt.Sprintf("Calendar `%s` was not found. See your calendars with `/mscalendar showcals`.", calendarName), false, nil
+		}
+		events, err = c.MSCalendar.ViewCalendarByID(c.user(), calendarID, from, to)
+	}
 	if err != nil {
 		return "", false, err
 	}
 
+	if !includeDeclined {
+		attending := []*remote.Event{}
+		for _, e := range events {
+			if e.ResponseStatus == nil || e.ResponseStatus.Response != "declined" {
+				attending = append(attending, e)
+			}
+		}
+		events = attending
+	}
+
 	out, err := views.RenderCalendarView(events, tz)
 	return out, false, err
 }
+
+// findCalendarID finds the user's calendar by name, ignoring case.
+func (c *Command) findCalendarID(name string) (string, bool, error) {
+	calendars, err := c.MSCalendar.GetCalendars(c.user())
+	if err != nil {
+		return "", false, err
+	}
+	for _, cal := range calendars {
+		if strings.EqualFold(cal.Name, name) {
+			return cal.ID, true, nil
+		}
+	}
+	return "", false, nil
+}
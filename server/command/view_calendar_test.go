@@ -0,0 +1,103 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_mscalendar"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+func TestViewCalendar(t *testing.T) {
+	start := time.Date(2020, 3, 11, 9, 0, 0, 0, time.UTC)
+	events := func() []*remote.Event {
+		return []*remote.Event{
+			{
+				Subject:  "Planning",
+				Start:    remote.NewDateTime(start, "UTC"),
+				End:      remote.NewDateTime(start.Add(time.Hour), "UTC"),
+				ShowAs:   "tentative",
+				Location: &remote.Location{DisplayName: "Room 1"},
+				OnlineMeeting: &remote.OnlineMeetingInfo{
+					JoinURL: "https://teams.microsoft.com/l/meetup-join/1",
+				},
+			},
+			{
+				Subject:        "Declined",
+				Start:          remote.NewDateTime(start.Add(2*time.Hour), "UTC"),
+				End:            remote.NewDateTime(start.Add(3*time.Hour), "UTC"),
+				ResponseStatus: &remote.EventResponseStatus{Response: "declined"},
+			},
+		}
+	}
+
+	tcs := []struct {
+		name           string
+		command        string
+		setup          func(m *mock_mscalendar.MockMSCalendar)
+		expectedOutput string
+	}{
+		{
+			name:    "calendar by name, declined hidden",
+			command: "viewcal tomorrow --calendar on-call",
+			setup: func(m *mock_mscalendar.MockMSCalendar) {
+				m.EXPECT().GetCalendars(gomock.Any()).Return([]*remote.Calendar{{ID: "cal1", Name: "Calendar"}, {ID: "cal2", Name: "On-call"}}, nil)
+				m.EXPECT().ViewCalendarByID(gomock.Any(), "cal2", gomock.Any(), gomock.Any()).DoAndReturn(func(_ *mscalendar.User, _ string, from, to time.Time) ([]*remote.Event, error) {
+					require.Equal(t, 24*time.Hour, to.Sub(from))
+					return events(), nil
+				})
+			},
+			expectedOutput: "Times are shown in UTC\nWednesday March 11\n\n| Time | Subject |\n| :--: | :-- |\n| 9:00AM - 10:00AM | [Planning]() · _tentative_ · Room 1 · [Join](https://teams.microsoft.com/l/meetup-join/1) |",
+		},
+		{
+			name:    "include declined",
+			command: "viewcal 2020-03-11..2020-03-12 --include-declined",
+			setup: func(m *mock_mscalendar.MockMSCalendar) {
+				m.EXPECT().ViewCalendar(gomock.Any(), time.Date(2020, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2020, 3, 13, 0, 0, 0, 0, time.UTC)).Return(events(), nil)
+			},
+			expectedOutput: "Times are shown in UTC\nWednesday March 11\n\n| Time | Subject |\n| :--: | :-- |\n| 9:00AM - 10:00AM | [Planning]() · _tentative_ · Room 1 · [Join](https://teams.microsoft.com/l/meetup-join/1) |\n| 11:00AM - 12:00PM | [Declined]() · _declined_ |",
+		},
+		{
+			name:    "unknown calendar",
+			command: "viewcal --calendar Personal",
+			setup: func(m *mock_mscalendar.MockMSCalendar) {
+				m.EXPECT().GetCalendars(gomock.Any()).Return([]*remote.Calendar{{ID: "cal1", Name: "Calendar"}}, nil)
+			},
+			expectedOutput: "Calendar `Personal` was not found. See your calendars with `/mscalendar showcals`.",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mscal := mock_mscalendar.NewMockMSCalendar(ctrl)
+			mscal.EXPECT().GetRemoteUser("user_id").Return(&remote.User{}, nil)
+			mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).AnyTimes()
+			tc.setup(mscal)
+
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: "/mscalendar " + tc.command,
+					UserId:  "user_id",
+				},
+				ChannelID:  "channel_id",
+				Config:     &config.Config{},
+				MSCalendar: mscal,
+			}
+
+			out, _, err := command.Handle()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out)
+		})
+	}
+}
@@ -12,6 +12,11 @@ type StoredConfig struct {
 	EnableStatusSync   bool
 	EnableDailySummary bool
 
+	// MeetingURLTemplate is the link added to events with a custom meeting.
+	// {random} is replaced by a random ID, and {username} by the username of
+	// the organizer.
+	MeetingURLTemplate string
+
 	bot.Config
 }
 
@@ -24,6 +24,21 @@ const (
 	PathDecline               = "/decline"
 	PathTentative             = "/tentative"
 	PathConfirmStatusChange   = "/confirm"
+	PathJoinCall              = "/call"
+	PathSnoozeReminder        = "/snooze"
+	PathDismissReminder       = "/dismiss"
+	PathRespondToConflict     = "/respond-conflict"
+	PathEditEvent             = "/edit-event"
+	PathRescheduleEvent       = "/reschedule-event"
+	PathCancelEvent           = "/cancel-event"
+	PathCreateEventFromPost   = "/create-event-from-post"
+	PathDelegation            = "/delegation"
+	PathRespondAsDelegate     = "/respond-delegate"
+	PathSearchEvents          = "/search-events"
+	PathImportICS             = "/import-ics"
+	PathNudgeAttendees        = "/nudge-attendees"
+	PathAcceptTeamInvites     = "/accept-team-invites"
+	PathEditMuted             = "/edit-muted"
 	PathNotification          = "/notification/v1"
 	PathEvent                 = "/event"
 
@@ -31,4 +46,10 @@ const (
 	FullPathOAuth2Redirect    = PathOAuth2 + PathComplete
 
 	EventIDKey = "EventID"
+	OptionKey  = "Option"
+
+	DelegateIDKey  = "DelegateID"
+	DelegatorIDKey = "DelegatorID"
+
+	TeamIDKey = "TeamID"
 )
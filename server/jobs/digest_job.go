@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+)
+
+// Unique id for the notification digest job
+const digestJobID = "notification_digest"
+
+// NewDigestJob creates a RegisteredJob with the parameters specific to the DigestJob
+func NewDigestJob() RegisteredJob {
+	return RegisteredJob{
+		id:       digestJobID,
+		interval: mscalendar.DigestJobInterval,
+		work:     runDigestJob,
+	}
+}
+
+// runDigestJob posts the notification digests that are due
+func runDigestJob(env mscalendar.Env) {
+	env.Logger.Debugf("Notification digest job beginning")
+
+	err := mscalendar.New(env, "").ProcessAllDigests(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during notification digest job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Notification digest job finished")
+}
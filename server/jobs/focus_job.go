@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+)
+
+// Unique id for the focus time job
+const focusJobID = "focus_time"
+
+// NewFocusJob creates a RegisteredJob with the parameters specific to the FocusJob
+func NewFocusJob() RegisteredJob {
+	return RegisteredJob{
+		id:       focusJobID,
+		interval: mscalendar.FocusJobInterval,
+		work:     runFocusJob,
+	}
+}
+
+// runFocusJob starts and ends focus sessions, and books the daily focus time
+func runFocusJob(env mscalendar.Env) {
+	env.Logger.Debugf("Focus time job beginning")
+
+	err := mscalendar.New(env, "").ProcessAllFocusTime(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during focus time job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Focus time job finished")
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+)
+
+// Unique id for the weekly summary job
+const weeklySummaryJobID = "weekly_summary"
+
+// NewWeeklySummaryJob creates a RegisteredJob with the parameters specific to the WeeklySummaryJob
+func NewWeeklySummaryJob() RegisteredJob {
+	return RegisteredJob{
+		id:       weeklySummaryJobID,
+		interval: dailySummaryJobInterval,
+		work:     runWeeklySummaryJob,
+	}
+}
+
+// runWeeklySummaryJob delivers the weekly summary and the next week preview to all users who have their settings configured to receive them now
+func runWeeklySummaryJob(env mscalendar.Env) {
+	env.Logger.Debugf("Weekly summary job beginning")
+
+	err := mscalendar.New(env, "").ProcessAllWeeklySummary(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during weekly summary job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Weekly summary job finished")
+}
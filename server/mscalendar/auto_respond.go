@@ -4,6 +4,8 @@
 package mscalendar
 
 import (
+	"time"
+
 	"github.com/mattermost/mattermost-server/v5/model"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
@@ -41,7 +43,15 @@ func (m *mscalendar) HandleBusyDM(post *model.Post) error {
 		}
 	}
 
-	if storedRecipient == nil || !storedRecipient.Settings.AutoRespond || len(storedRecipient.ActiveEvents) == 0 {
+	if storedRecipient == nil {
+		return nil
+	}
+	focus, err := m.Store.LoadFocusTime(storedRecipient.MattermostUserID)
+	if err != nil {
+		return err
+	}
+	inFocusTime := isInFocusTime(focus, time.Now())
+	if !inFocusTime && (!storedRecipient.Settings.AutoRespond || len(storedRecipient.ActiveEvents) == 0) {
 		return nil
 	}
 
@@ -54,7 +64,10 @@ func (m *mscalendar) HandleBusyDM(post *model.Post) error {
 	}
 
 	message := storedRecipient.Settings.AutoRespondMessage
-	if message == "" {
+	switch {
+	case inFocusTime:
+		message = DefaultFocusAutoRespondMessage
+	case message == "":
 		message = DefaultAutoRespondMessage
 	}
 
@@ -145,6 +145,7 @@ func TestHandleBusyDM(t *testing.T) {
 
 			mockStore.EXPECT().LoadUser("mattermost_user_sender_id").Return(nil, errors.New("user not found"))
 			mockStore.EXPECT().LoadUser("mattermost_user_recipient_id").Return(storedRecipient, nil)
+			mockStore.EXPECT().LoadFocusTime("mattermost_user_recipient_id").Return(&store.FocusTime{}, nil)
 
 			if tc.autoRespondSetting && len(tc.recipientActiveEvents) > 0 {
 				mockPluginAPI.EXPECT().GetMattermostUserStatus("mattermost_user_recipient_id").Return(recipientStatus, nil)
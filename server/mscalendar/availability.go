@@ -21,8 +21,21 @@ const (
 	StatusSyncJobInterval           = 5 * time.Minute
 	upcomingEventNotificationTime   = 10 * time.Minute
 	upcomingEventNotificationWindow = (StatusSyncJobInterval * 11) / 10 // 110% of the interval
+
+	// Outlook reminders set further ahead than this (e.g. the 18 hours used
+	// for all-day events) are delivered maxReminderLeadTime before the start,
+	// to keep the calendar view requested on every sync reasonably small.
+	maxReminderLeadTime = 2 * time.Hour
 )
 
+var reminderLeadTimes = map[string][]time.Duration{
+	store.ReminderLeadTime1Minute:   {time.Minute},
+	store.ReminderLeadTime5Minutes:  {5 * time.Minute},
+	store.ReminderLeadTime10Minutes: {10 * time.Minute},
+	store.ReminderLeadTime15Minutes: {15 * time.Minute},
+	store.ReminderLeadTime15And5:    {15 * time.Minute, 5 * time.Minute},
+}
+
 type Availability interface {
 	GetCalendarViews(users []*store.User) ([]*remote.ViewCalendarResponse, error)
 	Sync(mattermostUserID string) (string, error)
@@ -67,7 +80,7 @@ func (m *mscalendar) syncUsers(userIndex store.UserIndex) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		if user.Settings.UpdateStatus || user.Settings.ReceiveReminders {
+		if user.Settings.UpdateStatus || user.Settings.ReceiveReminders || len(user.ChannelBindings) > 0 || m.hasMeetingThreads(user) {
 			users = append(users, user)
 		}
 	}
@@ -84,6 +97,7 @@ func (m *mscalendar) syncUsers(userIndex store.UserIndex) (string, error) {
 	}
 
 	m.deliverReminders(users, calendarViews)
+	m.syncMeetingThreads(users, calendarViews)
 	out, err := m.setUserStatuses(users, calendarViews)
 	if err != nil {
 		return "", err
@@ -106,6 +120,7 @@ func (m *mscalendar) deliverReminders(users []*store.User, calendarViews []*remo
 	usersByRemoteID := map[string]*store.User{}
 	for _, u := range toNotify {
 		usersByRemoteID[u.Remote.ID] = u
+		m.deliverSnoozedReminders(u)
 	}
 
 	for _, view := range calendarViews {
@@ -118,8 +133,7 @@ func (m *mscalendar) deliverReminders(users []*store.User, calendarViews []*remo
 			continue
 		}
 
-		mattermostUserID := usersByRemoteID[view.RemoteUserID].MattermostUserID
-		m.notifyUpcomingEvents(mattermostUserID, view.Events)
+		m.notifyUpcomingEvents(user, view.Events)
 	}
 }
 
@@ -186,7 +200,11 @@ func (m *mscalendar) setStatusFromCalendarView(user *store.User, status *model.S
 		return "User offline and does not want status change confirmations. No status change", nil
 	}
 
-	events := filterBusyEvents(res.Events)
+	focus, err := m.Store.LoadFocusTime(user.MattermostUserID)
+	if err != nil {
+		return "", err
+	}
+	events := filterBusyEvents(withoutFocusTime(res.Events, focus))
 	busyStatus := model.STATUS_DND
 	if user.Settings.ReceiveNotificationsDuringMeeting {
 		busyStatus = model.STATUS_AWAY
@@ -196,6 +214,12 @@ func (m *mscalendar) setStatusFromCalendarView(user *store.User, status *model.S
 		return "No events in local or remote. No status change.", nil
 	}
 
+	// The focus session keeps the status until it ends. The active events are
+	// left as they are, so meetings that outlast it are handled afterwards.
+	if isInFocusTime(focus, time.Now()) {
+		return "User is in focus time. No status change.", nil
+	}
+
 	if len(user.ActiveEvents) > 0 && len(events) == 0 {
 		message := fmt.Sprintf("User is no longer busy in calendar, but is not set to busy (%s). No status change.", busyStatus)
 		if currentStatus == busyStatus {
@@ -278,7 +302,7 @@ func (m *mscalendar) setStatusFromCalendarView(user *store.User, status *model.S
 		message = fmt.Sprintf("User was free, but is now busy. Set status to busy (%s).", busyStatus)
 	}
 
-	err := m.Store.StoreUserActiveEvents(user.MattermostUserID, remoteHashes)
+	err = m.Store.StoreUserActiveEvents(user.MattermostUserID, remoteHashes)
 	if err != nil {
 		return "", err
 	}
@@ -323,8 +347,7 @@ func (m *mscalendar) setStatusOrAskUser(user *store.User, currentStatus *model.S
 		return nil
 	}
 
-	url := fmt.Sprintf("%s%s%s", m.Config.PluginURLPath, config.PathPostAction, config.PathConfirmStatusChange)
-	_, err = m.Poster.DMWithAttachments(user.MattermostUserID, views.RenderStatusChangeNotificationView(events, toSet, url))
+	_, err = m.Poster.DMWithAttachments(user.MattermostUserID, views.RenderStatusChangeNotificationView(events, toSet, m.actionURL(config.PathConfirmStatusChange)))
 	if err != nil {
 		return err
 	}
@@ -338,58 +361,147 @@ func (m *mscalendar) GetCalendarViews(users []*store.User) ([]*remote.ViewCalend
 	}
 
 	start := time.Now().UTC()
-	end := time.Now().UTC().Add(calendarViewTimeWindowSize)
 
 	params := []*remote.ViewCalendarParams{}
 	for _, u := range users {
 		params = append(params, &remote.ViewCalendarParams{
 			RemoteUserID: u.Remote.ID,
+			CalendarIDs:  u.Settings.CalendarIDs,
 			StartTime:    start,
-			EndTime:      end,
+			EndTime:      start.Add(calendarViewTimeWindow(u)),
 		})
 	}
 
 	return m.client.DoBatchViewCalendarRequests(params)
 }
 
-func (m *mscalendar) notifyUpcomingEvents(mattermostUserID string, events []*remote.Event) {
+func (m *mscalendar) notifyUpcomingEvents(user *store.User, events []*remote.Event) {
 	var timezone string
+	now := time.Now()
 	for _, event := range events {
 		if event.IsCancelled {
 			continue
 		}
-		upcomingTime := time.Now().Add(upcomingEventNotificationTime)
+		// A reminder due between two syncs is delivered by the next one, even
+		// if the event has started by then.
 		start := event.Start.Time()
-		diff := start.Sub(upcomingTime)
-
-		if (diff < upcomingEventNotificationWindow) && (diff > -upcomingEventNotificationWindow) {
-			var err error
-			if timezone == "" {
-				timezone, err = m.GetTimezoneByID(mattermostUserID)
-				if err != nil {
-					m.Logger.Warnf("notifyUpcomingEvents error getting timezone. err=%v", err)
-					return
-				}
-			}
+		if !start.After(now.Add(-StatusSyncJobInterval)) {
+			continue
+		}
 
-			message, err := views.RenderUpcomingEvent(event, timezone)
-			if err != nil {
-				m.Logger.Warnf("notifyUpcomingEvent error rendering schedule item. err=%v", err)
-				continue
-			}
-			_, err = m.Poster.DM(mattermostUserID, message)
+		leadTime, due := dueReminderLeadTime(getReminderLeadTimes(user.Settings.ReminderLeadTime, event), start, now)
+		if !due {
+			continue
+		}
+
+		reminderID := getReminderID(event, leadTime)
+		sent, err := m.Store.IsUserReminderSent(user.MattermostUserID, reminderID)
+		if err != nil {
+			m.Logger.Warnf("notifyUpcomingEvents error checking sent reminders. err=%v", err)
+			continue
+		}
+		if sent {
+			continue
+		}
+
+		if timezone == "" {
+			timezone, err = m.GetTimezoneByID(user.MattermostUserID)
 			if err != nil {
-				m.Logger.Warnf("notifyUpcomingEvents error creating DM. err=%v", err)
-				continue
+				m.Logger.Warnf("notifyUpcomingEvents error getting timezone. err=%v", err)
+				return
 			}
 		}
+
+		err = m.postReminder(user.MattermostUserID, event, timezone)
+		if err != nil {
+			m.Logger.Warnf("notifyUpcomingEvents error creating DM. err=%v", err)
+			continue
+		}
+
+		err = m.Store.StoreUserReminderSent(user.MattermostUserID, reminderID, start)
+		if err != nil {
+			m.Logger.Warnf("notifyUpcomingEvents error storing sent reminder. err=%v", err)
+		}
+	}
+}
+
+// dueReminderLeadTime returns the shortest of the lead times whose reminder
+// is due, so that the reminders due at once are delivered once, for the
+// nearest one.
+func dueReminderLeadTime(leadTimes []time.Duration, start, now time.Time) (time.Duration, bool) {
+	var nearest time.Duration
+	due := false
+	for _, leadTime := range leadTimes {
+		if start.Add(-leadTime).After(now) {
+			continue
+		}
+		if !due || leadTime < nearest {
+			nearest = leadTime
+			due = true
+		}
+	}
+	return nearest, due
+}
+
+// getReminderLeadTimes returns how long before the start of the event the user
+// wants to be reminded of it.
+func getReminderLeadTimes(setting string, event *remote.Event) []time.Duration {
+	if setting == store.ReminderLeadTimeOutlook {
+		if !event.IsReminderOn {
+			return nil
+		}
+		leadTime := time.Duration(event.ReminderMinutesBeforeStart) * time.Minute
+		if leadTime > maxReminderLeadTime {
+			leadTime = maxReminderLeadTime
+		}
+		return []time.Duration{leadTime}
+	}
+
+	leadTimes, ok := reminderLeadTimes[setting]
+	if !ok {
+		return []time.Duration{upcomingEventNotificationTime}
+	}
+	return leadTimes
+}
+
+func getReminderID(event *remote.Event, leadTime time.Duration) string {
+	return fmt.Sprintf("%s %s %d", event.ICalUID, event.Start.Time().UTC().Format(time.RFC3339), int(leadTime.Minutes()))
+}
+
+// calendarViewTimeWindow returns how far ahead the calendar view must look for
+// the user, wide enough to contain the events they need to be reminded of.
+func calendarViewTimeWindow(user *store.User) time.Duration {
+	window := calendarViewTimeWindowSize
+	if threadsWindow := meetingThreadsWindow(user); threadsWindow > window {
+		window = threadsWindow
+	}
+	if !user.Settings.ReceiveReminders {
+		return window
+	}
+
+	leadTimes, ok := reminderLeadTimes[user.Settings.ReminderLeadTime]
+	switch {
+	case user.Settings.ReminderLeadTime == store.ReminderLeadTimeOutlook:
+		leadTimes = []time.Duration{maxReminderLeadTime}
+	case !ok:
+		leadTimes = []time.Duration{upcomingEventNotificationTime}
+	}
+
+	for _, leadTime := range leadTimes {
+		if leadTime+upcomingEventNotificationWindow > window {
+			window = leadTime + upcomingEventNotificationWindow
+		}
 	}
+	return window
 }
 
+// filterBusyEvents returns the busy events starting within the status sync
+// window. The calendar view may reach further ahead to find upcoming reminders.
 func filterBusyEvents(events []*remote.Event) []*remote.Event {
+	statusWindowEnd := time.Now().Add(calendarViewTimeWindowSize)
 	result := []*remote.Event{}
 	for _, e := range events {
-		if e.ShowAs == "busy" {
+		if e.ShowAs == "busy" && e.Start.Time().Before(statusWindowEnd) {
 			result = append(result, e)
 		}
 	}
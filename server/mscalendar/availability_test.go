@@ -168,6 +168,62 @@ func TestSyncStatusAll(t *testing.T) {
 	}
 }
 
+func TestSyncStatusDuringFocusTime(t *testing.T) {
+	moment := time.Now().UTC()
+	eventHash := "event_id " + moment.Add(-time.Hour).Format(time.RFC3339)
+	busyEvent := &remote.Event{ICalUID: "event_id", Start: remote.NewDateTime(moment, "UTC"), ShowAs: "busy"}
+	focusEvent := &remote.Event{ICalUID: "focus_event_uid", Start: remote.NewDateTime(moment.Add(-time.Hour), "UTC"), ShowAs: "busy"}
+
+	for name, tc := range map[string]struct {
+		remoteEvents []*remote.Event
+		activeEvents []string
+		settings     store.Settings
+	}{
+		"Meeting ends during focus time. Stays Do Not Disturb.": {
+			remoteEvents: []*remote.Event{focusEvent},
+			activeEvents: []string{eventHash},
+		},
+		"Meeting starts during focus time. Not set to away.": {
+			remoteEvents: []*remote.Event{focusEvent, busyEvent},
+			activeEvents: []string{},
+			settings:     store.Settings{ReceiveNotificationsDuringMeeting: true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+			mockStore.EXPECT().LoadFocusTime("user_mm_id").Return(&store.FocusTime{
+				Sessions: []*store.FocusSession{{
+					EventID:     "focus_event_id",
+					ICalUID:     "focus_event_uid",
+					Start:       moment.Add(-time.Hour),
+					End:         moment.Add(time.Hour),
+					Started:     true,
+					PriorStatus: model.STATUS_ONLINE,
+				}},
+			}, nil)
+
+			m := &mscalendar{
+				Env: Env{Dependencies: &Dependencies{
+					Store:     mockStore,
+					PluginAPI: mockPluginAPI,
+				}},
+			}
+			user := &store.User{
+				MattermostUserID: "user_mm_id",
+				Settings:         tc.settings,
+				ActiveEvents:     tc.activeEvents,
+			}
+			res, err := m.setStatusFromCalendarView(user, &model.Status{Status: model.STATUS_DND, Manual: true}, &remote.ViewCalendarResponse{Events: tc.remoteEvents})
+			require.NoError(t, err)
+			require.Equal(t, "User is in focus time. No status change.", res)
+		})
+	}
+}
+
 func TestSyncStatusUserConfig(t *testing.T) {
 	for name, tc := range map[string]struct {
 		settings      store.Settings
@@ -231,10 +287,14 @@ func TestSyncStatusUserConfig(t *testing.T) {
 
 func TestReminders(t *testing.T) {
 	for name, tc := range map[string]struct {
-		remoteEvents   []*remote.Event
-		numReminders   int
-		apiError       *remote.APIError
-		shouldLogError bool
+		remoteEvents     []*remote.Event
+		snoozedReminders []*store.SnoozedReminder
+		reminderLeadTime string
+		alreadySent      bool
+		numReminders     int
+		numSnoozedDue    int
+		apiError         *remote.APIError
+		shouldLogError   bool
 	}{
 		"Most common case, no remote events. No reminder.": {
 			remoteEvents:   []*remote.Event{},
@@ -255,10 +315,17 @@ func TestReminders(t *testing.T) {
 			numReminders:   0,
 			shouldLogError: false,
 		},
-		"One remote event, but it is to soon in the future. Reminder has already occurred.": {
+		"One remote event, first seen after its reminder was due. Reminder should occur.": {
 			remoteEvents: []*remote.Event{
 				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(2*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
 			},
+			numReminders:   1,
+			shouldLogError: false,
+		},
+		"One remote event, in the range but not yet due. No reminder.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(12*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
 			numReminders:   0,
 			shouldLogError: false,
 		},
@@ -277,6 +344,78 @@ func TestReminders(t *testing.T) {
 			numReminders:   2,
 			shouldLogError: false,
 		},
+		"One remote event, and the reminder was already sent. No reminder.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(7*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			alreadySent:    true,
+			numReminders:   0,
+			shouldLogError: false,
+		},
+		"One remote event, in the range for the user's 15 minutes reminder. Reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(13*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			reminderLeadTime: store.ReminderLeadTime15Minutes,
+			numReminders:     1,
+			shouldLogError:   false,
+		},
+		"One remote event, in the range for the default reminder but not for the user's 1 minute reminder. No reminder.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(10*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			reminderLeadTime: store.ReminderLeadTime1Minute,
+			numReminders:     0,
+			shouldLogError:   false,
+		},
+		"One remote event, started since the user's 1 minute reminder was due. Reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(-2*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			reminderLeadTime: store.ReminderLeadTime1Minute,
+			numReminders:     1,
+			shouldLogError:   false,
+		},
+		"One remote event, with both of the user's 15 and 5 minutes reminders due. One reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(3*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			reminderLeadTime: store.ReminderLeadTime15And5,
+			numReminders:     1,
+			shouldLogError:   false,
+		},
+		"One remote event, with an Outlook reminder in range. Reminder should occur.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", IsReminderOn: true, ReminderMinutesBeforeStart: 30, Start: remote.NewDateTime(time.Now().Add(28*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			reminderLeadTime: store.ReminderLeadTimeOutlook,
+			numReminders:     1,
+			shouldLogError:   false,
+		},
+		"One remote event, with the Outlook reminder turned off. No reminder.": {
+			remoteEvents: []*remote.Event{
+				{ICalUID: "event_id", IsReminderOn: false, ReminderMinutesBeforeStart: 30, Start: remote.NewDateTime(time.Now().Add(32*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+			},
+			reminderLeadTime: store.ReminderLeadTimeOutlook,
+			numReminders:     0,
+			shouldLogError:   false,
+		},
+		"One snoozed reminder is due. Reminder should occur.": {
+			remoteEvents: []*remote.Event{},
+			snoozedReminders: []*store.SnoozedReminder{
+				{
+					Event:    &remote.Event{ID: "event_remote_id", ICalUID: "event_id", Start: remote.NewDateTime(time.Now().Add(-2*time.Minute).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(45*time.Minute).UTC(), "UTC")},
+					RemindAt: time.Now().Add(time.Minute),
+				},
+				{
+					Event:    &remote.Event{ID: "event_remote_id_2", ICalUID: "event_id_2", Start: remote.NewDateTime(time.Now().Add(time.Hour).UTC(), "UTC"), End: remote.NewDateTime(time.Now().Add(2*time.Hour).UTC(), "UTC")},
+					RemindAt: time.Now().Add(20 * time.Minute),
+				},
+			},
+			numReminders:   0,
+			numSnoozedDue:  1,
+			shouldLogError: false,
+		},
 		"Remote API Error. Error should be logged.": {
 			remoteEvents:   []*remote.Event{},
 			numReminders:   0,
@@ -299,18 +438,32 @@ func TestReminders(t *testing.T) {
 					ID:   "user_remote_id",
 					Mail: "user_email@example.com",
 				},
-				Settings: store.Settings{ReceiveReminders: true},
+				Settings: store.Settings{ReceiveReminders: true, ReminderLeadTime: tc.reminderLeadTime},
 			}, nil)
 			c.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{
 				{Events: tc.remoteEvents, RemoteUserID: "user_remote_id", Error: tc.apiError},
 			}, nil)
 
-			if tc.numReminders > 0 {
-				poster.EXPECT().DM("user_mm_id", gomock.Any()).Times(tc.numReminders)
+			if tc.snoozedReminders == nil {
+				s.EXPECT().LoadUserSnoozedReminders("user_mm_id").Return(nil, store.ErrNotFound).Times(1)
+			} else {
+				s.EXPECT().LoadUserSnoozedReminders("user_mm_id").Return(tc.snoozedReminders, nil).Times(1)
+				s.EXPECT().DeleteUserSnoozedReminder("user_mm_id", "event_remote_id").Return(nil).Times(1)
+			}
+
+			if tc.alreadySent {
+				s.EXPECT().IsUserReminderSent("user_mm_id", gomock.Any()).Return(true, nil).Times(1)
+			}
+
+			if tc.numReminders+tc.numSnoozedDue > 0 {
+				s.EXPECT().IsUserReminderSent("user_mm_id", gomock.Any()).Return(false, nil).Times(tc.numReminders)
+				poster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Times(tc.numReminders + tc.numSnoozedDue)
+				s.EXPECT().StoreUserReminderSent("user_mm_id", gomock.Any(), gomock.Any()).Return(nil).Times(tc.numReminders)
 				loadUser.Times(2)
 				c.EXPECT().GetMailboxSettings("user_remote_id").Times(1).Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
 			} else {
-				poster.EXPECT().DM(gomock.Any(), gomock.Any()).Times(0)
+				poster.EXPECT().DMWithAttachments(gomock.Any(), gomock.Any()).Times(0)
+				s.EXPECT().StoreUserReminderSent(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 				loadUser.Times(1)
 			}
 
@@ -328,6 +481,29 @@ func TestReminders(t *testing.T) {
 	}
 }
 
+func TestDueReminderLeadTime(t *testing.T) {
+	now := time.Now()
+	leadTimes := []time.Duration{15 * time.Minute, 5 * time.Minute}
+	for _, tc := range []struct {
+		name     string
+		start    time.Time
+		expected time.Duration
+		due      bool
+	}{
+		{name: "None due", start: now.Add(20 * time.Minute)},
+		{name: "Due exactly", start: now.Add(15 * time.Minute), expected: 15 * time.Minute, due: true},
+		{name: "Furthest due", start: now.Add(10 * time.Minute), expected: 15 * time.Minute, due: true},
+		{name: "Both due, nearest", start: now.Add(3 * time.Minute), expected: 5 * time.Minute, due: true},
+		{name: "Started", start: now.Add(-time.Minute), expected: 5 * time.Minute, due: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			leadTime, due := dueReminderLeadTime(leadTimes, tc.start, now)
+			require.Equal(t, tc.due, due)
+			require.Equal(t, tc.expected, leadTime)
+		})
+	}
+}
+
 func makeStatusSyncTestEnv(ctrl *gomock.Controller) (Env, remote.Client) {
 	s := mock_store.NewMockStore(ctrl)
 	poster := mock_bot.NewMockPoster(ctrl)
@@ -355,6 +531,8 @@ func makeStatusSyncTestEnv(ctrl *gomock.Controller) (Env, remote.Client) {
 		},
 	}, nil).Times(1)
 
+	s.EXPECT().LoadMeetingThreads("user_mm_id").Return(nil, store.ErrNotFound).AnyTimes()
+	s.EXPECT().LoadFocusTime("user_mm_id").Return(&store.FocusTime{}, nil).AnyTimes()
 	mockRemote.EXPECT().MakeSuperuserClient(context.Background()).Return(mockClient, nil)
 
 	return env, mockClient
@@ -6,6 +6,8 @@ package mscalendar
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 )
 
@@ -16,6 +18,16 @@ type Calendar interface {
 	FindMeetingTimes(user *User, meetingParams *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error)
 	GetCalendars(user *User) ([]*remote.Calendar, error)
 	ViewCalendar(user *User, from, to time.Time) ([]*remote.Event, error)
+	ViewCalendarByID(user *User, calendarID string, from, to time.Time) ([]*remote.Event, error)
+	GetEvent(user *User, eventID string) (*remote.Event, error)
+	UpdateEvent(user *User, event *remote.Event) (*remote.Event, error)
+	MoveEvent(user *User, eventID string, start, end time.Time) (*remote.Event, error)
+	CancelEvent(user *User, eventID, message string) error
+	OpenEditEventDialog(user *User, triggerID, eventID, postID string, rescheduleOnly bool) error
+	OpenCancelEventDialog(user *User, triggerID, eventID, postID string) error
+	OpenCreateEventFromPostDialog(user *User, triggerID, postID string) error
+	CreateEventFromPost(user *User, postID string, event *remote.Event, mattermostUserIDs []string, meetingType string) (*remote.Event, error)
+	AddOnlineMeeting(user *User, event *remote.Event, meetingType, channelID string) error
 }
 
 func (m *mscalendar) ViewCalendar(user *User, from, to time.Time) ([]*remote.Event, error) {
@@ -29,6 +41,19 @@ func (m *mscalendar) ViewCalendar(user *User, from, to time.Time) ([]*remote.Eve
 	return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
 }
 
+// ViewCalendarByID gets the events of one of the user's calendars, rather
+// than of the default calendar.
+func (m *mscalendar) ViewCalendarByID(user *User, calendarID string, from, to time.Time) ([]*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.GetCalendarView(user.Remote.ID, calendarID, from, to)
+}
+
 func (m *mscalendar) getTodayCalendarEvents(user *User, now time.Time, timezone string) ([]*remote.Event, error) {
 	err := m.Filter(
 		withClient,
@@ -43,7 +68,33 @@ func (m *mscalendar) getTodayCalendarEvents(user *User, now time.Time, timezone
 	}
 
 	from, to := getTodayHoursForTimezone(now, timezone)
-	return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	return m.getSelectedCalendarsView(user, from, to)
+}
+
+// getSelectedCalendarsView gets the events of the calendars the user selected
+// in the settings, or of the default calendar. The calendars are fetched in
+// one batch, leaving out the ones that fail.
+func (m *mscalendar) getSelectedCalendarsView(user *User, from, to time.Time) ([]*remote.Event, error) {
+	if len(user.Settings.CalendarIDs) == 0 {
+		return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	}
+
+	responses, err := m.client.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{{
+		RemoteUserID: user.Remote.ID,
+		CalendarIDs:  user.Settings.CalendarIDs,
+		StartTime:    from,
+		EndTime:      to,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return []*remote.Event{}, nil
+	}
+	if responses[0].Error != nil {
+		return nil, errors.New(responses[0].Error.Message)
+	}
+	return responses[0].Events, nil
 }
 
 func (m *mscalendar) CreateCalendar(user *User, calendar *remote.Calendar) (*remote.Calendar, error) {
@@ -66,6 +117,12 @@ func (m *mscalendar) CreateEvent(user *User, event *remote.Event, mattermostUser
 		return nil, err
 	}
 
+	return m.createEvent(user.Remote.ID, event, mattermostUserIDs)
+}
+
+// createEvent creates the event in the calendar of remoteUserID, inviting the
+// Mattermost users who have not connected their account to do so.
+func (m *mscalendar) createEvent(remoteUserID string, event *remote.Event, mattermostUserIDs []string) (*remote.Event, error) {
 	// invite non-mapped Mattermost
 	for id := range mattermostUserIDs {
 		mattermostUserID := mattermostUserIDs[id]
@@ -81,7 +138,7 @@ func (m *mscalendar) CreateEvent(user *User, event *remote.Event, mattermostUser
 		}
 	}
 
-	return m.client.CreateEvent(user.Remote.ID, event)
+	return m.client.CreateEvent(remoteUserID, event)
 }
 
 func (m *mscalendar) DeleteCalendar(user *User, calendarID string) error {
@@ -0,0 +1,96 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+func TestGetSelectedCalendarsView(t *testing.T) {
+	from := time.Date(2020, 3, 11, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	for _, tc := range []struct {
+		name          string
+		calendarIDs   []string
+		runAssertions func(client *mock_remote.MockClient)
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "Default calendar",
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().GetDefaultCalendarView("user_remote_id", from, to).Return([]*remote.Event{{ID: "default"}}, nil)
+			},
+			expected: []string{"default"},
+		},
+		{
+			name:        "Selected calendars",
+			calendarIDs: []string{"work", "on-call"},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{{
+					RemoteUserID: "user_remote_id",
+					CalendarIDs:  []string{"work", "on-call"},
+					StartTime:    from,
+					EndTime:      to,
+				}}).Return([]*remote.ViewCalendarResponse{{
+					RemoteUserID: "user_remote_id",
+					Events:       []*remote.Event{{ID: "work1"}, {ID: "work2"}, {ID: "on-call1"}},
+				}}, nil)
+			},
+			expected: []string{"work1", "work2", "on-call1"},
+		},
+		{
+			name:        "No selected calendar fetched",
+			calendarIDs: []string{"work"},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{{
+					RemoteUserID: "user_remote_id",
+					Error:        &remote.APIError{Message: "The specified object was not found in the store."},
+				}}, nil)
+			},
+			expectedError: "The specified object was not found in the store.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			tc.runAssertions(mockClient)
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{}},
+				client: mockClient,
+			}
+			user := &User{
+				MattermostUserID: "user_mm_id",
+				User: &store.User{
+					Remote:   &remote.User{ID: "user_remote_id"},
+					Settings: store.Settings{CalendarIDs: tc.calendarIDs},
+				},
+			}
+
+			events, err := m.getSelectedCalendarsView(user, from, to)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			ids := []string{}
+			for _, e := range events {
+				ids = append(ids, e.ID)
+			}
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+}
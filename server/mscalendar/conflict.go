@@ -0,0 +1,154 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+// The calendar views looked up for conflicts cover whole days, and are reused
+// for conflictViewCacheTTL since notifications often come in bursts.
+const conflictViewCacheTTL = 5 * time.Minute
+
+type conflictView struct {
+	start   time.Time
+	end     time.Time
+	expires time.Time
+	events  []*remote.Event
+}
+
+// addConflictsToSlackAttachment flags the user's events that overlap with
+// the notified event, and offers to decline or tentatively accept the less
+// important of them.
+func (processor *notificationProcessor) addConflictsToSlackAttachment(client remote.Client, remoteUserID string, event *remote.Event, sa *model.SlackAttachment, timezone string) {
+	if event.Start == nil || event.End == nil || event.IsCancelled {
+		return
+	}
+
+	events, err := processor.getConflictView(client, remoteUserID, event.Start.Time(), event.End.Time(), time.Now())
+	if err != nil {
+		processor.Logger.With(bot.LogContext{
+			"EventID": event.ID,
+		}).Warnf("webhook notification: failed to look up conflicting events. err=%v", err)
+		return
+	}
+
+	conflicts := remote.FindConflictsWith(event, events)
+	if len(conflicts) == 0 {
+		return
+	}
+
+	lines := []string{}
+	for _, c := range conflicts {
+		link, err := views.RenderEventLink(c)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("Conflicts with %s at %s", link, c.Start.In(timezone).Time().Format(time.Kitchen)))
+	}
+
+	lessImportant := lessImportantEvent(event, conflicts)
+	if lessImportant != nil {
+		lines = append(lines, fmt.Sprintf("Decline or tentatively accept **%s** to resolve the conflict.", views.EnsureSubject(lessImportant.Subject)))
+		sa.Actions = append(sa.Actions, processor.newPostActionsForConflict(lessImportant.ID)...)
+	}
+
+	sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+		Title: FieldConflicts,
+		Value: strings.Join(lines, "\n"),
+		Short: false,
+	})
+}
+
+// getConflictView gets the user's events from start to end, reusing the view
+// of the last notification of the user when it covers them. It is only used
+// by the notification worker, so the cache needs no locking.
+func (processor *notificationProcessor) getConflictView(client remote.Client, remoteUserID string, start, end, now time.Time) ([]*remote.Event, error) {
+	if processor.conflictViews == nil {
+		processor.conflictViews = map[string]*conflictView{}
+	}
+	cached := processor.conflictViews[remoteUserID]
+	if cached != nil && now.Before(cached.expires) && !start.Before(cached.start) && !end.After(cached.end) {
+		return cached.events, nil
+	}
+
+	viewStart := start.UTC().Truncate(24 * time.Hour)
+	viewEnd := end.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	events, err := client.GetDefaultCalendarView(remoteUserID, viewStart, viewEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, v := range processor.conflictViews {
+		if !now.Before(v.expires) {
+			delete(processor.conflictViews, id)
+		}
+	}
+	processor.conflictViews[remoteUserID] = &conflictView{
+		start:   viewStart,
+		end:     viewEnd,
+		expires: now.Add(conflictViewCacheTTL),
+		events:  events,
+	}
+	return events, nil
+}
+
+// lessImportantEvent picks the event of lowest importance among the notified
+// event and its conflicts, preferring the notified event. The events the user
+// organizes cannot be declined, so they are never picked.
+func lessImportantEvent(event *remote.Event, conflicts []*remote.Event) *remote.Event {
+	var picked *remote.Event
+	for _, e := range append([]*remote.Event{event}, conflicts...) {
+		if e.IsOrganizer {
+			continue
+		}
+		if picked == nil || importanceRank(e.Importance) < importanceRank(picked.Importance) {
+			picked = e
+		}
+	}
+	return picked
+}
+
+func importanceRank(importance string) int {
+	switch importance {
+	case "low":
+		return 0
+	case "high":
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (processor *notificationProcessor) newPostActionsForConflict(eventID string) []*model.PostAction {
+	actions := []*model.PostAction{}
+	for _, option := range []struct {
+		name   string
+		option string
+	}{
+		{"Decline", OptionNo},
+		{"Tentative", OptionMaybe},
+	} {
+		actions = append(actions, &model.PostAction{
+			Name: option.name,
+			Integration: &model.PostActionIntegration{
+				URL: processor.actionURL(config.PathRespondToConflict),
+				Context: map[string]interface{}{
+					config.EventIDKey: eventID,
+					config.OptionKey:  option.option,
+				},
+			},
+		})
+	}
+	return actions
+}
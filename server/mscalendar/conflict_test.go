@@ -0,0 +1,136 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+func TestAddConflictsToSlackAttachment(t *testing.T) {
+	at := func(hour int) *remote.DateTime {
+		return remote.NewDateTime(time.Date(2020, 2, 12, hour, 0, 0, 0, time.UTC), "UTC")
+	}
+	newEvent := func(id, subject, importance string, isOrganizer bool) *remote.Event {
+		return &remote.Event{
+			ID:             id,
+			Subject:        subject,
+			Importance:     importance,
+			IsOrganizer:    isOrganizer,
+			Weblink:        "link_" + id,
+			Start:          at(14),
+			End:            at(15),
+			ResponseStatus: &remote.EventResponseStatus{Response: ResponseYes},
+		}
+	}
+
+	for _, tc := range []struct {
+		name            string
+		event           *remote.Event
+		calendar        []*remote.Event
+		expectedField   string
+		expectedEventID string
+	}{
+		{
+			name:     "No conflicts",
+			event:    newEvent("new", "New", "normal", false),
+			calendar: []*remote.Event{newEvent("new", "New", "normal", false)},
+		},
+		{
+			name:            "Conflict with a more important event",
+			event:           newEvent("new", "New", "normal", false),
+			calendar:        []*remote.Event{newEvent("other", "Other", "high", false)},
+			expectedField:   "Conflicts with [Other](link_other) at 2:00PM\nDecline or tentatively accept **New** to resolve the conflict.",
+			expectedEventID: "new",
+		},
+		{
+			name:            "Conflict with a less important event",
+			event:           newEvent("new", "New", "normal", false),
+			calendar:        []*remote.Event{newEvent("other", "Other", "low", false)},
+			expectedField:   "Conflicts with [Other](link_other) at 2:00PM\nDecline or tentatively accept **Other** to resolve the conflict.",
+			expectedEventID: "other",
+		},
+		{
+			name:          "Conflict between organized events",
+			event:         newEvent("new", "New", "normal", true),
+			calendar:      []*remote.Event{newEvent("other", "Other", "low", true)},
+			expectedField: "Conflicts with [Other](link_other) at 2:00PM",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			mockClient.EXPECT().GetDefaultCalendarView("remote_user_id", time.Date(2020, 2, 12, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 13, 0, 0, 0, 0, time.UTC)).Return(tc.calendar, nil)
+
+			processor := &notificationProcessor{
+				Env: Env{
+					Config:       &config.Config{PluginURLPath: "/plugins/mscalendar"},
+					Dependencies: &Dependencies{Logger: &bot.NilLogger{}},
+				},
+			}
+			sa := &model.SlackAttachment{}
+			processor.addConflictsToSlackAttachment(mockClient, "remote_user_id", tc.event, sa, "UTC")
+
+			if tc.expectedField == "" {
+				require.Empty(t, sa.Fields)
+				require.Empty(t, sa.Actions)
+				return
+			}
+
+			require.Len(t, sa.Fields, 1)
+			require.Equal(t, FieldConflicts, sa.Fields[0].Title)
+			require.Equal(t, tc.expectedField, sa.Fields[0].Value)
+
+			if tc.expectedEventID == "" {
+				require.Empty(t, sa.Actions)
+				return
+			}
+			require.Len(t, sa.Actions, 2)
+			for _, a := range sa.Actions {
+				require.Equal(t, "/plugins/mscalendar/action/respond-conflict", a.Integration.URL)
+				require.Equal(t, tc.expectedEventID, a.Integration.Context[config.EventIDKey])
+			}
+			require.Equal(t, OptionNo, sa.Actions[0].Integration.Context[config.OptionKey])
+			require.Equal(t, OptionMaybe, sa.Actions[1].Integration.Context[config.OptionKey])
+		})
+	}
+}
+
+func TestGetConflictView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	day := time.Date(2020, 2, 12, 0, 0, 0, 0, time.UTC)
+	events := []*remote.Event{{ID: "event_id"}}
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockClient.EXPECT().GetDefaultCalendarView("remote_user_id", day, day.Add(24*time.Hour)).Return(events, nil).Times(2)
+	mockClient.EXPECT().GetDefaultCalendarView("remote_user_id", day.Add(24*time.Hour), day.Add(48*time.Hour)).Return(nil, nil)
+
+	processor := &notificationProcessor{}
+	now := time.Now()
+	view := func(startHour, endHour int, now time.Time) []*remote.Event {
+		result, err := processor.getConflictView(mockClient, "remote_user_id", day.Add(time.Duration(startHour)*time.Hour), day.Add(time.Duration(endHour)*time.Hour), now)
+		require.NoError(t, err)
+		return result
+	}
+
+	require.Equal(t, events, view(14, 15, now))
+	// Another event of the same day reuses the view
+	require.Equal(t, events, view(9, 10, now.Add(time.Minute)))
+	// The next day is not covered
+	require.Empty(t, view(33, 34, now.Add(2*time.Minute)))
+	// The view of the day expired
+	require.Equal(t, events, view(14, 15, now.Add(conflictViewCacheTTL+3*time.Minute)))
+}
@@ -26,6 +26,10 @@ type DailySummary interface {
 	SetDailySummaryPostTime(user *User, timeStr string) (*store.DailySummaryUserSettings, error)
 	SetDailySummaryEnabled(user *User, enable bool) (*store.DailySummaryUserSettings, error)
 	ProcessAllDailySummary(now time.Time) error
+	GetWeeklySummaryForUser(user *User, nextWeek bool) (string, error)
+	SetWeeklySummaryEnabled(user *User, enable bool) (*store.DailySummaryUserSettings, error)
+	SetNextWeekPreviewEnabled(user *User, enable bool) (*store.DailySummaryUserSettings, error)
+	ProcessAllWeeklySummary(now time.Time) error
 }
 
 func (m *mscalendar) GetDailySummarySettingsForUser(user *User) (*store.DailySummaryUserSettings, error) {
@@ -130,11 +134,13 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 			continue
 		}
 
-		start, end := getTodayHoursForTimezone(now, dsum.Timezone)
+		// The upcoming events are fetched too, to count the pending invitations
+		start, _ := getTodayHoursForTimezone(now, dsum.Timezone)
 		req := &remote.ViewCalendarParams{
 			RemoteUserID: storeUser.Remote.ID,
+			CalendarIDs:  storeUser.Settings.CalendarIDs,
 			StartTime:    start,
-			EndTime:      end,
+			EndTime:      start.Add(InvitesLookahead),
 		}
 		requests = append(requests, req)
 	}
@@ -154,15 +160,16 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 			// Should never reach this point
 			continue
 		}
-		postStr, err := views.RenderCalendarView(res.Events, dsum.Timezone)
+		_, end := getTodayHoursForTimezone(now, dsum.Timezone)
+		postStr, err := views.RenderDailySummary(eventsStartingBefore(res.Events, end), dsum.Timezone)
 		if err != nil {
 			m.Logger.Warnf("Error rendering user %s calendar. err=%v", user.MattermostUserID, err)
 		}
+		postStr += renderPendingInvitationsCount(res.Events, now)
 
 		m.Poster.DM(user.MattermostUserID, postStr)
 		m.Dependencies.Tracker.TrackDailySummarySent(user.MattermostUserID)
-		dsum.LastPostTime = time.Now().Format(time.RFC3339)
-		err = m.Store.StoreUser(user)
+		err = m.Store.StoreDailySummaryPostTime(user.MattermostUserID, time.Now())
 		if err != nil {
 			m.Logger.Warnf("Error storing daily summary LastPostTime for user %s. err=%v", user.MattermostUserID, err)
 		}
@@ -183,7 +190,27 @@ func (m *mscalendar) GetDailySummaryForUser(user *User) (string, error) {
 		return "Failed to get calendar events", err
 	}
 
-	return views.RenderCalendarView(calendarData, tz)
+	postStr, err := views.RenderDailySummary(calendarData, tz)
+	if err != nil {
+		return "", err
+	}
+
+	pending, err := m.GetPendingInvitations(user, time.Now())
+	if err != nil {
+		m.Logger.Warnf("Error getting the pending invitations of user %s. err=%v", user.MattermostUserID, err)
+		return postStr, nil
+	}
+	return postStr + renderPendingInvitationsCount(pending, time.Now()), nil
+}
+
+func eventsStartingBefore(events []*remote.Event, end time.Time) []*remote.Event {
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.Start != nil && e.Start.Time().Before(end) {
+			result = append(result, e)
+		}
+	}
+	return result
 }
 
 func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time) (bool, error) {
@@ -191,7 +218,14 @@ func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time)
 		return false, nil
 	}
 
-	lastPostStr := dsum.LastPostTime
+	return shouldPostSummary(dsum, dsum.LastPostTime, now, func(day time.Weekday) bool {
+		return day != time.Saturday && day != time.Sunday
+	})
+}
+
+// shouldPostSummary checks whether now is within the time window of the
+// user's summary post time, on one of the days the summary is posted.
+func shouldPostSummary(dsum *store.DailySummaryUserSettings, lastPostStr string, now time.Time, postDay func(time.Weekday) bool) (bool, error) {
 	if lastPostStr != "" {
 		lastPost, err := time.Parse(time.RFC3339, lastPostStr)
 		if err != nil {
@@ -217,7 +251,7 @@ func shouldPostDailySummary(dsum *store.DailySummaryUserSettings, now time.Time)
 	}
 
 	now = now.In(loc)
-	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+	if !postDay(now.Weekday()) {
 		return false, nil
 	}
 
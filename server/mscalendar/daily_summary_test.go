@@ -155,10 +155,8 @@ Wednesday February 12
 | 9:00AM - 11:00AM | [The subject]() |`).Return("postID2", nil).Times(1),
 				)
 
-				s.EXPECT().StoreUser(gomock.Any()).Times(2).DoAndReturn(func(u *store.User) error {
-					require.NotEmpty(t, u.Settings.DailySummary.LastPostTime)
-					return nil
-				})
+				s.EXPECT().StoreDailySummaryPostTime("user1_mm_id", gomock.Any()).Return(nil)
+				s.EXPECT().StoreDailySummaryPostTime("user2_mm_id", gomock.Any()).Return(nil)
 
 				mockLogger := deps.Logger.(*mock_bot.MockLogger)
 				mockLogger.EXPECT().Infof("Processed daily summary for %d users", 2)
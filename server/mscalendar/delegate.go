@@ -0,0 +1,290 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+const (
+	DelegationApprove = "approve"
+	DelegationDeny    = "deny"
+)
+
+// Delegation lets an assistant manage the calendar of another user, the
+// delegator, once the delegator approved it. The assistant acts with their own
+// Microsoft account, so the delegator must also share their calendar with the
+// assistant in Outlook.
+type Delegation interface {
+	RequestDelegation(user *User, delegatorUsername string) error
+	RespondToDelegationRequest(user *User, delegateID string, approve bool) error
+	RemoveDelegation(user *User, username string) error
+	ListDelegations(user *User) (delegates, delegators []string, err error)
+	GetDelegatorID(user *User, delegatorUsername string) (string, error)
+	ViewDelegatorCalendar(user *User, delegatorID string, from, to time.Time) ([]*remote.Event, error)
+	CreateDelegatorEvent(user *User, delegatorID string, event *remote.Event, mattermostUserIDs []string) (*remote.Event, error)
+	RespondToDelegatorEvent(user *User, delegatorID, eventID, response string) error
+}
+
+// RequestDelegation asks the delegator, in a DM, to let the user manage their
+// calendar.
+func (m *mscalendar) RequestDelegation(user *User, delegatorUsername string) error {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return err
+	}
+
+	delegator, err := m.getConnectedUserByUsername(delegatorUsername)
+	if err != nil {
+		return err
+	}
+	if delegator.MattermostUserID == user.MattermostUserID {
+		return errors.New("you cannot be your own delegate")
+	}
+	if containsString(user.Delegators, delegator.MattermostUserID) {
+		return errors.Errorf("you are already a delegate of @%s", delegator.MattermostUser.Username)
+	}
+
+	action := func(name, option string) *model.PostAction {
+		return &model.PostAction{
+			Name: name,
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL: m.actionURL(config.PathDelegation),
+				Context: map[string]interface{}{
+					config.DelegateIDKey: user.MattermostUserID,
+					config.OptionKey:     option,
+				},
+			},
+		}
+	}
+
+	text := fmt.Sprintf("%s would like to manage your calendar: receive your invitations and respond to them, view your calendar and create events on your behalf.\n"+
+		"If you approve, please also share your calendar with %s in Outlook, allowing them to edit it.", user.Markdown(), user.Remote.Mail)
+	_, err = m.Poster.DMWithAttachments(delegator.MattermostUserID, &model.SlackAttachment{
+		Title:    "Delegate access request",
+		Text:     text,
+		Fallback: text,
+		Actions: []*model.PostAction{
+			action("Approve", DelegationApprove),
+			action("Deny", DelegationDeny),
+		},
+	})
+	return err
+}
+
+// RespondToDelegationRequest approves or denies the request of the delegate to
+// manage the user's calendar, and lets the delegate know.
+func (m *mscalendar) RespondToDelegationRequest(user *User, delegateID string, approve bool) error {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return err
+	}
+
+	if !approve {
+		_, err = m.Poster.DM(delegateID, "@%s has denied your request to manage their calendar.", user.MattermostUser.Username)
+		return err
+	}
+
+	delegate, err := m.Store.LoadUser(delegateID)
+	if err != nil {
+		return errors.Wrap(err, "the delegate is no longer connected")
+	}
+
+	if !containsString(user.Delegates, delegateID) {
+		user.Delegates = append(user.Delegates, delegateID)
+		err = m.Store.StoreUser(user.User)
+		if err != nil {
+			return err
+		}
+	}
+	if !containsString(delegate.Delegators, user.MattermostUserID) {
+		delegate.Delegators = append(delegate.Delegators, user.MattermostUserID)
+		err = m.Store.StoreUser(delegate)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = m.Poster.DM(delegateID, "@%s has approved your request to manage their calendar. You will receive their invitations, and you can use `/%s viewcal --for @%[1]s` and `/%[2]s createevent --for @%[1]s`.",
+		user.MattermostUser.Username, config.CommandTrigger)
+	return err
+}
+
+// RemoveDelegation removes the link between the user and the other user,
+// whichever of them is the delegate.
+func (m *mscalendar) RemoveDelegation(user *User, username string) error {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return err
+	}
+
+	other, err := m.PluginAPI.GetMattermostUserByUsername(strings.TrimPrefix(username, "@"))
+	if err != nil {
+		return errors.Errorf("user %s was not found", username)
+	}
+	if !containsString(user.Delegates, other.Id) && !containsString(user.Delegators, other.Id) {
+		return errors.Errorf("there is no delegation between you and @%s", other.Username)
+	}
+
+	user.Delegates = removeString(user.Delegates, other.Id)
+	user.Delegators = removeString(user.Delegators, other.Id)
+	err = m.Store.StoreUser(user.User)
+	if err != nil {
+		return err
+	}
+
+	storedOther, err := m.Store.LoadUser(other.Id)
+	if err == nil {
+		storedOther.Delegates = removeString(storedOther.Delegates, user.MattermostUserID)
+		storedOther.Delegators = removeString(storedOther.Delegators, user.MattermostUserID)
+		err = m.Store.StoreUser(storedOther)
+	}
+	if err != nil && err != store.ErrNotFound {
+		return err
+	}
+
+	_, err = m.Poster.DM(other.Id, "%s has removed the delegate access between you.", user.Markdown())
+	return err
+}
+
+// ListDelegations returns the usernames of the user's delegates, and of the
+// users whose calendars the user manages.
+func (m *mscalendar) ListDelegations(user *User) (delegates, delegators []string, err error) {
+	err = m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usernames := func(mattermostUserIDs []string) []string {
+		names := []string{}
+		for _, id := range mattermostUserIDs {
+			mattermostUser, err := m.PluginAPI.GetMattermostUser(id)
+			if err != nil {
+				m.Logger.Warnf("Failed to get delegation user %s. err=%v", id, err)
+				continue
+			}
+			names = append(names, mattermostUser.Username)
+		}
+		return names
+	}
+
+	return usernames(user.Delegates), usernames(user.Delegators), nil
+}
+
+// GetDelegatorID finds the delegator by username, and checks that the user
+// still manages their calendar.
+func (m *mscalendar) GetDelegatorID(user *User, delegatorUsername string) (string, error) {
+	mattermostUser, err := m.PluginAPI.GetMattermostUserByUsername(strings.TrimPrefix(delegatorUsername, "@"))
+	if err != nil {
+		return "", errors.Errorf("user %s was not found", delegatorUsername)
+	}
+
+	_, err = m.loadDelegator(user, mattermostUser.Id)
+	if err != nil {
+		return "", err
+	}
+	return mattermostUser.Id, nil
+}
+
+func (m *mscalendar) ViewDelegatorCalendar(user *User, delegatorID string, from, to time.Time) ([]*remote.Event, error) {
+	delegator, err := m.loadDelegator(user, delegatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.GetDefaultCalendarView(delegator.Remote.ID, from, to)
+}
+
+func (m *mscalendar) CreateDelegatorEvent(user *User, delegatorID string, event *remote.Event, mattermostUserIDs []string) (*remote.Event, error) {
+	delegator, err := m.loadDelegator(user, delegatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.createEvent(delegator.Remote.ID, event, mattermostUserIDs)
+}
+
+func (m *mscalendar) RespondToDelegatorEvent(user *User, delegatorID, eventID, response string) error {
+	if response == OptionNotResponded {
+		return errors.New("not responded is not a valid response")
+	}
+
+	delegator, err := m.loadDelegator(user, delegatorID)
+	if err != nil {
+		return err
+	}
+
+	return m.respondToEvent(delegator.Remote.ID, eventID, response, nil)
+}
+
+// loadDelegator loads the delegator, after checking that the delegation is
+// known to both users, so that either of them can revoke it.
+func (m *mscalendar) loadDelegator(user *User, delegatorID string) (*store.User, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsString(user.Delegators, delegatorID) {
+		return nil, errors.New("you are not a delegate of this user")
+	}
+	delegator, err := m.Store.LoadUser(delegatorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the delegator")
+	}
+	if !containsString(delegator.Delegates, user.MattermostUserID) {
+		return nil, errors.New("you are not a delegate of this user")
+	}
+	return delegator, nil
+}
+
+func (m *mscalendar) getConnectedUserByUsername(username string) (*User, error) {
+	mattermostUser, err := m.PluginAPI.GetMattermostUserByUsername(strings.TrimPrefix(username, "@"))
+	if err != nil {
+		return nil, errors.Errorf("user %s was not found", username)
+	}
+
+	storedUser, err := m.Store.LoadUser(mattermostUser.Id)
+	if err != nil {
+		return nil, errors.Errorf("@%s has not connected their Microsoft account", mattermostUser.Username)
+	}
+
+	return &User{
+		MattermostUserID: mattermostUser.Id,
+		User:             storedUser,
+		MattermostUser:   mattermostUser,
+	}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	kept := []string{}
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+)
+
+func TestRespondToDelegatorEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		delegators    []string
+		response      string
+		err           string
+		runAssertions func(client *mock_remote.MockClient, s *mock_store.MockStore)
+	}{
+		{
+			name:       "Accepts on the delegator's calendar",
+			delegators: []string{"boss_mm_id"},
+			response:   OptionYes,
+			runAssertions: func(client *mock_remote.MockClient, s *mock_store.MockStore) {
+				s.EXPECT().LoadUser("boss_mm_id").Return(&store.User{
+					Remote:    &remote.User{ID: "boss_remote_id"},
+					Delegates: []string{"user_mm_id"},
+				}, nil)
+				client.EXPECT().AcceptEvent("boss_remote_id", "event_id", nil).Return(nil)
+			},
+		},
+		{
+			name:       "Declines on the delegator's calendar",
+			delegators: []string{"other_mm_id", "boss_mm_id"},
+			response:   OptionNo,
+			runAssertions: func(client *mock_remote.MockClient, s *mock_store.MockStore) {
+				s.EXPECT().LoadUser("boss_mm_id").Return(&store.User{
+					Remote:    &remote.User{ID: "boss_remote_id"},
+					Delegates: []string{"user_mm_id"},
+				}, nil)
+				client.EXPECT().DeclineEvent("boss_remote_id", "event_id", nil).Return(nil)
+			},
+		},
+		{
+			name:     "Not a delegate",
+			response: OptionYes,
+			err:      "you are not a delegate of this user",
+		},
+		{
+			name:       "Revoked by the delegator",
+			delegators: []string{"boss_mm_id"},
+			response:   OptionYes,
+			err:        "you are not a delegate of this user",
+			runAssertions: func(client *mock_remote.MockClient, s *mock_store.MockStore) {
+				s.EXPECT().LoadUser("boss_mm_id").Return(&store.User{
+					Remote: &remote.User{ID: "boss_remote_id"},
+				}, nil)
+			},
+		},
+		{
+			name:       "Not responded",
+			delegators: []string{"boss_mm_id"},
+			response:   OptionNotResponded,
+			err:        "not responded is not a valid response",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			mockStore := mock_store.NewMockStore(ctrl)
+			if tc.runAssertions != nil {
+				tc.runAssertions(mockClient, mockStore)
+			}
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{Store: mockStore}},
+				client: mockClient,
+			}
+			user := newTestEventUser()
+			user.Delegators = tc.delegators
+
+			err := m.RespondToDelegatorEvent(user, "boss_mm_id", "event_id", tc.response)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+const DigestJobInterval = 15 * time.Minute
+
+// Time changes of events starting within urgentChangeWindow skip the digest.
+const urgentChangeWindow = 2 * time.Hour
+
+// digestHours are the hours of the day at which the digests with fixed times
+// are posted.
+var digestHours = map[string][]int{
+	store.NotificationDigestMorningNoon:  {9, 13},
+	store.NotificationDigestTwiceADay:    {9, 17},
+	store.NotificationDigestEveryMorning: {9},
+}
+
+// digestIntervals are the number of hours between the digests posted at
+// regular intervals.
+var digestIntervals = map[string]int{
+	store.NotificationDigestEveryHour:   1,
+	store.NotificationDigestEvery2Hours: 2,
+	store.NotificationDigestEvery4Hours: 4,
+}
+
+type Digests interface {
+	ProcessAllDigests(now time.Time) error
+}
+
+// isUrgentChange tells if the notification of the event can't wait for the
+// digest: it is new, cancelled or moved, and starts, or started before it
+// moved, within urgentChangeWindow.
+func isUrgentChange(event, prior *remote.Event, now time.Time) bool {
+	startsSoon := func(e *remote.Event) bool {
+		if e == nil || e.Start == nil {
+			return false
+		}
+		start := e.Start.Time()
+		return !start.Before(now) && start.Before(now.Add(urgentChangeWindow))
+	}
+
+	if prior == nil || (event.IsCancelled && !prior.IsCancelled) {
+		return startsSoon(event)
+	}
+	if sameDateTime(event.Start, prior.Start) && sameDateTime(event.End, prior.End) {
+		return false
+	}
+	return startsSoon(event) || startsSoon(prior)
+}
+
+func sameDateTime(a, b *remote.DateTime) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Time().Equal(b.Time())
+}
+
+// addToDigest keeps the notification of the event for the creator's next
+// digest. Later notifications of the same event replace it, keeping the
+// changes of both.
+func (processor *notificationProcessor) addToDigest(creator *store.User, event *remote.Event, isNew bool, changes []string, timezone string) error {
+	return processor.Store.ModifyDigest(creator.MattermostUserID, func(digest *store.Digest) {
+		if len(digest.Events) == 0 {
+			digest.Since = time.Now()
+		}
+		digest.Timezone = timezone
+		addDigestEvent(digest, &store.DigestEvent{Event: event, New: isNew, Changes: changes})
+	})
+}
+
+// addDigestEvent adds the notification of an event to the digest, or merges
+// it into the pending notification of the same event.
+func addDigestEvent(digest *store.Digest, de *store.DigestEvent) {
+	var pending *store.DigestEvent
+	for _, e := range digest.Events {
+		if e.Event.ICalUID == de.Event.ICalUID {
+			pending = e
+			break
+		}
+	}
+	if pending == nil {
+		pending = &store.DigestEvent{New: de.New}
+		digest.Events = append(digest.Events, pending)
+	}
+	pending.Event = de.Event
+	for _, change := range de.Changes {
+		if !containsString(pending.Changes, change) {
+			pending.Changes = append(pending.Changes, change)
+		}
+	}
+}
+
+// ProcessAllDigests posts the digests that are due, and those of the users
+// who stopped using digests since notifications were kept for them. Each
+// digest is taken from the store before it is posted, so notifications added
+// meanwhile wait for the next one.
+func (m *mscalendar) ProcessAllDigests(now time.Time) error {
+	userIndex, err := m.Store.LoadUserIndex()
+	if err != nil {
+		return err
+	}
+
+	posted := 0
+	for _, u := range userIndex {
+		_, err = m.Store.LoadDigest(u.MattermostUserID)
+		if err != nil {
+			if err != store.ErrNotFound {
+				m.Logger.Warnf("Error loading the digest of user %s. err=%v", u.MattermostUserID, err)
+			}
+			continue
+		}
+		user, err := m.Store.LoadUser(u.MattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("Error loading user %s for the digest. err=%v", u.MattermostUserID, err)
+			continue
+		}
+		digest, err := m.Store.TakeDigest(u.MattermostUserID, func(digest *store.Digest) bool {
+			return shouldPostDigest(user.Settings.NotificationDigest, digest, now)
+		})
+		if err != nil {
+			m.Logger.Warnf("Error taking the digest of user %s. err=%v", u.MattermostUserID, err)
+			continue
+		}
+		if digest == nil || len(digest.Events) == 0 {
+			continue
+		}
+
+		_, err = m.Poster.DMWithAttachments(u.MattermostUserID, m.newDigestAttachments(digest)...)
+		if err != nil {
+			m.Logger.Warnf("Error posting the digest of user %s. err=%v", u.MattermostUserID, err)
+			m.restoreDigest(u.MattermostUserID, digest)
+			continue
+		}
+		posted++
+	}
+
+	m.Logger.Infof("Posted %d notification digests", posted)
+	return nil
+}
+
+// restoreDigest puts back a digest that could not be posted, before the
+// notifications added since it was taken.
+func (m *mscalendar) restoreDigest(mattermostUserID string, taken *store.Digest) {
+	err := m.Store.ModifyDigest(mattermostUserID, func(digest *store.Digest) {
+		added := digest.Events
+		digest.Events = nil
+		for _, de := range taken.Events {
+			addDigestEvent(digest, de)
+		}
+		for _, de := range added {
+			addDigestEvent(digest, de)
+		}
+		digest.Since = taken.Since
+		digest.Timezone = taken.Timezone
+	})
+	if err != nil {
+		m.Logger.With(bot.LogContext{
+			"MattermostUserID": mattermostUserID,
+		}).Warnf("Error restoring the digest that could not be posted. err=%v", err)
+	}
+}
+
+// shouldPostDigest tells if a time to post the digest has come since its
+// first notification arrived.
+func shouldPostDigest(option string, digest *store.Digest, now time.Time) bool {
+	if option == "" || len(digest.Events) == 0 {
+		return true
+	}
+	slot, ok := lastDigestTime(option, remote.NewDateTime(now.UTC(), "UTC").In(digest.Timezone).Time())
+	return ok && slot.After(digest.Since)
+}
+
+// lastDigestTime finds the latest time at or before now at which digests are
+// posted with the option, in the location of now.
+func lastDigestTime(option string, now time.Time) (time.Time, bool) {
+	if n, ok := digestIntervals[option]; ok {
+		return time.Date(now.Year(), now.Month(), now.Day(), now.Hour()-now.Hour()%n, 0, 0, 0, now.Location()), true
+	}
+
+	hours, ok := digestHours[option]
+	if !ok {
+		return time.Time{}, false
+	}
+	for days := 0; days >= -1; days-- {
+		for i := len(hours) - 1; i >= 0; i-- {
+			t := time.Date(now.Year(), now.Month(), now.Day()+days, hours[i], 0, 0, 0, now.Location())
+			if !t.After(now) {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// newDigestAttachments shows each event of the digest on a row, with a
+// selector to respond to the invitations.
+func (m *mscalendar) newDigestAttachments(digest *store.Digest) []*model.SlackAttachment {
+	attachments := []*model.SlackAttachment{}
+	for _, de := range digest.Events {
+		e := de.Event
+		title := views.EnsureSubject(e.Subject)
+		switch {
+		case e.IsCancelled:
+			title = "(cancelled) " + strings.TrimPrefix(title, "Canceled: ")
+		case de.New:
+			title = "(new) " + title
+		default:
+			title = "(updated) " + title
+		}
+
+		lines := []string{}
+		if when := eventToFields(e, digest.Timezone)[FieldWhen]; when != nil {
+			lines = append(lines, when.Strings()...)
+		}
+		if !de.New && !e.IsCancelled && len(de.Changes) > 0 {
+			lines = append(lines, "Changed: "+strings.Join(de.Changes, ", "))
+		}
+
+		sa := &model.SlackAttachment{
+			Title:     title,
+			TitleLink: e.Weblink,
+			Text:      strings.Join(lines, "\n"),
+			Fallback:  fmt.Sprintf("[%s](%s)", title, e.Weblink),
+		}
+		if e.Organizer != nil && e.Organizer.EmailAddress != nil {
+			sa.AuthorName = e.Organizer.EmailAddress.Name
+		}
+		if e.ResponseRequested && !e.IsOrganizer && !e.IsCancelled && e.ResponseStatus != nil {
+			sa.Actions = NewPostActionForEventResponse(e.ID, e.ResponseStatus.Response, m.actionURL(config.PathRespond))
+		}
+		attachments = append(attachments, sa)
+	}
+
+	if len(attachments) > 0 {
+		attachments[0].Pretext = fmt.Sprintf("**Notification digest**: %d events changed.", len(attachments))
+		if len(attachments) == 1 {
+			attachments[0].Pretext = "**Notification digest**: 1 event changed."
+		}
+	}
+	return attachments
+}
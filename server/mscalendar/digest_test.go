@@ -0,0 +1,153 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+)
+
+func TestIsUrgentChange(t *testing.T) {
+	now := time.Date(2020, 3, 11, 9, 0, 0, 0, time.UTC)
+	newEvent := func(start time.Time) *remote.Event {
+		return &remote.Event{
+			Start: remote.NewDateTime(start, "UTC"),
+			End:   remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		event    *remote.Event
+		prior    *remote.Event
+		expected bool
+	}{
+		{name: "New event starting soon", event: newEvent(now.Add(time.Hour)), expected: true},
+		{name: "New event starting later", event: newEvent(now.Add(3 * time.Hour)), expected: false},
+		{name: "Moved to start soon", event: newEvent(now.Add(time.Hour)), prior: newEvent(now.Add(24 * time.Hour)), expected: true},
+		{name: "Moved away from starting soon", event: newEvent(now.Add(24 * time.Hour)), prior: newEvent(now.Add(time.Hour)), expected: true},
+		{name: "Moved later", event: newEvent(now.Add(24 * time.Hour)), prior: newEvent(now.Add(5 * time.Hour)), expected: false},
+		{name: "Not moved", event: newEvent(now.Add(time.Hour)), prior: newEvent(now.Add(time.Hour)), expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isUrgentChange(tc.event, tc.prior, now))
+		})
+	}
+}
+
+func TestShouldPostDigest(t *testing.T) {
+	since := time.Date(2020, 3, 11, 9, 30, 0, 0, time.UTC)
+	digest := &store.Digest{
+		Events:   []*store.DigestEvent{{Event: &remote.Event{}}},
+		Since:    since,
+		Timezone: "UTC",
+	}
+
+	for _, tc := range []struct {
+		name     string
+		option   string
+		now      time.Time
+		expected bool
+	}{
+		{name: "Digests turned off", option: "", now: since, expected: true},
+		{name: "Same hour", option: store.NotificationDigestEveryHour, now: since.Add(20 * time.Minute), expected: false},
+		{name: "Next hour", option: store.NotificationDigestEveryHour, now: since.Add(30 * time.Minute), expected: true},
+		{name: "Before the even hour", option: store.NotificationDigestEvery2Hours, now: since.Add(20 * time.Minute), expected: false},
+		{name: "At the even hour", option: store.NotificationDigestEvery2Hours, now: since.Add(30 * time.Minute), expected: true},
+		{name: "Before the fixed time", option: store.NotificationDigestTwiceADay, now: since.Add(7 * time.Hour), expected: false},
+		{name: "After the fixed time", option: store.NotificationDigestTwiceADay, now: since.Add(8 * time.Hour), expected: true},
+		{name: "Next morning", option: store.NotificationDigestEveryMorning, now: since.Add(24 * time.Hour), expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, shouldPostDigest(tc.option, digest, tc.now))
+		})
+	}
+}
+
+func TestProcessAllDigests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockLogger := mock_bot.NewMockLogger(ctrl)
+
+	now := time.Date(2020, 3, 11, 17, 5, 0, 0, time.UTC)
+	start := time.Date(2020, 3, 12, 10, 0, 0, 0, time.UTC)
+	event := &remote.Event{
+		ID:                "event_id",
+		ICalUID:           "ical_uid",
+		Subject:           "Planning",
+		Start:             remote.NewDateTime(start, "UTC"),
+		End:               remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Organizer:         &remote.Attendee{EmailAddress: &remote.EmailAddress{Name: "Alice"}},
+		Location:          &remote.Location{},
+		ResponseStatus:    &remote.EventResponseStatus{Response: ResponseNone},
+		ResponseRequested: true,
+	}
+
+	mockStore.EXPECT().LoadUserIndex().Return(store.UserIndex{
+		{MattermostUserID: "due_mm_id"},
+		{MattermostUserID: "waiting_mm_id"},
+		{MattermostUserID: "no_digest_mm_id"},
+	}, nil)
+	dueDigest := &store.Digest{
+		Events:   []*store.DigestEvent{{Event: event, Changes: []string{FieldWhen}}},
+		Since:    now.Add(-2 * time.Hour),
+		Timezone: "UTC",
+	}
+	waitingDigest := &store.Digest{
+		Events:   []*store.DigestEvent{{Event: event, New: true}},
+		Since:    now.Add(-time.Minute),
+		Timezone: "UTC",
+	}
+	takeDigest := func(digest *store.Digest) func(string, func(*store.Digest) bool) (*store.Digest, error) {
+		return func(mattermostUserID string, isDue func(*store.Digest) bool) (*store.Digest, error) {
+			if !isDue(digest) {
+				return nil, nil
+			}
+			return digest, nil
+		}
+	}
+	mockStore.EXPECT().LoadDigest("due_mm_id").Return(dueDigest, nil)
+	mockStore.EXPECT().LoadUser("due_mm_id").Return(&store.User{Settings: store.Settings{NotificationDigest: store.NotificationDigestTwiceADay}}, nil)
+	mockStore.EXPECT().TakeDigest("due_mm_id", gomock.Any()).DoAndReturn(takeDigest(dueDigest))
+	mockStore.EXPECT().LoadDigest("waiting_mm_id").Return(waitingDigest, nil)
+	mockStore.EXPECT().LoadUser("waiting_mm_id").Return(&store.User{Settings: store.Settings{NotificationDigest: store.NotificationDigestTwiceADay}}, nil)
+	mockStore.EXPECT().TakeDigest("waiting_mm_id", gomock.Any()).DoAndReturn(takeDigest(waitingDigest))
+	mockStore.EXPECT().LoadDigest("no_digest_mm_id").Return(nil, store.ErrNotFound)
+
+	mockPoster.EXPECT().DMWithAttachments("due_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID string, attachments ...*model.SlackAttachment) (string, error) {
+		require.Len(t, attachments, 1)
+		require.Equal(t, "(updated) Planning", attachments[0].Title)
+		require.Contains(t, attachments[0].Text, "Changed: When")
+		require.Equal(t, "**Notification digest**: 1 event changed.", attachments[0].Pretext)
+		require.Len(t, attachments[0].Actions, 1)
+		require.Equal(t, "event_id", attachments[0].Actions[0].Integration.Context[config.EventIDKey])
+		return "post_id", nil
+	})
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any())
+
+	m := &mscalendar{
+		Env: Env{
+			Config: &config.Config{PluginURLPath: "/plugins/mscalendar"},
+			Dependencies: &Dependencies{
+				Store:  mockStore,
+				Poster: mockPoster,
+				Logger: mockLogger,
+			},
+		},
+	}
+	require.NoError(t, m.ProcessAllDigests(now))
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/timeexpr"
+)
+
+// EventTimeLayout is the format of the event times filled in dialogs, in the
+// time zone of the user's calendar. Times can also be entered like "tomorrow
+// 3pm".
+const (
+	EventTimeLayout  = "2006-01-02 3:04PM"
+	EventTimeExample = "2020-03-10 2:30PM"
+)
+
+const (
+	EventDialogSubjectField  = "subject"
+	EventDialogLocationField = "location"
+	EventDialogStartField    = "start"
+	EventDialogEndField      = "end"
+	EventDialogMessageField  = "message"
+)
+
+// EventDialogState identifies the event edited or cancelled in a dialog, and
+// the post the dialog was opened from, if any.
+type EventDialogState struct {
+	EventID        string
+	PostID         string
+	RescheduleOnly bool
+}
+
+// ParseEventTime parses a time like "tomorrow 3pm" or in EventTimeLayout,
+// relative to the current time in the given time zone.
+func ParseEventTime(value, timezone string) (time.Time, error) {
+	e, err := timeexpr.Parse(value, timeexpr.NowIn(timezone))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !e.HasTime || !e.End.IsZero() {
+		return time.Time{}, errors.Errorf("please enter a single time, like tomorrow 3pm or %s", EventTimeExample)
+	}
+	return e.Start, nil
+}
+
+// eventTimeHelp is the help text of the time fields of event dialogs.
+func eventTimeHelp(timezone string) string {
+	return "For example tomorrow 3pm or " + EventTimeExample + ", in " + timezone + "."
+}
+
+func (m *mscalendar) GetEvent(user *User, eventID string) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.GetEvent(user.Remote.ID, eventID)
+}
+
+func (m *mscalendar) UpdateEvent(user *User, event *remote.Event) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.UpdateEvent(user.Remote.ID, event)
+}
+
+// MoveEvent reschedules the event. The duration of the event is kept when end
+// is zero.
+func (m *mscalendar) MoveEvent(user *User, eventID string, start, end time.Time) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if end.IsZero() {
+		event, err := m.client.GetEvent(user.Remote.ID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		end = start.Add(event.End.Time().Sub(event.Start.Time()))
+	}
+	if !end.After(start) {
+		return nil, errors.New("the end of the event must be after its start")
+	}
+
+	return m.client.UpdateEvent(user.Remote.ID, &remote.Event{
+		ID:    eventID,
+		Start: remote.NewDateTime(start.UTC(), "UTC"),
+		End:   remote.NewDateTime(end.UTC(), "UTC"),
+	})
+}
+
+// CancelEvent cancels a meeting organized by the user, sending the message to
+// the attendees. Events without attendees are deleted.
+func (m *mscalendar) CancelEvent(user *User, eventID, message string) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return err
+	}
+	if !event.IsOrganizer {
+		return errors.New("only the organizer can cancel the event")
+	}
+
+	if len(event.Attendees) == 0 {
+		return m.client.DeleteEvent(user.Remote.ID, eventID)
+	}
+	return m.client.CancelEvent(user.Remote.ID, eventID, message)
+}
+
+// OpenEditEventDialog opens the dialog to edit the subject, location and time
+// of the event, or only its time when rescheduling.
+func (m *mscalendar) OpenEditEventDialog(user *User, triggerID, eventID, postID string, rescheduleOnly bool) error {
+	event, err := m.GetEvent(user, eventID)
+	if err != nil {
+		return err
+	}
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return err
+	}
+
+	elements := []model.DialogElement{}
+	if !rescheduleOnly {
+		location := ""
+		if event.Location != nil {
+			location = event.Location.DisplayName
+		}
+		elements = append(elements, model.DialogElement{
+			DisplayName: "Subject",
+			Name:        EventDialogSubjectField,
+			Type:        "text",
+			Default:     event.Subject,
+		}, model.DialogElement{
+			DisplayName: "Location",
+			Name:        EventDialogLocationField,
+			Type:        "text",
+			Optional:    true,
+			Default:     location,
+		})
+	}
+
+	helpText := eventTimeHelp(timezone)
+	elements = append(elements, model.DialogElement{
+		DisplayName: "Start",
+		Name:        EventDialogStartField,
+		Type:        "text",
+		Default:     event.Start.In(timezone).Time().Format(EventTimeLayout),
+		HelpText:    helpText,
+	}, model.DialogElement{
+		DisplayName: "End",
+		Name:        EventDialogEndField,
+		Type:        "text",
+		Default:     event.End.In(timezone).Time().Format(EventTimeLayout),
+		HelpText:    helpText,
+	})
+
+	title := "Edit event"
+	if rescheduleOnly {
+		title = "Reschedule event"
+	}
+	return m.openEventDialog(triggerID, config.PathEditEvent, title, "Save", elements, &EventDialogState{
+		EventID:        eventID,
+		PostID:         postID,
+		RescheduleOnly: rescheduleOnly,
+	})
+}
+
+// OpenCancelEventDialog opens the dialog to cancel the event with a message
+// to the attendees.
+func (m *mscalendar) OpenCancelEventDialog(user *User, triggerID, eventID, postID string) error {
+	event, err := m.GetEvent(user, eventID)
+	if err != nil {
+		return err
+	}
+	if !event.IsOrganizer {
+		return errors.New("only the organizer can cancel the event")
+	}
+
+	elements := []model.DialogElement{{
+		DisplayName: "Message",
+		Name:        EventDialogMessageField,
+		Type:        "textarea",
+		Optional:    true,
+		Placeholder: "Let the attendees know why the meeting is cancelled",
+		HelpText:    "Cancelling " + views.EnsureSubject(event.Subject),
+	}}
+
+	return m.openEventDialog(triggerID, config.PathCancelEvent, "Cancel meeting", "Cancel meeting", elements, &EventDialogState{
+		EventID: eventID,
+		PostID:  postID,
+	})
+}
+
+func (m *mscalendar) openEventDialog(triggerID, path, title, submitLabel string, elements []model.DialogElement, state *EventDialogState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return m.PluginAPI.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerID,
+		URL:       m.Config.PluginURL + config.PathDialogs + path,
+		Dialog: model.Dialog{
+			Title:       title,
+			Elements:    elements,
+			SubmitLabel: submitLabel,
+			State:       string(b),
+		},
+	})
+}
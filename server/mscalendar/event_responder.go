@@ -5,13 +5,15 @@ package mscalendar
 
 import (
 	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 )
 
 type EventResponder interface {
 	AcceptEvent(user *User, eventID string) error
 	DeclineEvent(user *User, eventID string) error
 	TentativelyAcceptEvent(user *User, eventID string) error
-	RespondToEvent(user *User, eventID, response string) error
+	RespondToEvent(user *User, eventID, response string, options *remote.EventResponseOptions) error
 }
 
 func (m *mscalendar) AcceptEvent(user *User, eventID string) error {
@@ -23,7 +25,7 @@ func (m *mscalendar) AcceptEvent(user *User, eventID string) error {
 		return err
 	}
 
-	return m.client.AcceptEvent(user.Remote.ID, eventID)
+	return m.client.AcceptEvent(user.Remote.ID, eventID, nil)
 }
 
 func (m *mscalendar) DeclineEvent(user *User, eventID string) error {
@@ -35,7 +37,7 @@ func (m *mscalendar) DeclineEvent(user *User, eventID string) error {
 		return err
 	}
 
-	return m.client.DeclineEvent(user.Remote.ID, eventID)
+	return m.client.DeclineEvent(user.Remote.ID, eventID, nil)
 }
 
 func (m *mscalendar) TentativelyAcceptEvent(user *User, eventID string) error {
@@ -47,13 +49,21 @@ func (m *mscalendar) TentativelyAcceptEvent(user *User, eventID string) error {
 		return err
 	}
 
-	return m.client.TentativelyAcceptEvent(user.Remote.ID, eventID)
+	return m.client.TentativelyAcceptEvent(user.Remote.ID, eventID, nil)
 }
 
-func (m *mscalendar) RespondToEvent(user *User, eventID, response string) error {
+func (m *mscalendar) RespondToEvent(user *User, eventID, response string, options *remote.EventResponseOptions) error {
 	if response == OptionNotResponded {
 		return errors.New("not responded is not a valid response")
 	}
+	if options != nil && options.ProposedNewTime != nil {
+		if response == OptionYes {
+			return errors.New("a new time can only be proposed when declining or tentatively accepting")
+		}
+		if !options.SendResponse {
+			return errors.New("a new time can only be proposed when sending a response")
+		}
+	}
 
 	err := m.Filter(
 		withClient,
@@ -63,13 +73,19 @@ func (m *mscalendar) RespondToEvent(user *User, eventID, response string) error
 		return err
 	}
 
+	return m.respondToEvent(user.Remote.ID, eventID, response, options)
+}
+
+// respondToEvent responds to the event in the calendar of remoteUserID, which
+// may be the calendar of a delegator.
+func (m *mscalendar) respondToEvent(remoteUserID, eventID, response string, options *remote.EventResponseOptions) error {
 	switch response {
 	case OptionYes:
-		return m.client.AcceptEvent(user.Remote.ID, eventID)
+		return m.client.AcceptEvent(remoteUserID, eventID, options)
 	case OptionNo:
-		return m.client.DeclineEvent(user.Remote.ID, eventID)
+		return m.client.DeclineEvent(remoteUserID, eventID, options)
 	case OptionMaybe:
-		return m.client.TentativelyAcceptEvent(user.Remote.ID, eventID)
+		return m.client.TentativelyAcceptEvent(remoteUserID, eventID, options)
 	default:
 		return errors.New(response + " is not a valid response")
 	}
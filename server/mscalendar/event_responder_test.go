@@ -0,0 +1,100 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+func TestRespondToEvent(t *testing.T) {
+	proposedNewTime := &remote.TimeSlot{
+		Start: remote.NewDateTime(time.Date(2020, 2, 12, 14, 0, 0, 0, time.UTC), "UTC"),
+		End:   remote.NewDateTime(time.Date(2020, 2, 12, 15, 0, 0, 0, time.UTC), "UTC"),
+	}
+
+	for _, tc := range []struct {
+		name          string
+		response      string
+		options       *remote.EventResponseOptions
+		err           string
+		runAssertions func(client *mock_remote.MockClient, options *remote.EventResponseOptions)
+	}{
+		{
+			name:     "Accept without options",
+			response: OptionYes,
+			runAssertions: func(client *mock_remote.MockClient, options *remote.EventResponseOptions) {
+				client.EXPECT().AcceptEvent("user_remote_id", "event_id", options).Return(nil)
+			},
+		},
+		{
+			name:     "Decline with a comment and a new time",
+			response: OptionNo,
+			options:  &remote.EventResponseOptions{Comment: "Can we meet later?", SendResponse: true, ProposedNewTime: proposedNewTime},
+			runAssertions: func(client *mock_remote.MockClient, options *remote.EventResponseOptions) {
+				client.EXPECT().DeclineEvent("user_remote_id", "event_id", options).Return(nil)
+			},
+		},
+		{
+			name:     "Tentatively accept without sending a response",
+			response: OptionMaybe,
+			options:  &remote.EventResponseOptions{SendResponse: false},
+			runAssertions: func(client *mock_remote.MockClient, options *remote.EventResponseOptions) {
+				client.EXPECT().TentativelyAcceptEvent("user_remote_id", "event_id", options).Return(nil)
+			},
+		},
+		{
+			name:     "Accept with a new time",
+			response: OptionYes,
+			options:  &remote.EventResponseOptions{SendResponse: true, ProposedNewTime: proposedNewTime},
+			err:      "a new time can only be proposed when declining or tentatively accepting",
+		},
+		{
+			name:     "New time without sending a response",
+			response: OptionNo,
+			options:  &remote.EventResponseOptions{SendResponse: false, ProposedNewTime: proposedNewTime},
+			err:      "a new time can only be proposed when sending a response",
+		},
+		{
+			name:     "Not responded",
+			response: OptionNotResponded,
+			err:      "not responded is not a valid response",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			if tc.runAssertions != nil {
+				tc.runAssertions(mockClient, tc.options)
+			}
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{}},
+				client: mockClient,
+			}
+			user := &User{
+				MattermostUserID: "user_mm_id",
+				User:             &store.User{Remote: &remote.User{ID: "user_remote_id"}},
+				MattermostUser:   &model.User{},
+			}
+
+			err := m.RespondToEvent(user, "event_id", tc.response, tc.options)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+func newTestEventUser() *User {
+	return &User{
+		MattermostUserID: "user_mm_id",
+		User:             &store.User{Remote: &remote.User{ID: "user_remote_id"}},
+		MattermostUser:   &model.User{},
+	}
+}
+
+func TestMoveEvent(t *testing.T) {
+	start := time.Date(2020, 2, 12, 14, 0, 0, 0, time.UTC)
+	event := &remote.Event{
+		ID:    "event_id",
+		Start: remote.NewDateTime(time.Date(2020, 2, 10, 9, 0, 0, 0, time.UTC), "UTC"),
+		End:   remote.NewDateTime(time.Date(2020, 2, 10, 9, 30, 0, 0, time.UTC), "UTC"),
+	}
+
+	for _, tc := range []struct {
+		name          string
+		end           time.Time
+		err           string
+		runAssertions func(client *mock_remote.MockClient)
+	}{
+		{
+			name: "Keeps the duration",
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().GetEvent("user_remote_id", "event_id").Return(event, nil)
+				client.EXPECT().UpdateEvent("user_remote_id", &remote.Event{
+					ID:    "event_id",
+					Start: remote.NewDateTime(start, "UTC"),
+					End:   remote.NewDateTime(start.Add(30*time.Minute), "UTC"),
+				}).Return(event, nil)
+			},
+		},
+		{
+			name: "Uses the given end",
+			end:  start.Add(2 * time.Hour),
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().UpdateEvent("user_remote_id", &remote.Event{
+					ID:    "event_id",
+					Start: remote.NewDateTime(start, "UTC"),
+					End:   remote.NewDateTime(start.Add(2*time.Hour), "UTC"),
+				}).Return(event, nil)
+			},
+		},
+		{
+			name: "End before start",
+			end:  start.Add(-time.Hour),
+			err:  "the end of the event must be after its start",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			if tc.runAssertions != nil {
+				tc.runAssertions(mockClient)
+			}
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{}},
+				client: mockClient,
+			}
+
+			_, err := m.MoveEvent(newTestEventUser(), "event_id", start, tc.end)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCancelEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		event         *remote.Event
+		err           string
+		runAssertions func(client *mock_remote.MockClient)
+	}{
+		{
+			name: "Meeting with attendees is cancelled",
+			event: &remote.Event{
+				ID:          "event_id",
+				IsOrganizer: true,
+				Attendees:   []*remote.Attendee{{EmailAddress: &remote.EmailAddress{Address: "other@example.com"}}},
+			},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().CancelEvent("user_remote_id", "event_id", "Sorry").Return(nil)
+			},
+		},
+		{
+			name:  "Event without attendees is deleted",
+			event: &remote.Event{ID: "event_id", IsOrganizer: true},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().DeleteEvent("user_remote_id", "event_id").Return(nil)
+			},
+		},
+		{
+			name:  "Not the organizer",
+			event: &remote.Event{ID: "event_id"},
+			err:   "only the organizer can cancel the event",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(tc.event, nil)
+			if tc.runAssertions != nil {
+				tc.runAssertions(mockClient)
+			}
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{}},
+				client: mockClient,
+			}
+
+			err := m.CancelEvent(newTestEventUser(), "event_id", "Sorry")
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseEventTime(t *testing.T) {
+	for _, tc := range []struct {
+		value         string
+		expected      time.Time
+		expectedError string
+	}{
+		{value: "2020-03-10 2:30PM", expected: time.Date(2020, 3, 10, 14, 30, 0, 0, time.UTC)},
+		{value: "2020-03-10 9am", expected: time.Date(2020, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{value: "fri", expectedError: "please enter a single time, like tomorrow 3pm or " + EventTimeExample},
+		{value: "2020-03-10 9-10am", expectedError: "please enter a single time, like tomorrow 3pm or " + EventTimeExample},
+		{value: "2020-03-10 3", expectedError: `"3" is ambiguous, please use 3am or 3pm`},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			parsed, err := ParseEventTime(tc.value, "UTC")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(parsed))
+		})
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/tz"
+)
+
+const FocusJobInterval = 5 * time.Minute
+
+const (
+	DefaultFocusDuration = time.Hour
+	MaxFocusDuration     = 12 * time.Hour
+
+	FocusEventSubject              = "Focus time"
+	DefaultFocusAutoRespondMessage = "This user is in focus time and will reply later."
+)
+
+// The daily focus time is booked at focusBookingHour, within the working
+// hours, in blocks of at least minFocusBlock.
+const (
+	focusBookingHour  = 8
+	focusDayStartHour = 9
+	focusDayEndHour   = 17
+	minFocusBlock     = time.Hour
+)
+
+type FocusTime interface {
+	StartFocusTime(user *User, start, end time.Time) (*remote.Event, error)
+	StopFocusTime(user *User) (bool, error)
+	ProcessAllFocusTime(now time.Time) error
+}
+
+// StartFocusTime books a private, busy event for the focus time. The user is
+// set to Do Not Disturb, and DMs are answered automatically, until it ends.
+func (m *mscalendar) StartFocusTime(user *User, start, end time.Time) (*remote.Event, error) {
+	if !end.After(start) {
+		return nil, errors.New("focus time must end after it starts")
+	}
+	if end.Sub(start) > MaxFocusDuration {
+		return nil, errors.Errorf("focus time can last up to %d hours", int(MaxFocusDuration.Hours()))
+	}
+
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event, session, err := m.createFocusEvent(m.client, user.User, start, end)
+	if err != nil {
+		return nil, err
+	}
+	updated := m.updateFocusSessions(user.MattermostUserID, []*store.FocusSession{session}, time.Now())
+
+	err = m.Store.ModifyFocusTime(user.MattermostUserID, func(focus *store.FocusTime) {
+		focus.Sessions = applyFocusUpdates(append(focus.Sessions, session), updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// StopFocusTime ends the focus time in progress early, shortening its event
+// to end now. It returns false when the user is not in focus time.
+func (m *mscalendar) StopFocusTime(user *User) (bool, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return false, err
+	}
+	focus, err := m.Store.LoadFocusTime(user.MattermostUserID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	stopped := map[string]*store.FocusSession{}
+	for _, s := range focus.Sessions {
+		if !s.Started {
+			continue
+		}
+		m.endFocusSession(user.MattermostUserID, s)
+		_, err = m.client.UpdateEvent(user.Remote.ID, &remote.Event{
+			ID:  s.EventID,
+			End: remote.NewDateTime(now.UTC(), "UTC"),
+		})
+		if err != nil {
+			m.Logger.Warnf("StopFocusTime error shortening the focus time event. err=%v", err)
+		}
+		stopped[s.EventID] = nil
+	}
+	if len(stopped) == 0 {
+		return false, nil
+	}
+
+	err = m.Store.ModifyFocusTime(user.MattermostUserID, func(focus *store.FocusTime) {
+		focus.Sessions = applyFocusUpdates(focus.Sessions, stopped)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ProcessAllFocusTime starts and ends the focus sessions of the users, and
+// books the daily focus time of those who chose to.
+func (m *mscalendar) ProcessAllFocusTime(now time.Time) error {
+	userIndex, err := m.Store.LoadUserIndex()
+	if err != nil {
+		return err
+	}
+
+	for _, u := range userIndex {
+		user, err := m.Store.LoadUser(u.MattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("Error loading user %s for focus time. err=%v", u.MattermostUserID, err)
+			continue
+		}
+		focus, err := m.Store.LoadFocusTime(u.MattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("Error loading the focus time of user %s. err=%v", u.MattermostUserID, err)
+			continue
+		}
+		if len(focus.Sessions) == 0 && user.Settings.DailyFocusHours == 0 {
+			continue
+		}
+
+		booked := []*store.FocusSession{}
+		nextBooking := time.Time{}
+		if user.Settings.DailyFocusHours > 0 && !now.Before(focus.NextBooking) {
+			booked, nextBooking, err = m.bookDailyFocusTime(user, now)
+			if err != nil {
+				m.Logger.Warnf("Error booking the daily focus time of user %s. err=%v", user.MattermostUserID, err)
+			}
+		}
+		updated := m.updateFocusSessions(user.MattermostUserID, append(focus.Sessions, booked...), now)
+		if len(booked) == 0 && nextBooking.IsZero() && len(updated) == 0 {
+			continue
+		}
+
+		err = m.Store.ModifyFocusTime(user.MattermostUserID, func(focus *store.FocusTime) {
+			focus.Sessions = applyFocusUpdates(append(focus.Sessions, booked...), updated)
+			if !nextBooking.IsZero() {
+				focus.NextBooking = nextBooking
+			}
+		})
+		if err != nil {
+			m.Logger.Warnf("Error storing the focus time of user %s. err=%v", user.MattermostUserID, err)
+		}
+	}
+	return nil
+}
+
+func (m *mscalendar) createFocusEvent(client remote.Client, user *store.User, start, end time.Time) (*remote.Event, *store.FocusSession, error) {
+	event, err := client.CreateEvent(user.Remote.ID, &remote.Event{
+		Subject:     FocusEventSubject,
+		ShowAs:      "busy",
+		Sensitivity: "private",
+		Start:       remote.NewDateTime(start.UTC(), "UTC"),
+		End:         remote.NewDateTime(end.UTC(), "UTC"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, &store.FocusSession{
+		EventID: event.ID,
+		ICalUID: event.ICalUID,
+		Start:   start,
+		End:     end,
+	}, nil
+}
+
+// bookDailyFocusTime books the daily focus time in the free blocks of the
+// working hours left today, on working days. It returns the booked sessions,
+// and when to book next, tomorrow morning, unless it should be tried again.
+func (m *mscalendar) bookDailyFocusTime(user *store.User, now time.Time) ([]*store.FocusSession, time.Time, error) {
+	client := m.Remote.MakeClient(context.Background(), user.OAuth2Token)
+	mailboxSettings, err := client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	loc, err := time.LoadLocation(tz.Go(mailboxSettings.TimeZone))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	localNow := now.In(loc)
+	atHour := func(hour int) time.Time {
+		return time.Date(localNow.Year(), localNow.Month(), localNow.Day(), hour, 0, 0, 0, loc)
+	}
+	nextBooking := atHour(focusBookingHour).AddDate(0, 0, 1)
+
+	if localNow.Weekday() == time.Saturday || localNow.Weekday() == time.Sunday {
+		return nil, nextBooking, nil
+	}
+	start := localNow.Truncate(FreeBusyInterval)
+	if start.Before(localNow) {
+		start = start.Add(FreeBusyInterval)
+	}
+	if dayStart := atHour(focusDayStartHour); start.Before(dayStart) {
+		start = dayStart
+	}
+	end := atHour(focusDayEndHour)
+	if end.Sub(start) < minFocusBlock {
+		return nil, nextBooking, nil
+	}
+
+	schedules, err := client.GetSchedule(
+		[]*remote.ScheduleUserInfo{{RemoteUserID: user.Remote.ID, Mail: user.Remote.Mail}},
+		remote.NewDateTime(start.UTC(), "UTC"),
+		remote.NewDateTime(end.UTC(), "UTC"),
+		int(FreeBusyInterval.Minutes()))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(schedules) == 0 || schedules[0].Error != nil {
+		return nil, time.Time{}, errors.New("free/busy information is not available")
+	}
+
+	booked := []*store.FocusSession{}
+	duration := time.Duration(user.Settings.DailyFocusHours) * time.Hour
+	for _, b := range findFocusBlocks(schedules[0].AvailabilityView, start, FreeBusyInterval, duration) {
+		_, session, err := m.createFocusEvent(client, user, b.start, b.end)
+		if err != nil {
+			m.Logger.Warnf("Error creating a focus time event for user %s. err=%v", user.MattermostUserID, err)
+			continue
+		}
+		booked = append(booked, session)
+	}
+	return booked, nextBooking, nil
+}
+
+type focusBlock struct {
+	start time.Time
+	end   time.Time
+}
+
+// findFocusBlocks picks the free runs of the availability view, longest first,
+// until they add up to the duration. Blocks are cut short to what is left, but
+// never below minFocusBlock, and are returned in order.
+func findFocusBlocks(view remote.AvailabilityView, start time.Time, interval, duration time.Duration) []focusBlock {
+	runs := []focusBlock{}
+	runStart := -1
+	for i := 0; i <= len(view); i++ {
+		free := i < len(view) && view[i] == '0'
+		if free && runStart < 0 {
+			runStart = i
+		}
+		if !free && runStart >= 0 {
+			runs = append(runs, focusBlock{
+				start: start.Add(time.Duration(runStart) * interval),
+				end:   start.Add(time.Duration(i) * interval),
+			})
+			runStart = -1
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].end.Sub(runs[i].start) > runs[j].end.Sub(runs[j].start)
+	})
+
+	blocks := []focusBlock{}
+	left := duration
+	for _, r := range runs {
+		if left < minFocusBlock {
+			break
+		}
+		length := r.end.Sub(r.start)
+		if length > left {
+			length = left
+		}
+		if rest := left - length; rest > 0 && rest < minFocusBlock {
+			length = left - minFocusBlock
+		}
+		if length < minFocusBlock {
+			continue
+		}
+		blocks = append(blocks, focusBlock{start: r.start, end: r.start.Add(length)})
+		left -= length
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].start.Before(blocks[j].start)
+	})
+	return blocks
+}
+
+// updateFocusSessions starts the focus sessions that are due, and ends those
+// that are over. It returns the sessions that changed by event ID, those that
+// are over being nil, for applyFocusUpdates to store.
+func (m *mscalendar) updateFocusSessions(mattermostUserID string, sessions []*store.FocusSession, now time.Time) map[string]*store.FocusSession {
+	updated := map[string]*store.FocusSession{}
+	for _, s := range sessions {
+		if !now.Before(s.End) {
+			if s.Started {
+				m.endFocusSession(mattermostUserID, s)
+			}
+			updated[s.EventID] = nil
+			continue
+		}
+		if !s.Started && !now.Before(s.Start) {
+			m.startFocusSession(mattermostUserID, s)
+			updated[s.EventID] = s
+		}
+	}
+	return updated
+}
+
+// applyFocusUpdates replaces the sessions that changed, and drops those that
+// are over.
+func applyFocusUpdates(sessions []*store.FocusSession, updated map[string]*store.FocusSession) []*store.FocusSession {
+	result := []*store.FocusSession{}
+	for _, s := range sessions {
+		if u, ok := updated[s.EventID]; ok {
+			if u == nil {
+				continue
+			}
+			s = u
+		}
+		result = append(result, s)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// startFocusSession sets the user to Do Not Disturb, remembering the status
+// to restore as the status sync does.
+func (m *mscalendar) startFocusSession(mattermostUserID string, session *store.FocusSession) {
+	session.Started = true
+	log := m.Logger.With(bot.LogContext{
+		"MattermostUserID": mattermostUserID,
+	})
+
+	status, err := m.PluginAPI.GetMattermostUserStatus(mattermostUserID)
+	if err != nil {
+		log.Warnf("startFocusSession error getting the status. err=%v", err)
+		return
+	}
+	if status.Status == model.STATUS_DND {
+		return
+	}
+	session.PriorStatus = model.STATUS_ONLINE
+	if status.Manual {
+		session.PriorStatus = status.Status
+	}
+
+	_, err = m.PluginAPI.UpdateMattermostUserStatus(mattermostUserID, model.STATUS_DND)
+	if err != nil {
+		log.Warnf("startFocusSession error setting the status. err=%v", err)
+	}
+}
+
+// endFocusSession restores the status the user had before the focus time,
+// unless they changed it since.
+func (m *mscalendar) endFocusSession(mattermostUserID string, session *store.FocusSession) {
+	if session.PriorStatus == "" {
+		return
+	}
+	log := m.Logger.With(bot.LogContext{
+		"MattermostUserID": mattermostUserID,
+	})
+
+	status, err := m.PluginAPI.GetMattermostUserStatus(mattermostUserID)
+	if err != nil {
+		log.Warnf("endFocusSession error getting the status. err=%v", err)
+		return
+	}
+	if status.Status != model.STATUS_DND {
+		return
+	}
+
+	_, err = m.PluginAPI.UpdateMattermostUserStatus(mattermostUserID, session.PriorStatus)
+	if err != nil {
+		log.Warnf("endFocusSession error restoring the status. err=%v", err)
+	}
+}
+
+// isInFocusTime tells if a focus session of the user is in progress.
+func isInFocusTime(focus *store.FocusTime, now time.Time) bool {
+	for _, s := range focus.Sessions {
+		if s.Started && !now.Before(s.Start) && now.Before(s.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutFocusTime drops the focus time events of the user, whose status is
+// handled by the focus sessions rather than the status sync.
+func withoutFocusTime(events []*remote.Event, focus *store.FocusTime) []*remote.Event {
+	if len(focus.Sessions) == 0 {
+		return events
+	}
+	result := []*remote.Event{}
+	for _, e := range events {
+		isFocusTime := false
+		for _, s := range focus.Sessions {
+			if s.ICalUID != "" && s.ICalUID == e.ICalUID {
+				isFocusTime = true
+				break
+			}
+		}
+		if !isFocusTime {
+			result = append(result, e)
+		}
+	}
+	return result
+}
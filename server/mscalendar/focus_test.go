@@ -0,0 +1,185 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+func TestFindFocusBlocks(t *testing.T) {
+	start := time.Date(2020, 3, 10, 9, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2020, 3, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		view     remote.AvailabilityView
+		duration time.Duration
+		expected []focusBlock
+	}{
+		{
+			name:     "Longest free run first",
+			view:     "0022000000220000",
+			duration: 2 * time.Hour,
+			expected: []focusBlock{{start: at(11, 0), end: at(13, 0)}},
+		},
+		{
+			name:     "Split across runs",
+			view:     "0002000",
+			duration: 3 * time.Hour,
+			expected: []focusBlock{{start: at(9, 0), end: at(10, 30)}, {start: at(11, 0), end: at(12, 30)}},
+		},
+		{
+			name:     "Keeps the last block an hour long",
+			view:     "0002000",
+			duration: 2 * time.Hour,
+			expected: []focusBlock{{start: at(9, 0), end: at(10, 0)}, {start: at(11, 0), end: at(12, 0)}},
+		},
+		{
+			name:     "Short runs skipped",
+			view:     "020202",
+			duration: time.Hour,
+			expected: []focusBlock{},
+		},
+		{
+			name:     "Tentative is not free",
+			view:     "0100",
+			duration: 2 * time.Hour,
+			expected: []focusBlock{{start: at(10, 0), end: at(11, 0)}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, findFocusBlocks(tc.view, start, FreeBusyInterval, tc.duration))
+		})
+	}
+}
+
+func TestStartFocusTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+	start := time.Now()
+	end := start.Add(time.Hour)
+	mockClient.EXPECT().CreateEvent("user_remote_id", gomock.Any()).DoAndReturn(func(_ string, e *remote.Event) (*remote.Event, error) {
+		require.Equal(t, FocusEventSubject, e.Subject)
+		require.Equal(t, "busy", e.ShowAs)
+		require.Equal(t, "private", e.Sensitivity)
+		e.ID = "focus_event_id"
+		e.ICalUID = "focus_event_uid"
+		return e, nil
+	})
+	mockPluginAPI.EXPECT().GetMattermostUserStatus("user_mm_id").Return(&model.Status{Status: model.STATUS_AWAY, Manual: true}, nil)
+	mockPluginAPI.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.STATUS_DND).Return(nil, nil)
+	mockStore.EXPECT().ModifyFocusTime("user_mm_id", gomock.Any()).DoAndReturn(func(_ string, modify func(*store.FocusTime)) error {
+		focus := &store.FocusTime{Sessions: []*store.FocusSession{{EventID: "booked_event_id", Start: end, End: end.Add(time.Hour)}}}
+		modify(focus)
+		require.Len(t, focus.Sessions, 2)
+		require.Equal(t, "booked_event_id", focus.Sessions[0].EventID)
+		require.Equal(t, "focus_event_uid", focus.Sessions[1].ICalUID)
+		require.True(t, focus.Sessions[1].Started)
+		require.Equal(t, model.STATUS_AWAY, focus.Sessions[1].PriorStatus)
+		return nil
+	})
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:     mockStore,
+			PluginAPI: mockPluginAPI,
+			Logger:    &bot.NilLogger{},
+		}},
+		client: mockClient,
+	}
+
+	user := newTestEventUser()
+	user.User.MattermostUserID = "user_mm_id"
+	_, err := m.StartFocusTime(user, start, end)
+	require.NoError(t, err)
+
+	_, err = m.StartFocusTime(newTestEventUser(), start, start.Add(13*time.Hour))
+	require.EqualError(t, err, "focus time can last up to 12 hours")
+}
+
+func TestUpdateFocusSessions(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name          string
+		session       store.FocusSession
+		currentStatus string
+		restored      string
+		remaining     int
+	}{
+		{
+			name:          "Ended, status restored",
+			session:       store.FocusSession{Start: now.Add(-time.Hour), End: now.Add(-time.Minute), Started: true, PriorStatus: model.STATUS_ONLINE},
+			currentStatus: model.STATUS_DND,
+			restored:      model.STATUS_ONLINE,
+		},
+		{
+			name:          "Ended, status changed by the user",
+			session:       store.FocusSession{Start: now.Add(-time.Hour), End: now.Add(-time.Minute), Started: true, PriorStatus: model.STATUS_ONLINE},
+			currentStatus: model.STATUS_AWAY,
+		},
+		{
+			name:      "Ended, was already Do Not Disturb",
+			session:   store.FocusSession{Start: now.Add(-time.Hour), End: now.Add(-time.Minute), Started: true},
+			remaining: 0,
+		},
+		{
+			name:      "In progress",
+			session:   store.FocusSession{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Started: true, PriorStatus: model.STATUS_ONLINE},
+			remaining: 1,
+		},
+		{
+			name:      "Upcoming",
+			session:   store.FocusSession{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+			remaining: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+			if tc.currentStatus != "" {
+				mockPluginAPI.EXPECT().GetMattermostUserStatus("user_mm_id").Return(&model.Status{Status: tc.currentStatus}, nil)
+			}
+			if tc.restored != "" {
+				mockPluginAPI.EXPECT().UpdateMattermostUserStatus("user_mm_id", tc.restored).Return(nil, nil)
+			}
+
+			m := &mscalendar{
+				Env: Env{Dependencies: &Dependencies{
+					PluginAPI: mockPluginAPI,
+					Logger:    &bot.NilLogger{},
+				}},
+			}
+			session := tc.session
+			session.EventID = "focus_event_id"
+			focus := &store.FocusTime{Sessions: []*store.FocusSession{&session}}
+
+			updated := m.updateFocusSessions("user_mm_id", focus.Sessions, now)
+			require.Equal(t, tc.remaining == 0, len(updated) > 0)
+			focus.Sessions = applyFocusUpdates(focus.Sessions, updated)
+			require.Len(t, focus.Sessions, tc.remaining)
+			require.Equal(t, tc.remaining == 1 && session.Started, isInFocusTime(focus, now))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// FreeBusyInterval is the length of the blocks of the free/busy timeline.
+const FreeBusyInterval = 30 * time.Minute
+
+type FreeBusy interface {
+	GetFreeBusy(user *User, mattermostUsernames []string, start, end time.Time) ([]*views.FreeBusyRow, error)
+}
+
+// GetFreeBusy looks up the schedules of the user and of the other users, as
+// seen by the user, so that Microsoft only shares what the user may see. The
+// first row is the user's own schedule.
+func (m *mscalendar) GetFreeBusy(user *User, mattermostUsernames []string, start, end time.Time) ([]*views.FreeBusyRow, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []*views.FreeBusyRow{{Name: "You"}}
+	mails := []string{user.Remote.Mail}
+	for _, username := range mattermostUsernames {
+		username = strings.TrimPrefix(username, "@")
+		row := &views.FreeBusyRow{Name: "@" + username}
+		rows = append(rows, row)
+		mails = append(mails, "")
+
+		mattermostUser, err := m.PluginAPI.GetMattermostUserByUsername(username)
+		if err != nil {
+			row.Error = "user not found"
+			continue
+		}
+		storedUser, err := m.Store.LoadUser(mattermostUser.Id)
+		if err != nil || storedUser.Remote == nil || storedUser.Remote.Mail == "" {
+			row.Error = "has not connected their Microsoft account"
+			continue
+		}
+		mails[len(mails)-1] = storedUser.Remote.Mail
+	}
+
+	requests := []*remote.ScheduleUserInfo{}
+	requested := map[string]bool{}
+	for _, mail := range mails {
+		if mail == "" || requested[strings.ToLower(mail)] {
+			continue
+		}
+		requested[strings.ToLower(mail)] = true
+		requests = append(requests, &remote.ScheduleUserInfo{
+			RemoteUserID: user.Remote.ID,
+			Mail:         mail,
+		})
+	}
+
+	schedules, err := m.client.GetSchedule(requests, remote.NewDateTime(start.UTC(), "UTC"), remote.NewDateTime(end.UTC(), "UTC"), int(FreeBusyInterval.Minutes()))
+	if err != nil {
+		return nil, err
+	}
+	byMail := map[string]*remote.ScheduleInformation{}
+	for _, s := range schedules {
+		byMail[strings.ToLower(s.ScheduleID)] = s
+	}
+
+	for i, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		s := byMail[strings.ToLower(mails[i])]
+		if s == nil || s.Error != nil {
+			row.Error = "free/busy information is not available"
+			continue
+		}
+		row.AvailabilityView = s.AvailabilityView
+		row.Items = s.ScheduleItems
+	}
+	return rows, nil
+}
+
+// NextCommonFreeSlot finds the first run of blocks, starting after now, in
+// which all the schedules found are free.
+func NextCommonFreeSlot(rows []*views.FreeBusyRow, start time.Time, interval time.Duration, now time.Time) (slotStart, slotEnd time.Time, found bool) {
+	availabilityViews := []remote.AvailabilityView{}
+	numBlocks := 0
+	for _, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		availabilityViews = append(availabilityViews, row.AvailabilityView)
+		if len(row.AvailabilityView) > numBlocks {
+			numBlocks = len(row.AvailabilityView)
+		}
+	}
+	if len(availabilityViews) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	isCommonFree := func(block int) bool {
+		for _, v := range availabilityViews {
+			if block >= len(v) || v[block] != remote.AvailabilityViewFree {
+				return false
+			}
+		}
+		return true
+	}
+
+	for block := 0; block < numBlocks; block++ {
+		blockStart := start.Add(time.Duration(block) * interval)
+		if blockStart.Before(now) || !isCommonFree(block) {
+			continue
+		}
+		last := block
+		for last+1 < numBlocks && isCommonFree(last+1) {
+			last++
+		}
+		return blockStart, start.Add(time.Duration(last+1) * interval), true
+	}
+	return time.Time{}, time.Time{}, false
+}
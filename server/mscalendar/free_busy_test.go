@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+)
+
+func TestGetFreeBusy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+	mockPluginAPI.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_mm_id"}, nil)
+	mockPluginAPI.EXPECT().GetMattermostUserByUsername("bob").Return(&model.User{Id: "bob_mm_id"}, nil)
+	mockPluginAPI.EXPECT().GetMattermostUserByUsername("nobody").Return(nil, errors.New("not found"))
+	mockStore.EXPECT().LoadUser("alice_mm_id").Return(&store.User{Remote: &remote.User{Mail: "alice@example.com"}}, nil)
+	mockStore.EXPECT().LoadUser("bob_mm_id").Return(nil, store.ErrNotFound)
+
+	items := []*remote.ScheduleItem{{Status: "busy", IsPrivate: true}}
+	mockClient.EXPECT().GetSchedule([]*remote.ScheduleUserInfo{
+		{RemoteUserID: "user_remote_id", Mail: "user@example.com"},
+		{RemoteUserID: "user_remote_id", Mail: "alice@example.com"},
+	}, gomock.Any(), gomock.Any(), 30).Return([]*remote.ScheduleInformation{
+		{ScheduleID: "Alice@example.com", AvailabilityView: "0220", ScheduleItems: items},
+		{ScheduleID: "user@example.com", AvailabilityView: "0000"},
+	}, nil)
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:     mockStore,
+			PluginAPI: mockPluginAPI,
+		}},
+		client: mockClient,
+	}
+	user := newTestEventUser()
+	user.Remote.Mail = "user@example.com"
+
+	start := time.Date(2020, 2, 12, 14, 0, 0, 0, time.UTC)
+	rows, err := m.GetFreeBusy(user, []string{"@alice", "bob", "@nobody"}, start, start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []*views.FreeBusyRow{
+		{Name: "You", AvailabilityView: "0000"},
+		{Name: "@alice", AvailabilityView: "0220", Items: items},
+		{Name: "@bob", Error: "has not connected their Microsoft account"},
+		{Name: "@nobody", Error: "user not found"},
+	}, rows)
+}
+
+func TestNextCommonFreeSlot(t *testing.T) {
+	start := time.Date(2020, 2, 12, 8, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2020, 2, 12, hour, minute, 0, 0, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		views     []remote.AvailabilityView
+		errors    []string
+		now       time.Time
+		found     bool
+		slotStart time.Time
+		slotEnd   time.Time
+	}{
+		{
+			name:      "First common run",
+			views:     []remote.AvailabilityView{"20000", "00120"},
+			now:       start,
+			found:     true,
+			slotStart: at(8, 30),
+			slotEnd:   at(9, 0),
+		},
+		{
+			name:      "Skips the past",
+			views:     []remote.AvailabilityView{"00000", "00200"},
+			now:       at(8, 10),
+			found:     true,
+			slotStart: at(8, 30),
+			slotEnd:   at(9, 0),
+		},
+		{
+			name:      "Ignores missing schedules",
+			views:     []remote.AvailabilityView{"2000", ""},
+			errors:    []string{"", "user not found"},
+			now:       start,
+			found:     true,
+			slotStart: at(8, 30),
+			slotEnd:   at(10, 0),
+		},
+		{
+			name:  "Out of office is not free",
+			views: []remote.AvailabilityView{"0303", "3030"},
+			now:   start,
+		},
+		{
+			name:   "No schedules",
+			views:  []remote.AvailabilityView{""},
+			errors: []string{"user not found"},
+			now:    start,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := []*views.FreeBusyRow{}
+			for i, v := range tc.views {
+				row := &views.FreeBusyRow{AvailabilityView: v}
+				if i < len(tc.errors) {
+					row.Error = tc.errors[i]
+				}
+				rows = append(rows, row)
+			}
+
+			slotStart, slotEnd, found := NextCommonFreeSlot(rows, start, FreeBusyInterval, tc.now)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.slotStart, slotStart)
+			require.Equal(t, tc.slotEnd, slotEnd)
+		})
+	}
+}
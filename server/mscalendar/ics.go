@@ -0,0 +1,674 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/ics"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/tz"
+)
+
+// ICSFileIDKey is the context key of the file imported by the "Add to my
+// calendar" button.
+const ICSFileIDKey = "file_id"
+
+// maxICSFileSize is the size of the largest iCalendar file that is offered
+// for import.
+const maxICSFileSize = 1024 * 1024
+
+// maxICSOfferedEvents is the number of events listed in an import offer.
+const maxICSOfferedEvents = 5
+
+type ICalendar interface {
+	ExportCalendar(user *User, from, to time.Time) (int, error)
+	OfferICSImport(post *model.Post, botUserID string) error
+	ImportICSFile(user *User, fileID string) (*ICSImportResult, error)
+}
+
+// ICSImportResult lists what happened to the events of an imported file.
+// Duplicates and Cancelled have the subjects of the events not imported.
+type ICSImportResult struct {
+	Created    []*remote.Event
+	Duplicates []string
+	Cancelled  []string
+}
+
+var icsPartStats = map[string]string{
+	"none":                ics.PartStatNeedsAction,
+	"notResponded":        ics.PartStatNeedsAction,
+	"organizer":           ics.PartStatAccepted,
+	"accepted":            ics.PartStatAccepted,
+	"tentativelyAccepted": ics.PartStatTentative,
+	"declined":            ics.PartStatDeclined,
+}
+
+var icsRoles = map[string]string{
+	"required": ics.RoleRequired,
+	"optional": ics.RoleOptional,
+	"resource": ics.RoleNonParticipant,
+}
+
+// ExportCalendar sends the events of the user's default calendar between from
+// and to as an iCalendar file in a direct message, and returns the number of
+// events sent. Times are written in the user's time zone.
+func (m *mscalendar) ExportCalendar(user *User, from, to time.Time) (int, error) {
+	events, err := m.ViewCalendar(user, from, to)
+	if err != nil {
+		return 0, err
+	}
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return 0, err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	cal := &ics.Calendar{Method: ics.MethodPublish}
+	now := time.Now()
+	for _, event := range events {
+		if event.Start == nil || event.End == nil {
+			continue
+		}
+		e := NewICSEvent(event, loc)
+		e.Stamp = now
+		cal.Events = append(cal.Events, e)
+	}
+
+	buf := &bytes.Buffer{}
+	err = ics.Encode(buf, cal)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to encode the calendar")
+	}
+
+	fileName := "calendar-" + from.In(loc).Format("2006-01-02") + "-" + to.In(loc).Format("2006-01-02") + ".ics"
+	_, err = m.Poster.DMWithFile(user.MattermostUserID, fileName, buf.Bytes(),
+		"Your calendar from %s to %s, with %d events.", from.In(loc).Format("Jan 2"), to.In(loc).Format("Jan 2"), len(cal.Events))
+	if err != nil {
+		return 0, err
+	}
+	return len(cal.Events), nil
+}
+
+// NewICSEvent converts an event to iCalendar. Times are written in loc, except
+// for all-day events, which keep their dates. The occurrences of a series get
+// its UID and a RECURRENCE-ID from their original start.
+func NewICSEvent(event *remote.Event, loc *time.Location) *ics.Event {
+	e := &ics.Event{
+		UID:         event.ICalUID,
+		Summary:     event.Subject,
+		URL:         event.Weblink,
+		AllDay:      event.IsAllDay,
+		Status:      ics.StatusConfirmed,
+		Transparent: event.ShowAs == "free",
+	}
+	if e.UID == "" {
+		e.UID = event.ID
+	}
+	if event.IsCancelled {
+		e.Status = ics.StatusCancelled
+	}
+
+	if event.Body != nil && event.Body.ContentType != "html" {
+		e.Description = event.Body.Content
+	} else {
+		e.Description = event.BodyPreview
+	}
+	if event.Location != nil {
+		e.Location = event.Location.DisplayName
+	}
+
+	if event.IsAllDay {
+		e.Start = allDayDate(event.Start)
+		e.End = allDayDate(event.End)
+	} else {
+		e.Start = event.Start.Time().In(loc)
+		e.End = event.End.Time().In(loc)
+	}
+	if event.OriginalStart != "" {
+		originalStart, err := time.Parse(time.RFC3339, event.OriginalStart)
+		switch {
+		case err != nil:
+			// Without its original start, the occurrence stands on its own
+			e.UID = event.ID
+		case event.IsAllDay:
+			// The original start is midnight in the time zone of the event
+			if eventLoc, locErr := time.LoadLocation(tz.Go(event.Start.TimeZone)); locErr == nil {
+				originalStart = originalStart.In(eventLoc)
+			}
+			e.RecurrenceID = time.Date(originalStart.Year(), originalStart.Month(), originalStart.Day(), 0, 0, 0, 0, time.UTC)
+		default:
+			e.RecurrenceID = originalStart.In(loc)
+		}
+	}
+
+	if event.Organizer != nil && event.Organizer.EmailAddress != nil {
+		e.Organizer = &ics.Attendee{
+			Name:  event.Organizer.EmailAddress.Name,
+			Email: event.Organizer.EmailAddress.Address,
+		}
+	}
+	for _, a := range event.Attendees {
+		if a.EmailAddress == nil {
+			continue
+		}
+		attendee := &ics.Attendee{
+			Name:     a.EmailAddress.Name,
+			Email:    a.EmailAddress.Address,
+			Role:     icsRoles[a.Type],
+			PartStat: ics.PartStatNeedsAction,
+		}
+		if a.Status != nil && icsPartStats[a.Status.Response] != "" {
+			attendee.PartStat = icsPartStats[a.Status.Response]
+		}
+		e.Attendees = append(e.Attendees, attendee)
+	}
+	return e
+}
+
+// allDayDate reads the date of an all-day event as written, since all-day
+// events do not move with time zones.
+func allDayDate(dt *remote.DateTime) time.Time {
+	const dateFormat = "2006-01-02"
+	if len(dt.DateTime) < len(dateFormat) {
+		return dt.Time()
+	}
+	t, err := time.Parse(dateFormat, dt.DateTime[:len(dateFormat)])
+	if err != nil {
+		return dt.Time()
+	}
+	return t
+}
+
+// OfferICSImport offers the poster of a post with iCalendar files to add
+// their events to their calendar, with a summary of the events and a button.
+// Files sent to the bot are offered in reply. Files posted elsewhere are
+// offered in a DM, only to connected users who opted in.
+func (m *mscalendar) OfferICSImport(post *model.Post, botUserID string) error {
+	channel, err := m.PluginAPI.GetMattermostChannel(post.ChannelId)
+	if err != nil {
+		return err
+	}
+	inBotDM := channel.Type == model.CHANNEL_DIRECT && channel.Name == model.GetDMNameFromIds(post.UserId, botUserID)
+	if !inBotDM {
+		poster, err := m.Store.LoadUser(post.UserId)
+		if err == store.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !poster.Settings.OfferICSImport {
+			return nil
+		}
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+
+	for _, fileID := range post.FileIds {
+		info, err := m.PluginAPI.GetMattermostFileInfo(fileID)
+		if err != nil {
+			return err
+		}
+		if !isICSFile(info) {
+			continue
+		}
+		data, err := m.PluginAPI.GetMattermostFile(fileID)
+		if err != nil {
+			return err
+		}
+		cal, err := ics.Decode(bytes.NewReader(data))
+		if err != nil || len(cal.Events) == 0 {
+			m.Logger.Debugf("Not offering to import %s. err=%v", info.Name, err)
+			continue
+		}
+
+		offer := m.newICSOfferAttachment(info, cal)
+		if inBotDM {
+			_, err = m.Poster.PostInChannelWithAttachments(post.ChannelId, rootID, offer)
+		} else {
+			_, err = m.Poster.DMWithAttachments(post.UserId, offer)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isICSFile(info *model.FileInfo) bool {
+	if info.Size > maxICSFileSize {
+		return false
+	}
+	return strings.EqualFold(info.Extension, "ics") || strings.HasPrefix(info.MimeType, ics.MediaType)
+}
+
+func (m *mscalendar) newICSOfferAttachment(info *model.FileInfo, cal *ics.Calendar) *model.SlackAttachment {
+	lines := []string{}
+	for i, e := range cal.Events {
+		if i == maxICSOfferedEvents {
+			lines = append(lines, fmt.Sprintf("- and %d more", len(cal.Events)-maxICSOfferedEvents))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- **%s** · %s", views.EnsureSubject(e.Summary), formatICSEventTime(e)))
+	}
+
+	title := "Calendar file " + info.Name
+	return &model.SlackAttachment{
+		Title:    title,
+		Text:     strings.Join(lines, "\n"),
+		Fallback: title,
+		Actions: []*model.PostAction{{
+			Name: "Add to my calendar",
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL: m.actionURL(config.PathImportICS),
+				Context: map[string]interface{}{
+					ICSFileIDKey: info.Id,
+				},
+			},
+		}},
+	}
+}
+
+// formatICSEventTime shows the start of the event in the time zone of the file,
+// since the people reading the offer may be in different time zones.
+func formatICSEventTime(e *ics.Event) string {
+	if e.AllDay {
+		return e.Start.Format("Monday, January 02") + " (all day)"
+	}
+	return e.Start.Format("Monday, January 02 · " + time.Kitchen + " MST")
+}
+
+// ImportICSFile creates the events of an iCalendar file in the user's default
+// calendar, and sends them to the user as cards. Events the user already has,
+// whether imported before or received as invitations, are skipped. The
+// attendees are not invited, only listed in the body.
+func (m *mscalendar) ImportICSFile(user *User, fileID string) (*ICSImportResult, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := m.PluginAPI.GetMattermostFile(fileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the file")
+	}
+	cal, err := ics.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the calendar file")
+	}
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ICSImportResult{}
+	for _, e := range cal.Events {
+		subject := views.EnsureSubject(e.Summary)
+		if e.Status == ics.StatusCancelled || cal.Method == ics.MethodCancel {
+			result.Cancelled = append(result.Cancelled, subject)
+			continue
+		}
+		if m.hasICSEvent(user, e.UID) {
+			result.Duplicates = append(result.Duplicates, subject)
+			continue
+		}
+
+		event, err := NewRemoteEvent(e, timezone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to import %s", subject)
+		}
+		created, err := m.client.CreateEvent(user.Remote.ID, event)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to import %s", subject)
+		}
+		result.Created = append(result.Created, created)
+		m.deleteICSExceptions(user, e, created, timezone)
+
+		if e.UID != "" {
+			end := e.End
+			if e.RRule != nil {
+				end = e.RRule.Until
+			}
+			err = m.Store.StoreUserImportedEventID(user.MattermostUserID, e.UID, created.ID, end)
+			if err != nil {
+				m.Logger.Warnf("Failed to store the imported event %s. err=%v", created.ID, err)
+			}
+		}
+	}
+
+	if len(result.Created) > 0 {
+		cards := []*model.SlackAttachment{}
+		for _, created := range result.Created {
+			cards = append(cards, newImportedEventAttachment(created, timezone))
+		}
+		_, err = m.Poster.DMWithAttachments(user.MattermostUserID, cards...)
+		if err != nil {
+			m.Logger.Warnf("Failed to send the imported events. err=%v", err)
+		}
+	}
+	return result, nil
+}
+
+// deleteICSExceptions deletes the occurrences of an imported series that are
+// excluded by its EXDATEs. Failures are only logged, since the series has
+// been created.
+func (m *mscalendar) deleteICSExceptions(user *User, e *ics.Event, created *remote.Event, timezone string) {
+	for _, exDate := range e.ExDates {
+		from, to := exDate, exDate.Add(time.Minute)
+		if e.AllDay {
+			from, to = exDate.AddDate(0, 0, -1), exDate.AddDate(0, 0, 2)
+		}
+		occurrences, err := m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+		if err != nil {
+			m.Logger.Warnf("Failed to find the excluded occurrence of %s on %s. err=%v", created.ID, exDate, err)
+			continue
+		}
+		for _, occurrence := range occurrences {
+			if occurrence.SeriesMasterID != created.ID || !isICSOccurrence(occurrence, e, exDate, timezone) {
+				continue
+			}
+			err = m.client.DeleteEvent(user.Remote.ID, occurrence.ID)
+			if err != nil {
+				m.Logger.Warnf("Failed to delete the excluded occurrence %s. err=%v", occurrence.ID, err)
+			}
+		}
+	}
+}
+
+// isICSOccurrence tells if an occurrence of a series starts at exDate, or on
+// its date in the calendar's time zone for all-day events.
+func isICSOccurrence(occurrence *remote.Event, e *ics.Event, exDate time.Time, timezone string) bool {
+	if occurrence.Start == nil {
+		return false
+	}
+	if e.AllDay {
+		return occurrence.Start.In(timezone).Time().Format("2006-01-02") == exDate.Format("2006-01-02")
+	}
+	return occurrence.Start.Time().Equal(exDate)
+}
+
+// hasICSEvent tells if the user has imported the event before and still has
+// it, or has a copy of it with the same UID.
+func (m *mscalendar) hasICSEvent(user *User, uid string) bool {
+	if uid == "" {
+		return false
+	}
+	eventID, err := m.Store.LoadUserImportedEventID(user.MattermostUserID, uid)
+	if err == nil {
+		if _, err = m.client.GetEvent(user.Remote.ID, eventID); err == nil {
+			return true
+		}
+	} else if err != store.ErrNotFound {
+		m.Logger.Warnf("Failed to load the imported event %s. err=%v", uid, err)
+	}
+
+	events, err := m.client.GetEventsByICalUID(user.Remote.ID, uid)
+	if err != nil {
+		m.Logger.Warnf("Failed to look up the event %s. err=%v", uid, err)
+		return false
+	}
+	return len(events) > 0
+}
+
+func newImportedEventAttachment(event *remote.Event, timezone string) *model.SlackAttachment {
+	title := views.EnsureSubject(event.Subject)
+	sa := &model.SlackAttachment{
+		Title:     "(added) " + title,
+		TitleLink: event.Weblink,
+		Fallback:  fmt.Sprintf("[%s](%s)", title, event.Weblink),
+	}
+	if event.Location == nil {
+		event.Location = &remote.Location{}
+	}
+	if event.Organizer == nil {
+		event.Organizer = &remote.Attendee{EmailAddress: &remote.EmailAddress{}}
+	}
+	if event.ResponseStatus == nil {
+		event.ResponseStatus = &remote.EventResponseStatus{}
+	}
+	ff := eventToFields(event, timezone)
+	for _, k := range []string{FieldWhen, FieldDuration, FieldLocation} {
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: k,
+			Value: strings.Join(ff[k].Strings(), ", "),
+			Short: true,
+		})
+	}
+	return sa
+}
+
+var remoteResponses = map[string]string{
+	ics.PartStatAccepted:  "accepted",
+	ics.PartStatDeclined:  "declined",
+	ics.PartStatTentative: "tentativelyAccepted",
+}
+
+// NewRemoteEvent converts an iCalendar event to be created in a calendar whose
+// time zone is timezone. Times in named locations keep their time zone, others
+// are sent in UTC. Recurring events in unrecognized time zones are rejected,
+// since their occurrences would drift across daylight saving time. All-day
+// events keep their dates.
+func NewRemoteEvent(e *ics.Event, timezone string) (*remote.Event, error) {
+	event := &remote.Event{
+		Subject:  e.Summary,
+		IsAllDay: e.AllDay,
+		ShowAs:   "busy",
+	}
+	if e.Transparent {
+		event.ShowAs = "free"
+	}
+	if e.Location != "" {
+		event.Location = &remote.Location{DisplayName: e.Location}
+	}
+
+	body := e.Description
+	if e.Organizer != nil {
+		body += "\n\nOrganizer: " + formatICSAttendee(e.Organizer)
+	}
+	if len(e.Attendees) > 0 {
+		attendees := []string{}
+		for _, a := range e.Attendees {
+			attendee := formatICSAttendee(a)
+			if response := remoteResponses[a.PartStat]; response != "" {
+				attendee += " (" + response + ")"
+			}
+			attendees = append(attendees, attendee)
+		}
+		body += "\nAttendees: " + strings.Join(attendees, ", ")
+	}
+	if e.URL != "" {
+		body += "\n" + e.URL
+	}
+	event.Body = &remote.ItemBody{
+		Content:     strings.TrimSpace(body),
+		ContentType: "text",
+	}
+
+	timeZoneName := "UTC"
+	switch {
+	case e.AllDay:
+		timeZoneName = timezone
+		event.Start = remote.NewDateTime(time.Date(e.Start.Year(), e.Start.Month(), e.Start.Day(), 0, 0, 0, 0, time.UTC), timezone)
+		end := e.End
+		if !end.After(e.Start) {
+			end = e.Start.AddDate(0, 0, 1)
+		}
+		event.End = remote.NewDateTime(time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC), timezone)
+	case isNamedLocation(e.Start.Location()):
+		timeZoneName = e.Start.Location().String()
+		event.Start = remote.NewDateTime(e.Start, timeZoneName)
+		event.End = remote.NewDateTime(e.End.In(e.Start.Location()), timeZoneName)
+	case e.RRule != nil && e.Start.Location() != time.UTC:
+		return nil, errors.New("the time zone of the event is not recognized, so its occurrences would move with daylight saving time")
+	default:
+		event.Start = remote.NewDateTime(e.Start.UTC(), "UTC")
+		event.End = remote.NewDateTime(e.End.UTC(), "UTC")
+	}
+
+	if e.RRule != nil {
+		recurrence, err := newRemoteRecurrence(e.RRule, e.Start, timeZoneName)
+		if err != nil {
+			return nil, err
+		}
+		event.Recurrence = recurrence
+	}
+	return event, nil
+}
+
+func formatICSAttendee(a *ics.Attendee) string {
+	if a.Name == "" {
+		return a.Email
+	}
+	return fmt.Sprintf("%s <%s>", a.Name, a.Email)
+}
+
+// isNamedLocation tells if the location is in the time zone database, rather
+// than a fixed offset from a VTIMEZONE.
+func isNamedLocation(loc *time.Location) bool {
+	if loc == time.UTC || loc.String() == "UTC" || loc.String() == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(loc.String())
+	return err == nil
+}
+
+var remoteDaysOfWeek = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+var remoteWeekIndexes = map[int]string{
+	1:  "first",
+	2:  "second",
+	3:  "third",
+	4:  "fourth",
+	-1: "last",
+}
+
+// newRemoteRecurrence converts an RRULE to the recurrence patterns Microsoft
+// supports. Rules with several months, days of the month or positions have no
+// equivalent.
+func newRemoteRecurrence(rule *ics.RecurrenceRule, start time.Time, timeZoneName string) (*remote.PatternedRecurrence, error) {
+	unsupported := errors.Errorf("the recurrence %s is not supported", rule.String())
+	if len(rule.ByMonth) > 1 || len(rule.ByMonthDay) > 1 || len(rule.BySetPos) > 1 {
+		return nil, unsupported
+	}
+
+	pattern := &remote.RecurrencePattern{
+		Interval:       rule.Interval,
+		FirstDayOfWeek: remoteDaysOfWeek[rule.WeekStart],
+	}
+	daysOfWeek := func() []string {
+		days := []string{}
+		for _, wn := range rule.ByDay {
+			days = append(days, remoteDaysOfWeek[wn.Weekday])
+		}
+		if len(days) == 0 {
+			days = append(days, remoteDaysOfWeek[start.Weekday()])
+		}
+		return days
+	}
+	// The position of the days in the month, like 2 for 2MO
+	index := func() (string, bool) {
+		position := 0
+		for _, wn := range rule.ByDay {
+			if position != 0 && wn.Ordinal != position {
+				return "", false
+			}
+			position = wn.Ordinal
+		}
+		if len(rule.BySetPos) == 1 {
+			if position != 0 {
+				return "", false
+			}
+			position = rule.BySetPos[0]
+		}
+		i, ok := remoteWeekIndexes[position]
+		return i, ok && len(rule.ByDay) > 0
+	}
+	month := int(start.Month())
+	if len(rule.ByMonth) == 1 {
+		month = rule.ByMonth[0]
+	}
+	dayOfMonth := start.Day()
+	if len(rule.ByMonthDay) == 1 {
+		dayOfMonth = rule.ByMonthDay[0]
+	}
+
+	switch rule.Freq {
+	case ics.FreqDaily:
+		pattern.Type = "daily"
+		if len(rule.ByDay) > 0 {
+			if rule.Interval != 1 {
+				return nil, unsupported
+			}
+			pattern.Type = "weekly"
+			pattern.DaysOfWeek = daysOfWeek()
+		}
+	case ics.FreqWeekly:
+		pattern.Type = "weekly"
+		pattern.DaysOfWeek = daysOfWeek()
+	case ics.FreqMonthly, ics.FreqYearly:
+		relative := len(rule.ByDay) > 0
+		if relative {
+			i, ok := index()
+			if !ok {
+				return nil, unsupported
+			}
+			pattern.Index = i
+			pattern.DaysOfWeek = daysOfWeek()
+		} else {
+			if dayOfMonth < 1 {
+				return nil, unsupported
+			}
+			pattern.DayOfMonth = dayOfMonth
+		}
+		switch {
+		case rule.Freq == ics.FreqMonthly && relative:
+			pattern.Type = "relativeMonthly"
+		case rule.Freq == ics.FreqMonthly:
+			pattern.Type = "absoluteMonthly"
+		case relative:
+			pattern.Type = "relativeYearly"
+			pattern.Month = month
+		default:
+			pattern.Type = "absoluteYearly"
+			pattern.Month = month
+		}
+	default:
+		return nil, unsupported
+	}
+
+	r := &remote.RecurrenceRange{
+		Type:               "noEnd",
+		StartDate:          start.Format("2006-01-02"),
+		RecurrenceTimeZone: timeZoneName,
+	}
+	switch {
+	case rule.Count > 0:
+		r.Type = "numbered"
+		r.NumberOfOccurrences = rule.Count
+	case !rule.Until.IsZero():
+		r.Type = "endDate"
+		r.EndDate = rule.Until.In(start.Location()).Format("2006-01-02")
+	}
+	return &remote.PatternedRecurrence{Pattern: pattern, Range: r}, nil
+}
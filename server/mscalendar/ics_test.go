@@ -0,0 +1,443 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/ics"
+)
+
+func TestNewICSEvent(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		event    *remote.Event
+		expected *ics.Event
+	}{
+		{
+			name: "Meeting",
+			event: &remote.Event{
+				ID:          "event_id",
+				ICalUID:     "event_uid",
+				Subject:     "Planning",
+				BodyPreview: "Agenda",
+				Body:        &remote.ItemBody{Content: "<p>Agenda</p>", ContentType: "html"},
+				Start:       &remote.DateTime{DateTime: "2020-03-11T14:00:00.0000000", TimeZone: "UTC"},
+				End:         &remote.DateTime{DateTime: "2020-03-11T15:00:00.0000000", TimeZone: "UTC"},
+				Location:    &remote.Location{DisplayName: "Room 1"},
+				Organizer:   &remote.Attendee{EmailAddress: &remote.EmailAddress{Name: "Alice", Address: "alice@example.com"}},
+				Attendees: []*remote.Attendee{
+					{Type: "required", Status: &remote.EventResponseStatus{Response: "tentativelyAccepted"}, EmailAddress: &remote.EmailAddress{Name: "Bob", Address: "bob@example.com"}},
+					{Type: "optional", Status: &remote.EventResponseStatus{Response: "none"}, EmailAddress: &remote.EmailAddress{Address: "carol@example.com"}},
+				},
+			},
+			expected: &ics.Event{
+				UID:         "event_uid",
+				Summary:     "Planning",
+				Description: "Agenda",
+				Location:    "Room 1",
+				Start:       time.Date(2020, 3, 11, 10, 0, 0, 0, loc),
+				End:         time.Date(2020, 3, 11, 11, 0, 0, 0, loc),
+				Status:      ics.StatusConfirmed,
+				Organizer:   &ics.Attendee{Name: "Alice", Email: "alice@example.com"},
+				Attendees: []*ics.Attendee{
+					{Name: "Bob", Email: "bob@example.com", Role: ics.RoleRequired, PartStat: ics.PartStatTentative},
+					{Email: "carol@example.com", Role: ics.RoleOptional, PartStat: ics.PartStatNeedsAction},
+				},
+			},
+		},
+		{
+			name: "Cancelled all-day event",
+			event: &remote.Event{
+				ID:          "event_id",
+				Subject:     "Holiday",
+				IsAllDay:    true,
+				IsCancelled: true,
+				ShowAs:      "free",
+				Start:       &remote.DateTime{DateTime: "2020-03-12T00:00:00.0000000", TimeZone: "Pacific Standard Time"},
+				End:         &remote.DateTime{DateTime: "2020-03-13T00:00:00.0000000", TimeZone: "Pacific Standard Time"},
+			},
+			expected: &ics.Event{
+				UID:         "event_id",
+				Summary:     "Holiday",
+				Start:       time.Date(2020, 3, 12, 0, 0, 0, 0, time.UTC),
+				End:         time.Date(2020, 3, 13, 0, 0, 0, 0, time.UTC),
+				AllDay:      true,
+				Status:      ics.StatusCancelled,
+				Transparent: true,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewICSEvent(tc.event, loc)
+			require.True(t, tc.expected.Start.Equal(actual.Start))
+			require.True(t, tc.expected.End.Equal(actual.End))
+			require.Equal(t, tc.expected.Start.Location().String(), actual.Start.Location().String())
+			tc.expected.Start, tc.expected.End = actual.Start, actual.End
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestExportCalendarOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2020, 3, 9, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	occurrence := func(id, day, originalDay string) *remote.Event {
+		return &remote.Event{
+			ID:            id,
+			ICalUID:       "series_uid",
+			Subject:       "Standup",
+			Type:          "occurrence",
+			OriginalStart: originalDay + "T09:00:00Z",
+			Start:         &remote.DateTime{DateTime: day + "T09:00:00.0000000", TimeZone: "UTC"},
+			End:           &remote.DateTime{DateTime: day + "T09:15:00.0000000", TimeZone: "UTC"},
+		}
+	}
+	moved := occurrence("occurrence_2", "2020-03-12", "2020-03-11")
+	moved.Type = "exception"
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockClient.EXPECT().GetDefaultCalendarView("user_remote_id", from, to).Return([]*remote.Event{
+		occurrence("occurrence_1", "2020-03-10", "2020-03-10"),
+		moved,
+	}, nil)
+	mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+
+	var exported string
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockPoster.EXPECT().DMWithFile("user_mm_id", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_, _ string, data []byte, _ string, _ ...interface{}) (string, error) {
+			exported = string(data)
+			return "post_id", nil
+		})
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Poster: mockPoster,
+			Logger: &bot.NilLogger{},
+		}},
+		client: mockClient,
+	}
+	count, err := m.ExportCalendar(newTestEventUser(), from, to)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	decoded, err := ics.Decode(strings.NewReader(exported))
+	require.NoError(t, err)
+	require.Len(t, decoded.Events, 2)
+	for i, originalStart := range []time.Time{
+		time.Date(2020, 3, 10, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 3, 11, 9, 0, 0, 0, time.UTC),
+	} {
+		require.Equal(t, "series_uid", decoded.Events[i].UID)
+		require.True(t, originalStart.Equal(decoded.Events[i].RecurrenceID))
+	}
+	require.True(t, time.Date(2020, 3, 12, 9, 0, 0, 0, time.UTC).Equal(decoded.Events[1].Start))
+}
+
+func TestNewRemoteEvent(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// A Wednesday
+	start := time.Date(2020, 3, 11, 9, 0, 0, 0, loc)
+	rule := func(value string) *ics.RecurrenceRule {
+		r, parseErr := ics.ParseRecurrenceRule(value, loc)
+		require.NoError(t, parseErr)
+		return r
+	}
+
+	for _, tc := range []struct {
+		name          string
+		rrule         string
+		pattern       *remote.RecurrencePattern
+		rangeType     string
+		expectedError string
+	}{
+		{
+			name:      "Weekly on the day of the start",
+			rrule:     "FREQ=WEEKLY;COUNT=4",
+			pattern:   &remote.RecurrencePattern{Type: "weekly", Interval: 1, DaysOfWeek: []string{"wednesday"}, FirstDayOfWeek: "monday"},
+			rangeType: "numbered",
+		},
+		{
+			name:      "Every weekday",
+			rrule:     "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			pattern:   &remote.RecurrencePattern{Type: "weekly", Interval: 1, DaysOfWeek: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, FirstDayOfWeek: "monday"},
+			rangeType: "noEnd",
+		},
+		{
+			name:      "Last Friday of every other month",
+			rrule:     "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20201231T000000Z",
+			pattern:   &remote.RecurrencePattern{Type: "relativeMonthly", Interval: 2, DaysOfWeek: []string{"friday"}, Index: "last", FirstDayOfWeek: "monday"},
+			rangeType: "endDate",
+		},
+		{
+			name:      "Same day every year",
+			rrule:     "FREQ=YEARLY",
+			pattern:   &remote.RecurrencePattern{Type: "absoluteYearly", Interval: 1, DayOfMonth: 11, Month: 3, FirstDayOfWeek: "monday"},
+			rangeType: "noEnd",
+		},
+		{
+			name:          "Several days of the month",
+			rrule:         "FREQ=MONTHLY;BYMONTHDAY=1,15",
+			expectedError: "the recurrence FREQ=MONTHLY;BYMONTHDAY=1,15 is not supported",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := NewRemoteEvent(&ics.Event{
+				Summary: "Sync",
+				Start:   start,
+				End:     start.Add(time.Hour),
+				RRule:   rule(tc.rrule),
+			}, "UTC")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &remote.DateTime{DateTime: "2020-03-11T09:00:00", TimeZone: "America/New_York"}, event.Start)
+			require.Equal(t, tc.pattern, event.Recurrence.Pattern)
+			require.Equal(t, tc.rangeType, event.Recurrence.Range.Type)
+			require.Equal(t, "2020-03-11", event.Recurrence.Range.StartDate)
+			require.Equal(t, "America/New_York", event.Recurrence.Range.RecurrenceTimeZone)
+		})
+	}
+}
+
+func TestImportICSFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockLogger := mock_bot.NewMockLogger(ctrl)
+
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:new",
+		"SUMMARY:New",
+		"DTSTART:20200311T140000Z",
+		"DTEND:20200311T150000Z",
+		"ORGANIZER;CN=Alice:mailto:alice@example.com",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:imported",
+		"SUMMARY:Imported before",
+		"DTSTART:20200312T140000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:invited",
+		"SUMMARY:Invited",
+		"DTSTART:20200313T140000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled",
+		"SUMMARY:Cancelled",
+		"STATUS:CANCELLED",
+		"DTSTART:20200314T140000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	mockPluginAPI.EXPECT().GetMattermostFile("file_id").Return([]byte(data), nil)
+	mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+
+	mockStore.EXPECT().LoadUserImportedEventID("user_mm_id", "new").Return("", store.ErrNotFound)
+	mockClient.EXPECT().GetEventsByICalUID("user_remote_id", "new").Return(nil, nil)
+	created := &remote.Event{
+		ID:      "created_id",
+		Subject: "New",
+		Start:   &remote.DateTime{DateTime: "2020-03-11T14:00:00", TimeZone: "UTC"},
+		End:     &remote.DateTime{DateTime: "2020-03-11T15:00:00", TimeZone: "UTC"},
+	}
+	mockClient.EXPECT().CreateEvent("user_remote_id", gomock.Any()).DoAndReturn(func(remoteUserID string, event *remote.Event) (*remote.Event, error) {
+		require.Equal(t, "New", event.Subject)
+		require.Equal(t, "Organizer: Alice <alice@example.com>", event.Body.Content)
+		require.Equal(t, &remote.DateTime{DateTime: "2020-03-11T14:00:00", TimeZone: "UTC"}, event.Start)
+		require.Empty(t, event.Attendees)
+		return created, nil
+	})
+	mockStore.EXPECT().StoreUserImportedEventID("user_mm_id", "new", "created_id", time.Date(2020, 3, 11, 15, 0, 0, 0, time.UTC)).Return(nil)
+
+	mockStore.EXPECT().LoadUserImportedEventID("user_mm_id", "imported").Return("imported_id", nil)
+	mockClient.EXPECT().GetEvent("user_remote_id", "imported_id").Return(&remote.Event{ID: "imported_id"}, nil)
+
+	mockStore.EXPECT().LoadUserImportedEventID("user_mm_id", "invited").Return("deleted_id", nil)
+	mockClient.EXPECT().GetEvent("user_remote_id", "deleted_id").Return(nil, errors.New("not found"))
+	mockClient.EXPECT().GetEventsByICalUID("user_remote_id", "invited").Return([]*remote.Event{{ID: "invitation_id"}}, nil)
+
+	mockPoster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Return("post_id", nil)
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:     mockStore,
+			PluginAPI: mockPluginAPI,
+			Poster:    mockPoster,
+			Logger:    mockLogger,
+		}},
+		client: mockClient,
+	}
+	result, err := m.ImportICSFile(newTestEventUser(), "file_id")
+	require.NoError(t, err)
+	require.Equal(t, []*remote.Event{created}, result.Created)
+	require.Equal(t, []string{"Imported before", "Invited"}, result.Duplicates)
+	require.Equal(t, []string{"Cancelled"}, result.Cancelled)
+}
+
+func TestNewRemoteEventUnrecognizedTimeZone(t *testing.T) {
+	start := time.Date(2020, 3, 11, 9, 0, 0, 0, time.FixedZone("Custom", -5*60*60))
+	rule, err := ics.ParseRecurrenceRule("FREQ=WEEKLY", start.Location())
+	require.NoError(t, err)
+
+	event, err := NewRemoteEvent(&ics.Event{Start: start, End: start.Add(time.Hour)}, "UTC")
+	require.NoError(t, err)
+	require.Equal(t, &remote.DateTime{DateTime: "2020-03-11T14:00:00", TimeZone: "UTC"}, event.Start)
+
+	_, err = NewRemoteEvent(&ics.Event{Start: start, End: start.Add(time.Hour), RRule: rule}, "UTC")
+	require.EqualError(t, err, "the time zone of the event is not recognized, so its occurrences would move with daylight saving time")
+}
+
+func TestImportICSFileExDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:series",
+		"SUMMARY:Standup",
+		"DTSTART:20200316T140000Z",
+		"DTEND:20200316T141500Z",
+		"RRULE:FREQ=DAILY;COUNT=5",
+		"EXDATE:20200317T140000Z,20200319T140000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	mockPluginAPI.EXPECT().GetMattermostFile("file_id").Return([]byte(data), nil)
+	mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+	mockStore.EXPECT().LoadUserImportedEventID("user_mm_id", "series").Return("", store.ErrNotFound)
+	mockClient.EXPECT().GetEventsByICalUID("user_remote_id", "series").Return(nil, nil)
+	created := &remote.Event{
+		ID:      "series_id",
+		Subject: "Standup",
+		Start:   &remote.DateTime{DateTime: "2020-03-16T14:00:00", TimeZone: "UTC"},
+		End:     &remote.DateTime{DateTime: "2020-03-16T14:15:00", TimeZone: "UTC"},
+	}
+	mockClient.EXPECT().CreateEvent("user_remote_id", gomock.Any()).Return(created, nil)
+	mockStore.EXPECT().StoreUserImportedEventID("user_mm_id", "series", "series_id", gomock.Any()).Return(nil)
+
+	first := time.Date(2020, 3, 17, 14, 0, 0, 0, time.UTC)
+	mockClient.EXPECT().GetDefaultCalendarView("user_remote_id", first, first.Add(time.Minute)).Return([]*remote.Event{
+		{ID: "other_id", SeriesMasterID: "other_series_id", Start: &remote.DateTime{DateTime: "2020-03-17T14:00:00", TimeZone: "UTC"}},
+		{ID: "occurrence_id", SeriesMasterID: "series_id", Start: &remote.DateTime{DateTime: "2020-03-17T14:00:00", TimeZone: "UTC"}},
+	}, nil)
+	mockClient.EXPECT().DeleteEvent("user_remote_id", "occurrence_id").Return(nil)
+	second := time.Date(2020, 3, 19, 14, 0, 0, 0, time.UTC)
+	mockClient.EXPECT().GetDefaultCalendarView("user_remote_id", second, second.Add(time.Minute)).Return(nil, errors.New("unavailable"))
+
+	mockPoster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Return("post_id", nil)
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:     mockStore,
+			PluginAPI: mockPluginAPI,
+			Poster:    mockPoster,
+			Logger:    &bot.NilLogger{},
+		}},
+		client: mockClient,
+	}
+	result, err := m.ImportICSFile(newTestEventUser(), "file_id")
+	require.NoError(t, err)
+	require.Equal(t, []*remote.Event{created}, result.Created)
+}
+
+func TestOfferICSImport(t *testing.T) {
+	botDM := &model.Channel{Id: "dm_id", Type: model.CHANNEL_DIRECT, Name: model.GetDMNameFromIds("user_mm_id", "bot_id")}
+	otherDM := &model.Channel{Id: "dm_id", Type: model.CHANNEL_DIRECT, Name: model.GetDMNameFromIds("user_mm_id", "other_id")}
+	channel := &model.Channel{Id: "channel_id", Type: model.CHANNEL_OPEN}
+
+	for _, tc := range []struct {
+		name     string
+		channel  *model.Channel
+		user     *store.User
+		userErr  error
+		offer    bool
+		inThread bool
+	}{
+		{name: "Sent to the bot", channel: botDM, offer: true, inThread: true},
+		{name: "Posted in a channel, opted in", channel: channel, user: &store.User{Settings: store.Settings{OfferICSImport: true}}, offer: true},
+		{name: "Posted in a channel, not opted in", channel: channel, user: &store.User{}},
+		{name: "Sent to another user, not opted in", channel: otherDM, user: &store.User{}},
+		{name: "Posted in a channel, not connected", channel: channel, userErr: store.ErrNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+			mockPoster := mock_bot.NewMockPoster(ctrl)
+
+			mockPluginAPI.EXPECT().GetMattermostChannel(tc.channel.Id).Return(tc.channel, nil)
+			if tc.user != nil || tc.userErr != nil {
+				mockStore.EXPECT().LoadUser("user_mm_id").Return(tc.user, tc.userErr)
+			}
+			if tc.offer {
+				data := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Launch\r\nDTSTART:20200311T140000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+				mockPluginAPI.EXPECT().GetMattermostFileInfo("file_id").Return(&model.FileInfo{Id: "file_id", Name: "launch.ics", Extension: "ics"}, nil)
+				mockPluginAPI.EXPECT().GetMattermostFile("file_id").Return([]byte(data), nil)
+			}
+			if tc.offer && tc.inThread {
+				mockPoster.EXPECT().PostInChannelWithAttachments("dm_id", "post_id", gomock.Any()).Return("offer_id", nil)
+			}
+			if tc.offer && !tc.inThread {
+				mockPoster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Return("offer_id", nil)
+			}
+
+			m := &mscalendar{
+				Env: Env{
+					Config: &config.Config{},
+					Dependencies: &Dependencies{
+						Store:     mockStore,
+						PluginAPI: mockPluginAPI,
+						Poster:    mockPoster,
+						Logger:    &bot.NilLogger{},
+					},
+				},
+			}
+			err := m.OfferICSImport(&model.Post{Id: "post_id", UserId: "user_mm_id", ChannelId: tc.channel.Id, FileIds: []string{"file_id"}}, "bot_id")
+			require.NoError(t, err)
+		})
+	}
+}
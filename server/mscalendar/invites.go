@@ -0,0 +1,205 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+// InvitesLookahead is how far ahead pending invitations are looked for.
+const InvitesLookahead = 7 * 24 * time.Hour
+
+// maxInvitesShown is the number of pending invitations listed at once.
+const maxInvitesShown = 20
+
+type Invitations interface {
+	GetPendingInvitations(user *User, now time.Time) ([]*remote.Event, error)
+	PostPendingInvitations(user *User, events []*remote.Event, teamID, timezone string) error
+	AcceptInvitationsFromTeam(user *User, teamID string) (int, error)
+}
+
+// isPendingInvitation tells if the organizer of the event is waiting for the
+// user's response.
+func isPendingInvitation(e *remote.Event) bool {
+	return e.ResponseRequested && views.IsPendingInvitation(e)
+}
+
+// GetPendingInvitations gets the upcoming events the user has not responded
+// to, soonest first.
+func (m *mscalendar) GetPendingInvitations(user *User, now time.Time) ([]*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := m.getSelectedCalendarsView(user, now, now.Add(InvitesLookahead))
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []*remote.Event{}
+	for _, e := range events {
+		if isPendingInvitation(e) && e.Start != nil {
+			pending = append(pending, e)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Start.Time().Before(pending[j].Start.Time())
+	})
+	return pending, nil
+}
+
+// PostPendingInvitations sends the pending invitations to the user in a
+// direct message, each with a selector to respond to it, and a button to
+// accept those organized by members of the team.
+func (m *mscalendar) PostPendingInvitations(user *User, events []*remote.Event, teamID, timezone string) error {
+	attachments := []*model.SlackAttachment{}
+	for i, e := range events {
+		if i == maxInvitesShown {
+			break
+		}
+		title := views.EnsureSubject(e.Subject)
+		sa := &model.SlackAttachment{
+			Title:     title,
+			TitleLink: e.Weblink,
+			Fallback:  fmt.Sprintf("[%s](%s)", title, e.Weblink),
+			Actions:   NewPostActionForEventResponse(e.ID, ResponseNone, m.actionURL(config.PathRespond)),
+		}
+		if when := eventToFields(e, timezone)[FieldWhen]; when != nil {
+			sa.Text = strings.Join(when.Strings(), ", ")
+		}
+		if e.Organizer != nil && e.Organizer.EmailAddress != nil {
+			sa.AuthorName = e.Organizer.EmailAddress.Name
+		}
+		attachments = append(attachments, sa)
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	attachments[0].Pretext = fmt.Sprintf("**Pending invitations**: %d events need a response.", len(events))
+	if len(events) == 1 {
+		attachments[0].Pretext = "**Pending invitations**: 1 event needs a response."
+	}
+	if len(events) > maxInvitesShown {
+		attachments[0].Pretext += fmt.Sprintf(" The first %d are shown.", maxInvitesShown)
+	}
+
+	if teamID != "" {
+		fromTeam, err := m.filterInvitationsFromTeam(events, teamID)
+		if err != nil {
+			return err
+		}
+		if len(fromTeam) > 0 {
+			attachments = append(attachments, &model.SlackAttachment{
+				Text: fmt.Sprintf("%d of these invitations are from members of your team.", len(fromTeam)),
+				Actions: []*model.PostAction{{
+					Name: "Accept all from my team",
+					Type: model.POST_ACTION_TYPE_BUTTON,
+					Integration: &model.PostActionIntegration{
+						URL: m.actionURL(config.PathAcceptTeamInvites),
+						Context: map[string]interface{}{
+							config.TeamIDKey: teamID,
+						},
+					},
+				}},
+			})
+		}
+	}
+
+	_, err := m.Poster.DMWithAttachments(user.MattermostUserID, attachments...)
+	return err
+}
+
+// AcceptInvitationsFromTeam accepts the pending invitations organized by
+// members of the team. It returns the number of invitations accepted.
+func (m *mscalendar) AcceptInvitationsFromTeam(user *User, teamID string) (int, error) {
+	events, err := m.GetPendingInvitations(user, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	fromTeam, err := m.filterInvitationsFromTeam(events, teamID)
+	if err != nil {
+		return 0, err
+	}
+
+	accepted := 0
+	for _, e := range fromTeam {
+		err = m.client.AcceptEvent(user.Remote.ID, e.ID, nil)
+		if err != nil {
+			m.Logger.Warnf("Failed to accept event %s. err=%v", e.ID, err)
+			continue
+		}
+		accepted++
+	}
+	return accepted, nil
+}
+
+// filterInvitationsFromTeam keeps the events organized by connected users
+// who are members of the team.
+func (m *mscalendar) filterInvitationsFromTeam(events []*remote.Event, teamID string) ([]*remote.Event, error) {
+	index, err := m.Store.LoadUserIndex()
+	if err != nil {
+		return nil, err
+	}
+	byEmail := map[string]*store.UserShort{}
+	for _, u := range index {
+		byEmail[strings.ToLower(u.Email)] = u
+	}
+
+	members := map[string]bool{}
+	fromTeam := []*remote.Event{}
+	for _, e := range events {
+		if e.Organizer == nil || e.Organizer.EmailAddress == nil {
+			continue
+		}
+		u := byEmail[strings.ToLower(e.Organizer.EmailAddress.Address)]
+		if u == nil {
+			continue
+		}
+		isMember, ok := members[u.MattermostUserID]
+		if !ok {
+			member, memberErr := m.PluginAPI.GetMattermostTeamMember(teamID, u.MattermostUserID)
+			isMember = memberErr == nil && member.DeleteAt == 0
+			members[u.MattermostUserID] = isMember
+		}
+		if isMember {
+			fromTeam = append(fromTeam, e)
+		}
+	}
+	return fromTeam, nil
+}
+
+// renderPendingInvitationsCount reminds the user of the invitations to
+// respond to in the events, if any.
+func renderPendingInvitationsCount(events []*remote.Event, now time.Time) string {
+	count := 0
+	for _, e := range events {
+		if isPendingInvitation(e) && e.End != nil && e.End.Time().After(now) {
+			count++
+		}
+	}
+
+	switch count {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("\n\nYou have 1 pending invitation. Use `/%s invites` to respond to it.", config.CommandTrigger)
+	default:
+		return fmt.Sprintf("\n\nYou have %d pending invitations. Use `/%s invites` to respond to them.", count, config.CommandTrigger)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/msgraph"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+func newTestInvitation(id, organizer, response string, requested bool, start time.Time) *remote.Event {
+	return &remote.Event{
+		ID:                id,
+		Subject:           id,
+		ResponseRequested: requested,
+		ResponseStatus:    &remote.EventResponseStatus{Response: response},
+		Organizer:         &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: organizer}},
+		Start:             remote.NewDateTime(start, "UTC"),
+		End:               remote.NewDateTime(start.Add(time.Hour), "UTC"),
+	}
+}
+
+func TestGetPendingInvitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2020, 2, 12, 9, 0, 0, 0, time.UTC)
+	organized := newTestInvitation("organized", "user@example.com", ResponseNone, true, now.Add(time.Hour))
+	organized.IsOrganizer = true
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockClient.EXPECT().GetDefaultCalendarView("user_remote_id", now, now.Add(InvitesLookahead)).Return([]*remote.Event{
+		newTestInvitation("later", "a@example.com", ResponseNone, true, now.Add(48*time.Hour)),
+		newTestInvitation("accepted", "a@example.com", ResponseYes, true, now.Add(time.Hour)),
+		newTestInvitation("not_requested", "a@example.com", ResponseNone, false, now.Add(time.Hour)),
+		organized,
+		newTestInvitation("sooner", "a@example.com", ResponseNone, true, now.Add(2*time.Hour)),
+	}, nil)
+
+	m := &mscalendar{
+		Env:    Env{Dependencies: &Dependencies{}},
+		client: mockClient,
+	}
+	pending, err := m.GetPendingInvitations(newTestEventUser(), now)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, "sooner", pending[0].ID)
+	require.Equal(t, "later", pending[1].ID)
+}
+
+func TestAcceptInvitationsFromTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+	mockClient.EXPECT().GetDefaultCalendarView("user_remote_id", gomock.Any(), gomock.Any()).Return([]*remote.Event{
+		newTestInvitation("teammate_1", "Teammate@example.com", ResponseNone, true, now.Add(time.Hour)),
+		newTestInvitation("teammate_2", "teammate@example.com", ResponseNone, true, now.Add(2*time.Hour)),
+		newTestInvitation("other_team", "other@example.com", ResponseNone, true, now.Add(time.Hour)),
+		newTestInvitation("left_team", "former@example.com", ResponseNone, true, now.Add(time.Hour)),
+		newTestInvitation("external", "external@example.org", ResponseNone, true, now.Add(time.Hour)),
+	}, nil)
+	mockStore.EXPECT().LoadUserIndex().Return(store.UserIndex{
+		{MattermostUserID: "teammate_mm_id", Email: "teammate@example.com"},
+		{MattermostUserID: "other_mm_id", Email: "other@example.com"},
+		{MattermostUserID: "former_mm_id", Email: "former@example.com"},
+	}, nil)
+	mockPluginAPI.EXPECT().GetMattermostTeamMember("team_id", "teammate_mm_id").Return(&model.TeamMember{}, nil).Times(1)
+	mockPluginAPI.EXPECT().GetMattermostTeamMember("team_id", "other_mm_id").Return(nil, errors.New("not a member"))
+	mockPluginAPI.EXPECT().GetMattermostTeamMember("team_id", "former_mm_id").Return(&model.TeamMember{DeleteAt: 1}, nil)
+	mockClient.EXPECT().AcceptEvent("user_remote_id", "teammate_1", nil).Return(nil)
+	mockClient.EXPECT().AcceptEvent("user_remote_id", "teammate_2", nil).Return(nil)
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:     mockStore,
+			PluginAPI: mockPluginAPI,
+			Logger:    &bot.NilLogger{},
+		}},
+		client: mockClient,
+	}
+	accepted, err := m.AcceptInvitationsFromTeam(newTestEventUser(), "team_id")
+	require.NoError(t, err)
+	require.Equal(t, 2, accepted)
+}
+
+func TestAcceptInvitationsFromTeamAccepted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+	mockStore.EXPECT().LoadUserIndex().Return(store.UserIndex{
+		{MattermostUserID: "teammate_mm_id", Email: "teammate@example.com"},
+	}, nil)
+	mockPluginAPI.EXPECT().GetMattermostTeamMember("team_id", "teammate_mm_id").Return(&model.TeamMember{}, nil)
+
+	// Graph answers the responses to events with 202 Accepted and no content
+	accepted := []string{}
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) *http.Response {
+		if r.Method == http.MethodPost {
+			accepted = append(accepted, r.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusAccepted,
+				Status:     "202 Accepted",
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}
+		}
+		body, _ := json.Marshal(map[string]interface{}{"value": []*remote.Event{
+			newTestInvitation("teammate_1", "teammate@example.com", ResponseNone, true, time.Now().Add(time.Hour)),
+		}})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}
+	})}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	client := msgraph.NewRemote(&config.Config{}, &bot.NilLogger{}).MakeClient(ctx, &oauth2.Token{AccessToken: "token"})
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:     mockStore,
+			PluginAPI: mockPluginAPI,
+			Logger:    &bot.NilLogger{},
+		}},
+		client: client,
+	}
+	count, err := m.AcceptInvitationsFromTeam(newTestEventUser(), "team_id")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, []string{"/v1.0/users/user_remote_id/events/teammate_1/accept"}, accepted)
+}
+
+type roundTripFunc func(r *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r), nil
+}
+
+func TestRenderPendingInvitationsCount(t *testing.T) {
+	now := time.Date(2020, 2, 12, 9, 0, 0, 0, time.UTC)
+	ended := newTestInvitation("ended", "a@example.com", ResponseNone, true, now.Add(-2*time.Hour))
+	pending := newTestInvitation("pending", "a@example.com", ResponseNone, true, now.Add(time.Hour))
+	accepted := newTestInvitation("accepted", "a@example.com", ResponseYes, true, now.Add(time.Hour))
+
+	require.Equal(t, "", renderPendingInvitationsCount([]*remote.Event{ended, accepted}, now))
+	require.Equal(t, "\n\nYou have 1 pending invitation. Use `/mscalendar invites` to respond to it.",
+		renderPendingInvitationsCount([]*remote.Event{ended, pending, accepted}, now))
+	require.Equal(t, "\n\nYou have 2 pending invitations. Use `/mscalendar invites` to respond to them.",
+		renderPendingInvitationsCount([]*remote.Event{pending, pending}, now))
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+// DefaultMeetingThreadLeadTime is how long before the meetings their threads
+// are posted, unless the organizer chooses otherwise. Threads can be posted
+// up to maxReminderLeadTime ahead, the furthest the status sync looks.
+const DefaultMeetingThreadLeadTime = 15 * time.Minute
+
+type MeetingThreads interface {
+	BindEventToChannel(user *User, eventID, channelID string, leadTime time.Duration) (*store.ChannelBinding, error)
+	UnbindEvent(user *User, eventID string) (*store.ChannelBinding, error)
+	GetChannelBindings(user *User) ([]*store.ChannelBinding, error)
+}
+
+// BindEventToChannel has a thread posted in the channel before each
+// occurrence of an event the user organizes. Binding the event again moves
+// the threads to the new channel.
+func (m *mscalendar) BindEventToChannel(user *User, eventID, channelID string, leadTime time.Duration) (*store.ChannelBinding, error) {
+	if leadTime <= 0 || leadTime > maxReminderLeadTime {
+		return nil, errors.Errorf("threads can be posted up to %d minutes before the meetings", int(maxReminderLeadTime.Minutes()))
+	}
+
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := m.PluginAPI.GetMattermostChannel(channelID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the channel")
+	}
+	if channel.Type != model.CHANNEL_OPEN && channel.Type != model.CHANNEL_PRIVATE {
+		return nil, errors.New("threads can only be posted in public and private channels")
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsOrganizer {
+		return nil, errors.New("only the organizer can post threads for an event")
+	}
+
+	binding := &store.ChannelBinding{
+		ICalUID:     event.ICalUID,
+		Subject:     views.EnsureSubject(event.Subject),
+		ChannelID:   channelID,
+		ChannelName: channel.Name,
+		LeadTime:    leadTime,
+	}
+	user.ChannelBindings = append(removeChannelBinding(user.ChannelBindings, event.ICalUID), binding)
+	err = m.Store.StoreUser(user.User)
+	if err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+// UnbindEvent stops posting threads for the event. The organizer is still
+// asked for the notes of the threads already posted.
+func (m *mscalendar) UnbindEvent(user *User, eventID string) (*store.ChannelBinding, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var binding *store.ChannelBinding
+	for _, b := range user.ChannelBindings {
+		if b.ICalUID == event.ICalUID {
+			binding = b
+		}
+	}
+	if binding == nil {
+		return nil, errors.New("no threads are posted for this event")
+	}
+
+	user.ChannelBindings = removeChannelBinding(user.ChannelBindings, event.ICalUID)
+	err = m.Store.StoreUser(user.User)
+	if err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+func (m *mscalendar) GetChannelBindings(user *User) ([]*store.ChannelBinding, error) {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, err
+	}
+	return user.ChannelBindings, nil
+}
+
+func removeChannelBinding(bindings []*store.ChannelBinding, iCalUID string) []*store.ChannelBinding {
+	result := []*store.ChannelBinding{}
+	for _, b := range bindings {
+		if b.ICalUID != iCalUID {
+			result = append(result, b)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// meetingThreadsWindow is how far ahead the status sync must look to find
+// the meetings whose threads are due.
+func meetingThreadsWindow(user *store.User) time.Duration {
+	window := time.Duration(0)
+	for _, b := range user.ChannelBindings {
+		if b.LeadTime+upcomingEventNotificationWindow > window {
+			window = b.LeadTime + upcomingEventNotificationWindow
+		}
+	}
+	return window
+}
+
+// hasMeetingThreads tells if threads were posted for the user that wait for
+// the notes, which keeps the user synced after unbinding the events.
+func (m *mscalendar) hasMeetingThreads(user *store.User) bool {
+	threads, err := m.Store.LoadMeetingThreads(user.MattermostUserID)
+	if err != nil && err != store.ErrNotFound {
+		m.Logger.With(bot.LogContext{
+			"MattermostUserID": user.MattermostUserID,
+		}).Warnf("hasMeetingThreads error loading meeting threads. err=%v", err)
+	}
+	return len(threads) > 0
+}
+
+// syncMeetingThreads posts the threads of the bound events that are about to
+// start, and asks the organizers for the notes of the meetings that ended,
+// including those of the events unbound since.
+func (m *mscalendar) syncMeetingThreads(users []*store.User, calendarViews []*remote.ViewCalendarResponse) {
+	usersByRemoteID := map[string]*store.User{}
+	for _, u := range users {
+		if len(u.ChannelBindings) > 0 || m.hasMeetingThreads(u) {
+			usersByRemoteID[u.Remote.ID] = u
+		}
+	}
+	if len(usersByRemoteID) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for _, view := range calendarViews {
+		user, ok := usersByRemoteID[view.RemoteUserID]
+		if !ok || view.Error != nil {
+			continue
+		}
+		log := m.Logger.With(bot.LogContext{
+			"MattermostUserID": user.MattermostUserID,
+		})
+
+		threads, err := m.Store.LoadMeetingThreads(user.MattermostUserID)
+		if err != nil && err != store.ErrNotFound {
+			log.Warnf("syncMeetingThreads error loading meeting threads. err=%v", err)
+			continue
+		}
+
+		changed := false
+		open := []*store.MeetingThread{}
+		for _, t := range threads {
+			if t.End.After(now) {
+				open = append(open, t)
+				continue
+			}
+			m.promptMeetingNotes(user, t)
+			changed = true
+		}
+
+		timezone := ""
+		for _, e := range view.Events {
+			binding := findChannelBinding(user.ChannelBindings, e)
+			if binding == nil || !isMeetingThreadDue(e, binding, now) {
+				continue
+			}
+			occurrenceID := getOccurrenceID(e)
+			if hasMeetingThread(open, occurrenceID) {
+				continue
+			}
+
+			if timezone == "" {
+				timezone, err = m.GetTimezoneByID(user.MattermostUserID)
+				if err != nil {
+					log.Warnf("syncMeetingThreads error getting timezone. err=%v", err)
+					break
+				}
+			}
+			postID, err := m.Poster.PostInChannel(binding.ChannelID, "", "%s", m.renderMeetingThread(e, timezone))
+			if err != nil {
+				log.Warnf("syncMeetingThreads error posting a meeting thread. err=%v", err)
+				continue
+			}
+			open = append(open, &store.MeetingThread{
+				OccurrenceID: occurrenceID,
+				ChannelID:    binding.ChannelID,
+				PostID:       postID,
+				End:          e.End.Time(),
+			})
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+		err = m.Store.StoreMeetingThreads(user.MattermostUserID, open)
+		if err != nil {
+			log.Warnf("syncMeetingThreads error storing meeting threads. err=%v", err)
+		}
+	}
+}
+
+func findChannelBinding(bindings []*store.ChannelBinding, event *remote.Event) *store.ChannelBinding {
+	if event.IsCancelled || event.Start == nil || event.End == nil {
+		return nil
+	}
+	for _, b := range bindings {
+		if b.ICalUID == event.ICalUID {
+			return b
+		}
+	}
+	return nil
+}
+
+// isMeetingThreadDue tells if the thread of the event should be posted, from
+// the binding's lead time until the meeting ends.
+func isMeetingThreadDue(event *remote.Event, binding *store.ChannelBinding, now time.Time) bool {
+	return !now.Before(event.Start.Time().Add(-binding.LeadTime)) && now.Before(event.End.Time())
+}
+
+func getOccurrenceID(event *remote.Event) string {
+	return fmt.Sprintf("%s %s", event.ICalUID, event.Start.Time().UTC().Format(time.RFC3339))
+}
+
+func hasMeetingThread(threads []*store.MeetingThread, occurrenceID string) bool {
+	for _, t := range threads {
+		if t.OccurrenceID == occurrenceID {
+			return true
+		}
+	}
+	return false
+}
+
+// renderMeetingThread shows the agenda of the meeting, its attendees,
+// mentioning those who are connected, and the link to join it.
+func (m *mscalendar) renderMeetingThread(event *remote.Event, timezone string) string {
+	link, err := views.RenderEventLink(event)
+	if err != nil {
+		link = views.EnsureSubject(event.Subject)
+	}
+	lines := []string{
+		"#### " + link,
+		fmt.Sprintf("**When**: %s - %s (%s)",
+			event.Start.In(timezone).Time().Format("Monday, January 02 · "+time.Kitchen),
+			event.End.In(timezone).Time().Format(time.Kitchen),
+			timezone),
+	}
+	if joinURL := getJoinURL(event, m.Config.PluginURL, m.Config.MeetingURLTemplate); joinURL != "" {
+		lines = append(lines, fmt.Sprintf("**Join**: %s", joinURL))
+	}
+	if attendees := m.renderMeetingAttendees(event); attendees != "" {
+		lines = append(lines, fmt.Sprintf("**Attendees**: %s", attendees))
+	}
+
+	agenda := strings.TrimSpace(event.BodyPreview)
+	if event.Body != nil && strings.EqualFold(event.Body.ContentType, "text") {
+		agenda = strings.TrimSpace(event.Body.Content)
+	}
+	if agenda != "" {
+		lines = append(lines, "", "**Agenda**", agenda)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *mscalendar) renderMeetingAttendees(event *remote.Event) string {
+	index, err := m.Store.LoadUserIndex()
+	if err != nil {
+		m.Logger.Warnf("renderMeetingAttendees error loading the user index. err=%v", err)
+		index = store.UserIndex{}
+	}
+	byEmail := map[string]*store.UserShort{}
+	for _, u := range index {
+		byEmail[strings.ToLower(u.Email)] = u
+	}
+
+	names := []string{}
+	for _, a := range event.Attendees {
+		if a.Type == "resource" || a.EmailAddress == nil {
+			continue
+		}
+		if u := byEmail[strings.ToLower(a.EmailAddress.Address)]; u != nil {
+			if mattermostUser, err := m.PluginAPI.GetMattermostUser(u.MattermostUserID); err == nil {
+				names = append(names, "@"+mattermostUser.Username)
+				continue
+			}
+		}
+		names = append(names, attendeeName(a))
+	}
+	return strings.Join(names, ", ")
+}
+
+// promptMeetingNotes replies in the thread of a meeting that ended, asking
+// the organizer for the notes.
+func (m *mscalendar) promptMeetingNotes(user *store.User, thread *store.MeetingThread) {
+	organizer := "Organizer"
+	if mattermostUser, err := m.PluginAPI.GetMattermostUser(user.MattermostUserID); err == nil {
+		organizer = "@" + mattermostUser.Username
+	}
+
+	_, err := m.Poster.PostInChannel(thread.ChannelID, thread.PostID, "%s, the meeting has ended. Please reply in this thread with the notes and action items.", organizer)
+	if err != nil {
+		m.Logger.With(bot.LogContext{
+			"MattermostUserID": user.MattermostUserID,
+			"PostID":           thread.PostID,
+		}).Warnf("promptMeetingNotes error replying in the meeting thread. err=%v", err)
+	}
+}
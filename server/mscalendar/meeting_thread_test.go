@@ -0,0 +1,208 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+)
+
+func TestBindEventToChannel(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		channelType string
+		isOrganizer bool
+		leadTime    time.Duration
+		expectedErr string
+	}{
+		{name: "Bound", channelType: model.CHANNEL_OPEN, isOrganizer: true, leadTime: 15 * time.Minute},
+		{name: "Lead time too long", channelType: model.CHANNEL_OPEN, isOrganizer: true, leadTime: 3 * time.Hour, expectedErr: "threads can be posted up to 120 minutes before the meetings"},
+		{name: "Direct message", channelType: model.CHANNEL_DIRECT, isOrganizer: true, leadTime: 15 * time.Minute, expectedErr: "threads can only be posted in public and private channels"},
+		{name: "Not the organizer", channelType: model.CHANNEL_PRIVATE, leadTime: 15 * time.Minute, expectedErr: "only the organizer can post threads for an event"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+			mockPluginAPI.EXPECT().GetMattermostChannel("channel_id").Return(&model.Channel{Name: "town-square", Type: tc.channelType}, nil).AnyTimes()
+			mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(&remote.Event{
+				ID:          "event_id",
+				ICalUID:     "event_uid",
+				Subject:     "Weekly sync",
+				IsOrganizer: tc.isOrganizer,
+			}, nil).AnyTimes()
+			if tc.expectedErr == "" {
+				mockStore.EXPECT().StoreUser(gomock.Any()).DoAndReturn(func(u *store.User) error {
+					require.Len(t, u.ChannelBindings, 1)
+					require.Equal(t, "town-square", u.ChannelBindings[0].ChannelName)
+					return nil
+				})
+			}
+
+			m := &mscalendar{
+				Env: Env{Dependencies: &Dependencies{
+					Store:     mockStore,
+					PluginAPI: mockPluginAPI,
+				}},
+				client: mockClient,
+			}
+			user := newTestEventUser()
+			user.ChannelBindings = []*store.ChannelBinding{{ICalUID: "event_uid", ChannelID: "other_channel_id"}}
+
+			binding, err := m.BindEventToChannel(user, "event_id", "channel_id", tc.leadTime)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "channel_id", binding.ChannelID)
+			require.Equal(t, "Weekly sync", binding.Subject)
+		})
+	}
+}
+
+func TestSyncMeetingThreads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+	now := time.Now().UTC()
+	newEvent := func(iCalUID string, start time.Time) *remote.Event {
+		return &remote.Event{
+			ICalUID:     iCalUID,
+			Subject:     iCalUID,
+			BodyPreview: "Review the incidents of the week",
+			Start:       remote.NewDateTime(start, "UTC"),
+			End:         remote.NewDateTime(start.Add(time.Hour), "UTC"),
+			Organizer:   &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: "organizer@example.com"}},
+			Attendees: []*remote.Attendee{
+				{EmailAddress: &remote.EmailAddress{Name: "Alice", Address: "alice@example.com"}},
+				{EmailAddress: &remote.EmailAddress{Name: "Bob", Address: "bob@example.com"}},
+			},
+		}
+	}
+	due := newEvent("due_uid", now.Add(10*time.Minute))
+	posted := newEvent("posted_uid", now.Add(5*time.Minute))
+	notDue := newEvent("not_due_uid", now.Add(30*time.Minute))
+	unbound := newEvent("unbound_uid", now.Add(5*time.Minute))
+
+	user := &store.User{
+		MattermostUserID: "user_mm_id",
+		Remote:           &remote.User{ID: "user_remote_id"},
+		ChannelBindings: []*store.ChannelBinding{
+			{ICalUID: "due_uid", ChannelID: "channel_id", LeadTime: 15 * time.Minute},
+			{ICalUID: "posted_uid", ChannelID: "channel_id", LeadTime: 15 * time.Minute},
+			{ICalUID: "not_due_uid", ChannelID: "channel_id", LeadTime: 15 * time.Minute},
+		},
+	}
+
+	mockStore.EXPECT().LoadMeetingThreads("user_mm_id").Return([]*store.MeetingThread{
+		{OccurrenceID: getOccurrenceID(posted), ChannelID: "channel_id", PostID: "posted_post_id", End: posted.End.Time()},
+		{OccurrenceID: "ended", ChannelID: "channel_id", PostID: "ended_post_id", End: now.Add(-time.Minute)},
+	}, nil)
+	mockPluginAPI.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{Username: "organizer"}, nil)
+	mockPoster.EXPECT().PostInChannel("channel_id", "ended_post_id", gomock.Any(), "@organizer").Return("reply_id", nil)
+
+	mockStore.EXPECT().LoadUser("user_mm_id").Return(user, nil)
+	mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "Pacific Standard Time"}, nil)
+	mockStore.EXPECT().LoadUserIndex().Return(store.UserIndex{{MattermostUserID: "alice_mm_id", Email: "alice@example.com"}}, nil)
+	mockPluginAPI.EXPECT().GetMattermostUser("alice_mm_id").Return(&model.User{Username: "alice"}, nil)
+	mockPoster.EXPECT().PostInChannel("channel_id", "", "%s", gomock.Any()).DoAndReturn(func(channelID, rootID, format string, args ...interface{}) (string, error) {
+		message := args[0].(string)
+		require.True(t, strings.HasPrefix(message, "#### [due_uid]"))
+		require.Contains(t, message, "**Attendees**: @alice, Bob")
+		require.Contains(t, message, "**Agenda**\nReview the incidents of the week")
+		return "due_post_id", nil
+	})
+
+	mockStore.EXPECT().StoreMeetingThreads("user_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID string, threads []*store.MeetingThread) error {
+		require.Len(t, threads, 2)
+		require.Equal(t, "posted_post_id", threads[0].PostID)
+		require.Equal(t, "due_post_id", threads[1].PostID)
+		return nil
+	})
+
+	m := &mscalendar{
+		Env: Env{
+			Config: &config.Config{},
+			Dependencies: &Dependencies{
+				Store:     mockStore,
+				Poster:    mockPoster,
+				PluginAPI: mockPluginAPI,
+				Logger:    &bot.NilLogger{},
+			},
+		},
+		client: mockClient,
+	}
+	m.syncMeetingThreads([]*store.User{user}, []*remote.ViewCalendarResponse{
+		{RemoteUserID: "user_remote_id", Events: []*remote.Event{due, posted, notDue, unbound}},
+	})
+}
+
+func TestSyncMeetingThreadsAfterUnbind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+	now := time.Now().UTC()
+	unbound := &store.User{
+		MattermostUserID: "user_mm_id",
+		Remote:           &remote.User{ID: "user_remote_id"},
+	}
+	other := &store.User{
+		MattermostUserID: "other_mm_id",
+		Remote:           &remote.User{ID: "other_remote_id"},
+		Settings:         store.Settings{UpdateStatus: true},
+	}
+	threads := []*store.MeetingThread{
+		{OccurrenceID: "ended", ChannelID: "channel_id", PostID: "ended_post_id", End: now.Add(-time.Minute)},
+	}
+
+	mockStore.EXPECT().LoadMeetingThreads("user_mm_id").Return(threads, nil).Times(2)
+	mockStore.EXPECT().LoadMeetingThreads("other_mm_id").Return(nil, store.ErrNotFound)
+	mockPluginAPI.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{Username: "organizer"}, nil)
+	mockPoster.EXPECT().PostInChannel("channel_id", "ended_post_id", gomock.Any(), "@organizer").Return("reply_id", nil)
+	mockStore.EXPECT().StoreMeetingThreads("user_mm_id", []*store.MeetingThread{}).Return(nil)
+
+	m := &mscalendar{
+		Env: Env{
+			Config: &config.Config{},
+			Dependencies: &Dependencies{
+				Store:     mockStore,
+				Poster:    mockPoster,
+				PluginAPI: mockPluginAPI,
+				Logger:    &bot.NilLogger{},
+			},
+		},
+	}
+	m.syncMeetingThreads([]*store.User{unbound, other}, []*remote.ViewCalendarResponse{
+		{RemoteUserID: "user_remote_id", Events: []*remote.Event{}},
+		{RemoteUserID: "other_remote_id", Events: []*remote.Event{}},
+	})
+}
@@ -7,6 +7,7 @@ package mock_mscalendar
 import (
 	gomock "github.com/golang/mock/gomock"
 	mscalendar "github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar"
+	views "github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
 	remote "github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 	store "github.com/mattermost/mattermost-plugin-mscalendar/server/store"
 	model "github.com/mattermost/mattermost-server/v5/model"
@@ -51,6 +52,35 @@ func (mr *MockMSCalendarMockRecorder) AcceptEvent(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptEvent", reflect.TypeOf((*MockMSCalendar)(nil).AcceptEvent), arg0, arg1)
 }
 
+// AcceptInvitationsFromTeam mocks base method
+func (m *MockMSCalendar) AcceptInvitationsFromTeam(arg0 *mscalendar.User, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationsFromTeam", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitationsFromTeam indicates an expected call of AcceptInvitationsFromTeam
+func (mr *MockMSCalendarMockRecorder) AcceptInvitationsFromTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationsFromTeam", reflect.TypeOf((*MockMSCalendar)(nil).AcceptInvitationsFromTeam), arg0, arg1)
+}
+
+// AddOnlineMeeting mocks base method
+func (m *MockMSCalendar) AddOnlineMeeting(arg0 *mscalendar.User, arg1 *remote.Event, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOnlineMeeting", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOnlineMeeting indicates an expected call of AddOnlineMeeting
+func (mr *MockMSCalendarMockRecorder) AddOnlineMeeting(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOnlineMeeting", reflect.TypeOf((*MockMSCalendar)(nil).AddOnlineMeeting), arg0, arg1, arg2, arg3)
+}
+
 // AfterDisconnect mocks base method
 func (m *MockMSCalendar) AfterDisconnect(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +109,50 @@ func (mr *MockMSCalendarMockRecorder) AfterSuccessfullyConnect(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterSuccessfullyConnect", reflect.TypeOf((*MockMSCalendar)(nil).AfterSuccessfullyConnect), arg0, arg1)
 }
 
+// BindEventToChannel mocks base method
+func (m *MockMSCalendar) BindEventToChannel(arg0 *mscalendar.User, arg1, arg2 string, arg3 time.Duration) (*store.ChannelBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindEventToChannel", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*store.ChannelBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindEventToChannel indicates an expected call of BindEventToChannel
+func (mr *MockMSCalendarMockRecorder) BindEventToChannel(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindEventToChannel", reflect.TypeOf((*MockMSCalendar)(nil).BindEventToChannel), arg0, arg1, arg2, arg3)
+}
+
+// BookRoom mocks base method
+func (m *MockMSCalendar) BookRoom(arg0 *mscalendar.User, arg1, arg2 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookRoom", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookRoom indicates an expected call of BookRoom
+func (mr *MockMSCalendarMockRecorder) BookRoom(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookRoom", reflect.TypeOf((*MockMSCalendar)(nil).BookRoom), arg0, arg1, arg2)
+}
+
+// CancelEvent mocks base method
+func (m *MockMSCalendar) CancelEvent(arg0 *mscalendar.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEvent indicates an expected call of CancelEvent
+func (mr *MockMSCalendarMockRecorder) CancelEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockMSCalendar)(nil).CancelEvent), arg0, arg1, arg2)
+}
+
 // ClearSettingsPosts mocks base method
 func (m *MockMSCalendar) ClearSettingsPosts(arg0 string) {
 	m.ctrl.T.Helper()
@@ -106,6 +180,21 @@ func (mr *MockMSCalendarMockRecorder) CreateCalendar(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendar", reflect.TypeOf((*MockMSCalendar)(nil).CreateCalendar), arg0, arg1)
 }
 
+// CreateDelegatorEvent mocks base method
+func (m *MockMSCalendar) CreateDelegatorEvent(arg0 *mscalendar.User, arg1 string, arg2 *remote.Event, arg3 []string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelegatorEvent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelegatorEvent indicates an expected call of CreateDelegatorEvent
+func (mr *MockMSCalendarMockRecorder) CreateDelegatorEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegatorEvent", reflect.TypeOf((*MockMSCalendar)(nil).CreateDelegatorEvent), arg0, arg1, arg2, arg3)
+}
+
 // CreateEvent mocks base method
 func (m *MockMSCalendar) CreateEvent(arg0 *mscalendar.User, arg1 *remote.Event, arg2 []string) (*remote.Event, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +210,21 @@ func (mr *MockMSCalendarMockRecorder) CreateEvent(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockMSCalendar)(nil).CreateEvent), arg0, arg1, arg2)
 }
 
+// CreateEventFromPost mocks base method
+func (m *MockMSCalendar) CreateEventFromPost(arg0 *mscalendar.User, arg1 string, arg2 *remote.Event, arg3 []string, arg4 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventFromPost", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEventFromPost indicates an expected call of CreateEventFromPost
+func (mr *MockMSCalendarMockRecorder) CreateEventFromPost(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventFromPost", reflect.TypeOf((*MockMSCalendar)(nil).CreateEventFromPost), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateMyEventSubscription mocks base method
 func (m *MockMSCalendar) CreateMyEventSubscription() (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -206,6 +310,50 @@ func (mr *MockMSCalendarMockRecorder) DisconnectUser(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectUser", reflect.TypeOf((*MockMSCalendar)(nil).DisconnectUser), arg0)
 }
 
+// DismissReminder mocks base method
+func (m *MockMSCalendar) DismissReminder(arg0 *mscalendar.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReminder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissReminder indicates an expected call of DismissReminder
+func (mr *MockMSCalendarMockRecorder) DismissReminder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReminder", reflect.TypeOf((*MockMSCalendar)(nil).DismissReminder), arg0, arg1)
+}
+
+// ExportCalendar mocks base method
+func (m *MockMSCalendar) ExportCalendar(arg0 *mscalendar.User, arg1, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCalendar", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCalendar indicates an expected call of ExportCalendar
+func (mr *MockMSCalendarMockRecorder) ExportCalendar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCalendar", reflect.TypeOf((*MockMSCalendar)(nil).ExportCalendar), arg0, arg1, arg2)
+}
+
+// FindFreeRooms mocks base method
+func (m *MockMSCalendar) FindFreeRooms(arg0 *mscalendar.User, arg1 string, arg2, arg3 time.Time, arg4 int) ([]*remote.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFreeRooms", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*remote.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFreeRooms indicates an expected call of FindFreeRooms
+func (mr *MockMSCalendarMockRecorder) FindFreeRooms(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFreeRooms", reflect.TypeOf((*MockMSCalendar)(nil).FindFreeRooms), arg0, arg1, arg2, arg3, arg4)
+}
+
 // FindMeetingTimes mocks base method
 func (m *MockMSCalendar) FindMeetingTimes(arg0 *mscalendar.User, arg1 *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
 	m.ctrl.T.Helper()
@@ -265,6 +413,21 @@ func (mr *MockMSCalendarMockRecorder) GetCalendars(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendars", reflect.TypeOf((*MockMSCalendar)(nil).GetCalendars), arg0)
 }
 
+// GetChannelBindings mocks base method
+func (m *MockMSCalendar) GetChannelBindings(arg0 *mscalendar.User) ([]*store.ChannelBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelBindings", arg0)
+	ret0, _ := ret[0].([]*store.ChannelBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelBindings indicates an expected call of GetChannelBindings
+func (mr *MockMSCalendarMockRecorder) GetChannelBindings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelBindings", reflect.TypeOf((*MockMSCalendar)(nil).GetChannelBindings), arg0)
+}
+
 // GetDailySummaryForUser mocks base method
 func (m *MockMSCalendar) GetDailySummaryForUser(arg0 *mscalendar.User) (string, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +458,81 @@ func (mr *MockMSCalendarMockRecorder) GetDailySummarySettingsForUser(arg0 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailySummarySettingsForUser", reflect.TypeOf((*MockMSCalendar)(nil).GetDailySummarySettingsForUser), arg0)
 }
 
+// GetDelegatorID mocks base method
+func (m *MockMSCalendar) GetDelegatorID(arg0 *mscalendar.User, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorID", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorID indicates an expected call of GetDelegatorID
+func (mr *MockMSCalendarMockRecorder) GetDelegatorID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorID", reflect.TypeOf((*MockMSCalendar)(nil).GetDelegatorID), arg0, arg1)
+}
+
+// GetEvent mocks base method
+func (m *MockMSCalendar) GetEvent(arg0 *mscalendar.User, arg1 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent
+func (mr *MockMSCalendarMockRecorder) GetEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockMSCalendar)(nil).GetEvent), arg0, arg1)
+}
+
+// GetFreeBusy mocks base method
+func (m *MockMSCalendar) GetFreeBusy(arg0 *mscalendar.User, arg1 []string, arg2, arg3 time.Time) ([]*views.FreeBusyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFreeBusy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*views.FreeBusyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFreeBusy indicates an expected call of GetFreeBusy
+func (mr *MockMSCalendarMockRecorder) GetFreeBusy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFreeBusy", reflect.TypeOf((*MockMSCalendar)(nil).GetFreeBusy), arg0, arg1, arg2, arg3)
+}
+
+// GetNotificationFilters mocks base method
+func (m *MockMSCalendar) GetNotificationFilters(arg0 *mscalendar.User) (*store.NotificationFilters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationFilters", arg0)
+	ret0, _ := ret[0].(*store.NotificationFilters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationFilters indicates an expected call of GetNotificationFilters
+func (mr *MockMSCalendarMockRecorder) GetNotificationFilters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationFilters", reflect.TypeOf((*MockMSCalendar)(nil).GetNotificationFilters), arg0)
+}
+
+// GetPendingInvitations mocks base method
+func (m *MockMSCalendar) GetPendingInvitations(arg0 *mscalendar.User, arg1 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvitations indicates an expected call of GetPendingInvitations
+func (mr *MockMSCalendarMockRecorder) GetPendingInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvitations", reflect.TypeOf((*MockMSCalendar)(nil).GetPendingInvitations), arg0, arg1)
+}
+
 // GetRemoteUser mocks base method
 func (m *MockMSCalendar) GetRemoteUser(arg0 string) (*remote.User, error) {
 	m.ctrl.T.Helper()
@@ -310,6 +548,21 @@ func (mr *MockMSCalendarMockRecorder) GetRemoteUser(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteUser", reflect.TypeOf((*MockMSCalendar)(nil).GetRemoteUser), arg0)
 }
 
+// GetRoomByName mocks base method
+func (m *MockMSCalendar) GetRoomByName(arg0 *mscalendar.User, arg1 string) (*remote.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomByName", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomByName indicates an expected call of GetRoomByName
+func (mr *MockMSCalendarMockRecorder) GetRoomByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomByName", reflect.TypeOf((*MockMSCalendar)(nil).GetRoomByName), arg0, arg1)
+}
+
 // GetTimezone mocks base method
 func (m *MockMSCalendar) GetTimezone(arg0 *mscalendar.User) (string, error) {
 	m.ctrl.T.Helper()
@@ -340,6 +593,21 @@ func (mr *MockMSCalendarMockRecorder) GetUserSettings(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockMSCalendar)(nil).GetUserSettings), arg0)
 }
 
+// GetWeeklySummaryForUser mocks base method
+func (m *MockMSCalendar) GetWeeklySummaryForUser(arg0 *mscalendar.User, arg1 bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeeklySummaryForUser", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeeklySummaryForUser indicates an expected call of GetWeeklySummaryForUser
+func (mr *MockMSCalendarMockRecorder) GetWeeklySummaryForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklySummaryForUser", reflect.TypeOf((*MockMSCalendar)(nil).GetWeeklySummaryForUser), arg0, arg1)
+}
+
 // HandleBusyDM mocks base method
 func (m *MockMSCalendar) HandleBusyDM(arg0 *model.Post) error {
 	m.ctrl.T.Helper()
@@ -354,6 +622,21 @@ func (mr *MockMSCalendarMockRecorder) HandleBusyDM(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBusyDM", reflect.TypeOf((*MockMSCalendar)(nil).HandleBusyDM), arg0)
 }
 
+// ImportICSFile mocks base method
+func (m *MockMSCalendar) ImportICSFile(arg0 *mscalendar.User, arg1 string) (*mscalendar.ICSImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportICSFile", arg0, arg1)
+	ret0, _ := ret[0].(*mscalendar.ICSImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportICSFile indicates an expected call of ImportICSFile
+func (mr *MockMSCalendarMockRecorder) ImportICSFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportICSFile", reflect.TypeOf((*MockMSCalendar)(nil).ImportICSFile), arg0, arg1)
+}
+
 // IsAuthorizedAdmin mocks base method
 func (m *MockMSCalendar) IsAuthorizedAdmin(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -369,6 +652,22 @@ func (mr *MockMSCalendarMockRecorder) IsAuthorizedAdmin(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAuthorizedAdmin", reflect.TypeOf((*MockMSCalendar)(nil).IsAuthorizedAdmin), arg0)
 }
 
+// ListDelegations mocks base method
+func (m *MockMSCalendar) ListDelegations(arg0 *mscalendar.User) ([]string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDelegations", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDelegations indicates an expected call of ListDelegations
+func (mr *MockMSCalendarMockRecorder) ListDelegations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDelegations", reflect.TypeOf((*MockMSCalendar)(nil).ListDelegations), arg0)
+}
+
 // ListRemoteSubscriptions mocks base method
 func (m *MockMSCalendar) ListRemoteSubscriptions() ([]*remote.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -399,6 +698,93 @@ func (mr *MockMSCalendarMockRecorder) LoadMyEventSubscription() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMyEventSubscription", reflect.TypeOf((*MockMSCalendar)(nil).LoadMyEventSubscription))
 }
 
+// MoveEvent mocks base method
+func (m *MockMSCalendar) MoveEvent(arg0 *mscalendar.User, arg1 string, arg2, arg3 time.Time) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveEvent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveEvent indicates an expected call of MoveEvent
+func (mr *MockMSCalendarMockRecorder) MoveEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveEvent", reflect.TypeOf((*MockMSCalendar)(nil).MoveEvent), arg0, arg1, arg2, arg3)
+}
+
+// MuteKeyword mocks base method
+func (m *MockMSCalendar) MuteKeyword(arg0 *mscalendar.User, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteKeyword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteKeyword indicates an expected call of MuteKeyword
+func (mr *MockMSCalendarMockRecorder) MuteKeyword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteKeyword", reflect.TypeOf((*MockMSCalendar)(nil).MuteKeyword), arg0, arg1, arg2)
+}
+
+// MuteOrganizer mocks base method
+func (m *MockMSCalendar) MuteOrganizer(arg0 *mscalendar.User, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteOrganizer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteOrganizer indicates an expected call of MuteOrganizer
+func (mr *MockMSCalendarMockRecorder) MuteOrganizer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteOrganizer", reflect.TypeOf((*MockMSCalendar)(nil).MuteOrganizer), arg0, arg1, arg2)
+}
+
+// NewSearchResultsAttachment mocks base method
+func (m *MockMSCalendar) NewSearchResultsAttachment(arg0 *mscalendar.SearchResults, arg1 string) (*model.SlackAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSearchResultsAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*model.SlackAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewSearchResultsAttachment indicates an expected call of NewSearchResultsAttachment
+func (mr *MockMSCalendarMockRecorder) NewSearchResultsAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSearchResultsAttachment", reflect.TypeOf((*MockMSCalendar)(nil).NewSearchResultsAttachment), arg0, arg1)
+}
+
+// NudgeNonResponders mocks base method
+func (m *MockMSCalendar) NudgeNonResponders(arg0 *mscalendar.User, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NudgeNonResponders", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NudgeNonResponders indicates an expected call of NudgeNonResponders
+func (mr *MockMSCalendarMockRecorder) NudgeNonResponders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NudgeNonResponders", reflect.TypeOf((*MockMSCalendar)(nil).NudgeNonResponders), arg0, arg1)
+}
+
+// OfferICSImport mocks base method
+func (m *MockMSCalendar) OfferICSImport(arg0 *model.Post, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferICSImport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OfferICSImport indicates an expected call of OfferICSImport
+func (mr *MockMSCalendarMockRecorder) OfferICSImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferICSImport", reflect.TypeOf((*MockMSCalendar)(nil).OfferICSImport), arg0, arg1)
+}
+
 // OpenAutoRespondDialog mocks base method
 func (m *MockMSCalendar) OpenAutoRespondDialog(arg0 model.OpenDialogRequest) error {
 	m.ctrl.T.Helper()
@@ -413,6 +799,90 @@ func (mr *MockMSCalendarMockRecorder) OpenAutoRespondDialog(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAutoRespondDialog", reflect.TypeOf((*MockMSCalendar)(nil).OpenAutoRespondDialog), arg0)
 }
 
+// OpenCancelEventDialog mocks base method
+func (m *MockMSCalendar) OpenCancelEventDialog(arg0 *mscalendar.User, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCancelEventDialog", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenCancelEventDialog indicates an expected call of OpenCancelEventDialog
+func (mr *MockMSCalendarMockRecorder) OpenCancelEventDialog(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCancelEventDialog", reflect.TypeOf((*MockMSCalendar)(nil).OpenCancelEventDialog), arg0, arg1, arg2, arg3)
+}
+
+// OpenCreateEventFromPostDialog mocks base method
+func (m *MockMSCalendar) OpenCreateEventFromPostDialog(arg0 *mscalendar.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCreateEventFromPostDialog", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenCreateEventFromPostDialog indicates an expected call of OpenCreateEventFromPostDialog
+func (mr *MockMSCalendarMockRecorder) OpenCreateEventFromPostDialog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCreateEventFromPostDialog", reflect.TypeOf((*MockMSCalendar)(nil).OpenCreateEventFromPostDialog), arg0, arg1, arg2)
+}
+
+// OpenEditEventDialog mocks base method
+func (m *MockMSCalendar) OpenEditEventDialog(arg0 *mscalendar.User, arg1, arg2, arg3 string, arg4 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenEditEventDialog", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenEditEventDialog indicates an expected call of OpenEditEventDialog
+func (mr *MockMSCalendarMockRecorder) OpenEditEventDialog(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenEditEventDialog", reflect.TypeOf((*MockMSCalendar)(nil).OpenEditEventDialog), arg0, arg1, arg2, arg3, arg4)
+}
+
+// OpenMutedDialog mocks base method
+func (m *MockMSCalendar) OpenMutedDialog(arg0 *mscalendar.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMutedDialog", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenMutedDialog indicates an expected call of OpenMutedDialog
+func (mr *MockMSCalendarMockRecorder) OpenMutedDialog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMutedDialog", reflect.TypeOf((*MockMSCalendar)(nil).OpenMutedDialog), arg0, arg1, arg2)
+}
+
+// PostPendingInvitations mocks base method
+func (m *MockMSCalendar) PostPendingInvitations(arg0 *mscalendar.User, arg1 []*remote.Event, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostPendingInvitations", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostPendingInvitations indicates an expected call of PostPendingInvitations
+func (mr *MockMSCalendarMockRecorder) PostPendingInvitations(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostPendingInvitations", reflect.TypeOf((*MockMSCalendar)(nil).PostPendingInvitations), arg0, arg1, arg2, arg3)
+}
+
+// PostSearchResults mocks base method
+func (m *MockMSCalendar) PostSearchResults(arg0 *mscalendar.User, arg1 *mscalendar.SearchResults, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostSearchResults", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostSearchResults indicates an expected call of PostSearchResults
+func (mr *MockMSCalendarMockRecorder) PostSearchResults(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSearchResults", reflect.TypeOf((*MockMSCalendar)(nil).PostSearchResults), arg0, arg1, arg2)
+}
+
 // PrintSettings mocks base method
 func (m *MockMSCalendar) PrintSettings(arg0 string) {
 	m.ctrl.T.Helper()
@@ -439,6 +909,62 @@ func (mr *MockMSCalendarMockRecorder) ProcessAllDailySummary(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllDailySummary", reflect.TypeOf((*MockMSCalendar)(nil).ProcessAllDailySummary), arg0)
 }
 
+// ProcessAllDigests mocks base method
+func (m *MockMSCalendar) ProcessAllDigests(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAllDigests", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAllDigests indicates an expected call of ProcessAllDigests
+func (mr *MockMSCalendarMockRecorder) ProcessAllDigests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllDigests", reflect.TypeOf((*MockMSCalendar)(nil).ProcessAllDigests), arg0)
+}
+
+// ProcessAllFocusTime mocks base method
+func (m *MockMSCalendar) ProcessAllFocusTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAllFocusTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAllFocusTime indicates an expected call of ProcessAllFocusTime
+func (mr *MockMSCalendarMockRecorder) ProcessAllFocusTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllFocusTime", reflect.TypeOf((*MockMSCalendar)(nil).ProcessAllFocusTime), arg0)
+}
+
+// ProcessAllWeeklySummary mocks base method
+func (m *MockMSCalendar) ProcessAllWeeklySummary(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAllWeeklySummary", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAllWeeklySummary indicates an expected call of ProcessAllWeeklySummary
+func (mr *MockMSCalendarMockRecorder) ProcessAllWeeklySummary(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllWeeklySummary", reflect.TypeOf((*MockMSCalendar)(nil).ProcessAllWeeklySummary), arg0)
+}
+
+// RemoveDelegation mocks base method
+func (m *MockMSCalendar) RemoveDelegation(arg0 *mscalendar.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDelegation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDelegation indicates an expected call of RemoveDelegation
+func (mr *MockMSCalendarMockRecorder) RemoveDelegation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDelegation", reflect.TypeOf((*MockMSCalendar)(nil).RemoveDelegation), arg0, arg1)
+}
+
 // RenewMyEventSubscription mocks base method
 func (m *MockMSCalendar) RenewMyEventSubscription() (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -454,18 +980,75 @@ func (mr *MockMSCalendarMockRecorder) RenewMyEventSubscription() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewMyEventSubscription", reflect.TypeOf((*MockMSCalendar)(nil).RenewMyEventSubscription))
 }
 
+// RequestDelegation mocks base method
+func (m *MockMSCalendar) RequestDelegation(arg0 *mscalendar.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDelegation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestDelegation indicates an expected call of RequestDelegation
+func (mr *MockMSCalendarMockRecorder) RequestDelegation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDelegation", reflect.TypeOf((*MockMSCalendar)(nil).RequestDelegation), arg0, arg1)
+}
+
+// RespondToDelegationRequest mocks base method
+func (m *MockMSCalendar) RespondToDelegationRequest(arg0 *mscalendar.User, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondToDelegationRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondToDelegationRequest indicates an expected call of RespondToDelegationRequest
+func (mr *MockMSCalendarMockRecorder) RespondToDelegationRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToDelegationRequest", reflect.TypeOf((*MockMSCalendar)(nil).RespondToDelegationRequest), arg0, arg1, arg2)
+}
+
+// RespondToDelegatorEvent mocks base method
+func (m *MockMSCalendar) RespondToDelegatorEvent(arg0 *mscalendar.User, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondToDelegatorEvent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondToDelegatorEvent indicates an expected call of RespondToDelegatorEvent
+func (mr *MockMSCalendarMockRecorder) RespondToDelegatorEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToDelegatorEvent", reflect.TypeOf((*MockMSCalendar)(nil).RespondToDelegatorEvent), arg0, arg1, arg2, arg3)
+}
+
 // RespondToEvent mocks base method
-func (m *MockMSCalendar) RespondToEvent(arg0 *mscalendar.User, arg1, arg2 string) error {
+func (m *MockMSCalendar) RespondToEvent(arg0 *mscalendar.User, arg1, arg2 string, arg3 *remote.EventResponseOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RespondToEvent", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RespondToEvent", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RespondToEvent indicates an expected call of RespondToEvent
-func (mr *MockMSCalendarMockRecorder) RespondToEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockMSCalendarMockRecorder) RespondToEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToEvent", reflect.TypeOf((*MockMSCalendar)(nil).RespondToEvent), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToEvent", reflect.TypeOf((*MockMSCalendar)(nil).RespondToEvent), arg0, arg1, arg2, arg3)
+}
+
+// SearchEvents mocks base method
+func (m *MockMSCalendar) SearchEvents(arg0 *mscalendar.User, arg1 string, arg2, arg3 time.Time, arg4 int) (*mscalendar.SearchResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEvents", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*mscalendar.SearchResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEvents indicates an expected call of SearchEvents
+func (mr *MockMSCalendarMockRecorder) SearchEvents(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEvents", reflect.TypeOf((*MockMSCalendar)(nil).SearchEvents), arg0, arg1, arg2, arg3, arg4)
 }
 
 // SetDailySummaryEnabled mocks base method
@@ -498,6 +1081,35 @@ func (mr *MockMSCalendarMockRecorder) SetDailySummaryPostTime(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDailySummaryPostTime", reflect.TypeOf((*MockMSCalendar)(nil).SetDailySummaryPostTime), arg0, arg1)
 }
 
+// SetMuted mocks base method
+func (m *MockMSCalendar) SetMuted(arg0 *mscalendar.User, arg1, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMuted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMuted indicates an expected call of SetMuted
+func (mr *MockMSCalendarMockRecorder) SetMuted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMuted", reflect.TypeOf((*MockMSCalendar)(nil).SetMuted), arg0, arg1, arg2)
+}
+
+// SetNextWeekPreviewEnabled mocks base method
+func (m *MockMSCalendar) SetNextWeekPreviewEnabled(arg0 *mscalendar.User, arg1 bool) (*store.DailySummaryUserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNextWeekPreviewEnabled", arg0, arg1)
+	ret0, _ := ret[0].(*store.DailySummaryUserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNextWeekPreviewEnabled indicates an expected call of SetNextWeekPreviewEnabled
+func (mr *MockMSCalendarMockRecorder) SetNextWeekPreviewEnabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNextWeekPreviewEnabled", reflect.TypeOf((*MockMSCalendar)(nil).SetNextWeekPreviewEnabled), arg0, arg1)
+}
+
 // SetUserAutoRespondMessage mocks base method
 func (m *MockMSCalendar) SetUserAutoRespondMessage(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -512,6 +1124,66 @@ func (mr *MockMSCalendarMockRecorder) SetUserAutoRespondMessage(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAutoRespondMessage", reflect.TypeOf((*MockMSCalendar)(nil).SetUserAutoRespondMessage), arg0, arg1)
 }
 
+// SetWeeklySummaryEnabled mocks base method
+func (m *MockMSCalendar) SetWeeklySummaryEnabled(arg0 *mscalendar.User, arg1 bool) (*store.DailySummaryUserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWeeklySummaryEnabled", arg0, arg1)
+	ret0, _ := ret[0].(*store.DailySummaryUserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWeeklySummaryEnabled indicates an expected call of SetWeeklySummaryEnabled
+func (mr *MockMSCalendarMockRecorder) SetWeeklySummaryEnabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWeeklySummaryEnabled", reflect.TypeOf((*MockMSCalendar)(nil).SetWeeklySummaryEnabled), arg0, arg1)
+}
+
+// SnoozeReminder mocks base method
+func (m *MockMSCalendar) SnoozeReminder(arg0 *mscalendar.User, arg1 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeReminder", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeReminder indicates an expected call of SnoozeReminder
+func (mr *MockMSCalendarMockRecorder) SnoozeReminder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeReminder", reflect.TypeOf((*MockMSCalendar)(nil).SnoozeReminder), arg0, arg1)
+}
+
+// StartFocusTime mocks base method
+func (m *MockMSCalendar) StartFocusTime(arg0 *mscalendar.User, arg1, arg2 time.Time) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFocusTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartFocusTime indicates an expected call of StartFocusTime
+func (mr *MockMSCalendarMockRecorder) StartFocusTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFocusTime", reflect.TypeOf((*MockMSCalendar)(nil).StartFocusTime), arg0, arg1, arg2)
+}
+
+// StopFocusTime mocks base method
+func (m *MockMSCalendar) StopFocusTime(arg0 *mscalendar.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopFocusTime", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopFocusTime indicates an expected call of StopFocusTime
+func (mr *MockMSCalendarMockRecorder) StopFocusTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopFocusTime", reflect.TypeOf((*MockMSCalendar)(nil).StopFocusTime), arg0)
+}
+
 // Sync mocks base method
 func (m *MockMSCalendar) Sync(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -556,6 +1228,48 @@ func (mr *MockMSCalendarMockRecorder) TentativelyAcceptEvent(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockMSCalendar)(nil).TentativelyAcceptEvent), arg0, arg1)
 }
 
+// UnbindEvent mocks base method
+func (m *MockMSCalendar) UnbindEvent(arg0 *mscalendar.User, arg1 string) (*store.ChannelBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbindEvent", arg0, arg1)
+	ret0, _ := ret[0].(*store.ChannelBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbindEvent indicates an expected call of UnbindEvent
+func (mr *MockMSCalendarMockRecorder) UnbindEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbindEvent", reflect.TypeOf((*MockMSCalendar)(nil).UnbindEvent), arg0, arg1)
+}
+
+// UpdateEvent mocks base method
+func (m *MockMSCalendar) UpdateEvent(arg0 *mscalendar.User, arg1 *remote.Event) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent
+func (mr *MockMSCalendarMockRecorder) UpdateEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockMSCalendar)(nil).UpdateEvent), arg0, arg1)
+}
+
+// UpdateSettingsPost mocks base method
+func (m *MockMSCalendar) UpdateSettingsPost(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateSettingsPost", arg0, arg1)
+}
+
+// UpdateSettingsPost indicates an expected call of UpdateSettingsPost
+func (mr *MockMSCalendarMockRecorder) UpdateSettingsPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettingsPost", reflect.TypeOf((*MockMSCalendar)(nil).UpdateSettingsPost), arg0, arg1)
+}
+
 // ViewCalendar mocks base method
 func (m *MockMSCalendar) ViewCalendar(arg0 *mscalendar.User, arg1, arg2 time.Time) ([]*remote.Event, error) {
 	m.ctrl.T.Helper()
@@ -571,6 +1285,36 @@ func (mr *MockMSCalendarMockRecorder) ViewCalendar(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCalendar", reflect.TypeOf((*MockMSCalendar)(nil).ViewCalendar), arg0, arg1, arg2)
 }
 
+// ViewCalendarByID mocks base method
+func (m *MockMSCalendar) ViewCalendarByID(arg0 *mscalendar.User, arg1 string, arg2, arg3 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewCalendarByID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewCalendarByID indicates an expected call of ViewCalendarByID
+func (mr *MockMSCalendarMockRecorder) ViewCalendarByID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCalendarByID", reflect.TypeOf((*MockMSCalendar)(nil).ViewCalendarByID), arg0, arg1, arg2, arg3)
+}
+
+// ViewDelegatorCalendar mocks base method
+func (m *MockMSCalendar) ViewDelegatorCalendar(arg0 *mscalendar.User, arg1 string, arg2, arg3 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewDelegatorCalendar", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewDelegatorCalendar indicates an expected call of ViewDelegatorCalendar
+func (mr *MockMSCalendarMockRecorder) ViewDelegatorCalendar(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewDelegatorCalendar", reflect.TypeOf((*MockMSCalendar)(nil).ViewDelegatorCalendar), arg0, arg1, arg2, arg3)
+}
+
 // Welcome mocks base method
 func (m *MockMSCalendar) Welcome(arg0 string) error {
 	m.ctrl.T.Helper()
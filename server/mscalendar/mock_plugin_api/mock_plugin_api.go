@@ -48,6 +48,66 @@ func (mr *MockPluginAPIMockRecorder) GetMattermostChannel(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostChannel", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostChannel), arg0)
 }
 
+// GetMattermostFile mocks base method
+func (m *MockPluginAPI) GetMattermostFile(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostFile", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostFile indicates an expected call of GetMattermostFile
+func (mr *MockPluginAPIMockRecorder) GetMattermostFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostFile", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostFile), arg0)
+}
+
+// GetMattermostFileInfo mocks base method
+func (m *MockPluginAPI) GetMattermostFileInfo(arg0 string) (*model.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostFileInfo", arg0)
+	ret0, _ := ret[0].(*model.FileInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostFileInfo indicates an expected call of GetMattermostFileInfo
+func (mr *MockPluginAPIMockRecorder) GetMattermostFileInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostFileInfo", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostFileInfo), arg0)
+}
+
+// GetMattermostTeam mocks base method
+func (m *MockPluginAPI) GetMattermostTeam(arg0 string) (*model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostTeam", arg0)
+	ret0, _ := ret[0].(*model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostTeam indicates an expected call of GetMattermostTeam
+func (mr *MockPluginAPIMockRecorder) GetMattermostTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostTeam", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostTeam), arg0)
+}
+
+// GetMattermostTeamMember mocks base method
+func (m *MockPluginAPI) GetMattermostTeamMember(arg0, arg1 string) (*model.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(*model.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostTeamMember indicates an expected call of GetMattermostTeamMember
+func (mr *MockPluginAPIMockRecorder) GetMattermostTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostTeamMember", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostTeamMember), arg0, arg1)
+}
+
 // GetMattermostUser mocks base method
 func (m *MockPluginAPI) GetMattermostUser(arg0 string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +198,35 @@ func (mr *MockPluginAPIMockRecorder) GetPost(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockPluginAPI)(nil).GetPost), arg0)
 }
 
+// GetPostThread mocks base method
+func (m *MockPluginAPI) GetPostThread(arg0 string) (*model.PostList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostThread", arg0)
+	ret0, _ := ret[0].(*model.PostList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostThread indicates an expected call of GetPostThread
+func (mr *MockPluginAPIMockRecorder) GetPostThread(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostThread", reflect.TypeOf((*MockPluginAPI)(nil).GetPostThread), arg0)
+}
+
+// HasPermissionToChannel mocks base method
+func (m *MockPluginAPI) HasPermissionToChannel(arg0, arg1 string, arg2 *model.Permission) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermissionToChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasPermissionToChannel indicates an expected call of HasPermissionToChannel
+func (mr *MockPluginAPIMockRecorder) HasPermissionToChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermissionToChannel", reflect.TypeOf((*MockPluginAPI)(nil).HasPermissionToChannel), arg0, arg1, arg2)
+}
+
 // IsSysAdmin mocks base method
 func (m *MockPluginAPI) IsSysAdmin(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -181,3 +270,18 @@ func (mr *MockPluginAPIMockRecorder) UpdateMattermostUserStatus(arg0, arg1 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMattermostUserStatus", reflect.TypeOf((*MockPluginAPI)(nil).UpdateMattermostUserStatus), arg0, arg1)
 }
+
+// UpdatePost mocks base method
+func (m *MockPluginAPI) UpdatePost(arg0 *model.Post) (*model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePost", arg0)
+	ret0, _ := ret[0].(*model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePost indicates an expected call of UpdatePost
+func (mr *MockPluginAPIMockRecorder) UpdatePost(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockPluginAPI)(nil).UpdatePost), arg0)
+}
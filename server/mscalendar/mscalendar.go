@@ -17,7 +17,19 @@ import (
 type MSCalendar interface {
 	Availability
 	Calendar
+	Delegation
+	Digests
 	EventResponder
+	FocusTime
+	FreeBusy
+	ICalendar
+	Invitations
+	MeetingThreads
+	Reminders
+	NotificationFilters
+	Rooms
+	RSVPTracker
+	Search
 	AutoRespond
 	Subscriptions
 	Users
@@ -42,6 +54,10 @@ type Dependencies struct {
 type PluginAPI interface {
 	OpenInteractiveDialog(dialog model.OpenDialogRequest) error
 	GetMattermostChannel(mattermostChannelID string) (*model.Channel, error)
+	GetMattermostTeam(mattermostTeamID string) (*model.Team, error)
+	GetMattermostTeamMember(mattermostTeamID, mattermostUserID string) (*model.TeamMember, error)
+	GetMattermostFileInfo(fileID string) (*model.FileInfo, error)
+	GetMattermostFile(fileID string) ([]byte, error)
 	GetMattermostUsersInChannel(mattermostChannelID string, sortBy string, page int, perPage int) ([]*model.User, error)
 	GetMattermostUser(mattermostUserID string) (*model.User, error)
 	GetMattermostUserByUsername(mattermostUsername string) (*model.User, error)
@@ -50,6 +66,9 @@ type PluginAPI interface {
 	IsSysAdmin(mattermostUserID string) (bool, error)
 	UpdateMattermostUserStatus(mattermostUserID, status string) (*model.Status, error)
 	GetPost(postID string) (*model.Post, error)
+	GetPostThread(postID string) (*model.PostList, error)
+	HasPermissionToChannel(mattermostUserID, channelID string, permission *model.Permission) bool
+	UpdatePost(post *model.Post) (*model.Post, error)
 }
 
 type Env struct {
@@ -32,6 +32,7 @@ const (
 	FieldAttendees      = "Attendees"
 	FieldOrganizer      = "Organizer"
 	FieldResponseStatus = "ResponseStatus"
+	FieldConflicts      = "Conflicts"
 )
 
 const (
@@ -69,6 +70,10 @@ type notificationProcessor struct {
 
 	queue chan *remote.Notification
 	quit  chan bool
+
+	// conflictViews are the calendar views looked up for conflicts, by
+	// remote user ID.
+	conflictViews map[string]*conflictView
 }
 
 func NewNotificationProcessor(env Env) NotificationProcessor {
@@ -170,12 +175,22 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 			return err
 		}
 	}
+	if n.Event == nil {
+		return errors.New("notification has no event")
+	}
+	if n.ChangeType == remote.ChangeTypeDeleted {
+		return processor.processDeletedEvent(creator, n.Event.ID)
+	}
 
 	var sa *model.SlackAttachment
 	prior, err := processor.Store.LoadUserEvent(creator.MattermostUserID, n.Event.ICalUID)
 	if err != nil && err != store.ErrNotFound {
 		return err
 	}
+	var priorEvent *remote.Event
+	if prior != nil {
+		priorEvent = prior.Remote
+	}
 
 	mailSettings, err := client.GetMailboxSettings(sub.Remote.CreatorID)
 	if err != nil {
@@ -183,10 +198,34 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 	}
 	timezone := mailSettings.TimeZone
 
-	if prior != nil {
+	// Responses to the organizer's events only update the RSVP tracker
+	trackerUpdated := processor.updateRSVPTracker(creator, n.Event, prior, timezone)
+
+	// change describes the update for the history of the event, and is empty
+	// for new events
+	change := ""
+	if n.Event.IsCancelled && (priorEvent == nil || !priorEvent.IsCancelled) {
+		sa = processor.cancelledEventSlackAttachment(n, timezone)
+		if prior != nil {
+			// The last notification is replaced with the cancellation below
+			processor.clearStaleActions(creator.MattermostUserID, prior, "This event has been cancelled.", prior.NotificationPostID)
+			change = "Cancelled"
+		} else {
+			prior = &store.Event{}
+		}
+	} else if prior != nil {
 		var changed bool
-		changed, sa = processor.updatedEventSlackAttachment(n, prior.Remote, timezone)
-		if !changed {
+		changed, sa = processor.updatedEventSlackAttachment(n, prior.Remote, timezone, importantChanges(creator.Settings.NotificationFilters))
+		if changed {
+			change = "Changed " + strings.Join(changedFields(sa), ", ")
+		} else {
+			if trackerUpdated {
+				prior.Remote = n.Event
+				err = processor.Store.StoreUserEvent(creator.MattermostUserID, prior)
+				if err != nil {
+					return err
+				}
+			}
 			processor.Logger.With(bot.LogContext{
 				"MattermostUserID": creator.MattermostUserID,
 				"SubscriptionID":   n.SubscriptionID,
@@ -201,10 +240,43 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 		prior = &store.Event{}
 	}
 
-	_, err = processor.Poster.DMWithAttachments(creator.MattermostUserID, sa)
+	if isNotificationFiltered(creator.Settings.NotificationFilters, n.Event) {
+		// The event is kept, for later changes to be compared with
+		prior.Remote = n.Event
+		processor.Logger.With(bot.LogContext{
+			"MattermostUserID": creator.MattermostUserID,
+			"SubscriptionID":   n.SubscriptionID,
+			"EventID":          n.Event.ID,
+		}).Debugf("webhook notification: event filtered out by the user's settings.")
+		return processor.Store.StoreUserEvent(creator.MattermostUserID, prior)
+	}
+
+	if creator.Settings.NotificationDigest != "" && !isUrgentChange(n.Event, priorEvent, time.Now()) {
+		err = processor.addToDigest(creator, n.Event, priorEvent == nil, changedFields(sa), timezone)
+		if err != nil {
+			return err
+		}
+		if !n.Event.IsOrganizer {
+			processor.notifyDelegates(creator, n.Event, sa)
+		}
+
+		prior.Remote = n.Event
+		return processor.Store.StoreUserEvent(creator.MattermostUserID, prior)
+	}
+
+	processor.addConflictsToSlackAttachment(client, sub.Remote.CreatorID, n.Event, sa, timezone)
+
+	postID, err := processor.postNotification(creator, prior, sa, change, timezone)
 	if err != nil {
 		return err
 	}
+	if len(sa.Actions) > 0 && !containsString(prior.PostIDs, postID) {
+		prior.PostIDs = append(prior.PostIDs, postID)
+	}
+
+	if !n.Event.IsOrganizer {
+		processor.notifyDelegates(creator, n.Event, sa)
+	}
 
 	prior.Remote = n.Event
 	err = processor.Store.StoreUserEvent(creator.MattermostUserID, prior)
@@ -220,6 +292,44 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 	return nil
 }
 
+// notifyDelegates sends a copy of the invitation notification to the
+// delegates of the creator, letting them respond on the creator's behalf.
+func (processor *notificationProcessor) notifyDelegates(creator *store.User, event *remote.Event, sa *model.SlackAttachment) {
+	if len(creator.Delegates) == 0 {
+		return
+	}
+
+	creatorName := creator.MattermostUserID
+	if mattermostUser, err := processor.PluginAPI.GetMattermostUser(creator.MattermostUserID); err == nil {
+		creatorName = "@" + mattermostUser.Username
+	}
+
+	for _, delegateID := range creator.Delegates {
+		delegate, err := processor.Store.LoadUser(delegateID)
+		if err != nil || !containsString(delegate.Delegators, creator.MattermostUserID) {
+			continue
+		}
+
+		delegateSA := *sa
+		delegateSA.Pretext = "On behalf of " + creatorName
+		delegateSA.Actions = nil
+		if event.ResponseRequested && !event.IsCancelled {
+			delegateSA.Actions = NewPostActionForEventResponse(event.ID, event.ResponseStatus.Response, processor.actionURL(config.PathRespondAsDelegate))
+			for _, a := range delegateSA.Actions {
+				a.Integration.Context[config.DelegatorIDKey] = creator.MattermostUserID
+			}
+		}
+
+		_, err = processor.Poster.DMWithAttachments(delegateID, &delegateSA)
+		if err != nil {
+			processor.Logger.With(bot.LogContext{
+				"MattermostUserID": delegateID,
+				"DelegatorID":      creator.MattermostUserID,
+			}).Warnf("webhook notification: failed to notify delegate. err=%v", err)
+		}
+	}
+}
+
 func (processor *notificationProcessor) newSlackAttachment(n *remote.Notification) *model.SlackAttachment {
 	title := views.EnsureSubject(n.Event.Subject)
 	titleLink := n.Event.Weblink
@@ -252,10 +362,13 @@ func (processor *notificationProcessor) newEventSlackAttachment(n *remote.Notifi
 	if n.Event.ResponseRequested && !n.Event.IsOrganizer {
 		sa.Actions = NewPostActionForEventResponse(n.Event.ID, n.Event.ResponseStatus.Response, processor.actionURL(config.PathRespond))
 	}
+	if n.Event.IsOrganizer && !n.Event.IsCancelled {
+		sa.Actions = processor.newPostActionsForOrganizer(n.Event.ID)
+	}
 	return sa
 }
 
-func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.Notification, prior *remote.Event, timezone string) (bool, *model.SlackAttachment) {
+func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.Notification, prior *remote.Event, timezone string, important []string) (bool, *model.SlackAttachment) {
 	sa := processor.newSlackAttachment(n)
 	sa.Title = "(updated) " + sa.Title
 
@@ -271,7 +384,7 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 
 	hasImportantChanges := false
 	for _, k := range allChanges {
-		if isImportantChange(k) {
+		if containsString(important, k) {
 			hasImportantChanges = true
 			break
 		}
@@ -282,7 +395,7 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 	}
 
 	for _, k := range added {
-		if !isImportantChange(k) {
+		if !containsString(important, k) {
 			continue
 		}
 		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
@@ -292,7 +405,7 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 		})
 	}
 	for _, k := range updated {
-		if !isImportantChange(k) {
+		if !containsString(important, k) {
 			continue
 		}
 		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
@@ -302,7 +415,7 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 		})
 	}
 	for _, k := range deleted {
-		if !isImportantChange(k) {
+		if !containsString(important, k) {
 			continue
 		}
 		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
@@ -315,22 +428,43 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 	if n.Event.ResponseRequested && !n.Event.IsOrganizer && !n.Event.IsCancelled {
 		sa.Actions = NewPostActionForEventResponse(n.Event.ID, n.Event.ResponseStatus.Response, processor.actionURL(config.PathRespond))
 	}
-	return true, sa
-}
-
-func isImportantChange(fieldName string) bool {
-	for _, ic := range importantNotificationChanges {
-		if ic == fieldName {
-			return true
-		}
+	if n.Event.IsOrganizer && !n.Event.IsCancelled {
+		sa.Actions = processor.newPostActionsForOrganizer(n.Event.ID)
 	}
-	return false
+	return true, sa
 }
 
 func (processor *notificationProcessor) actionURL(action string) string {
 	return fmt.Sprintf("%s%s%s", processor.Config.PluginURLPath, config.PathPostAction, action)
 }
 
+// newPostActionsForOrganizer lets the organizer reschedule or cancel the
+// event from the notification.
+func (processor *notificationProcessor) newPostActionsForOrganizer(eventID string) []*model.PostAction {
+	context := map[string]interface{}{
+		config.EventIDKey: eventID,
+	}
+
+	return []*model.PostAction{
+		{
+			Name: "Reschedule",
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL:     processor.actionURL(config.PathRescheduleEvent),
+				Context: context,
+			},
+		},
+		{
+			Name: "Cancel meeting",
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL:     processor.actionURL(config.PathCancelEvent),
+				Context: context,
+			},
+		},
+	}
+}
+
 func NewPostActionForEventResponse(eventID, response, url string) []*model.PostAction {
 	context := map[string]interface{}{
 		config.EventIDKey: eventID,
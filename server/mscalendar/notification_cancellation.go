@@ -0,0 +1,151 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+// cancelledEventSlackAttachment shows the cancellation of an event with the
+// message the organizer sent with it.
+func (processor *notificationProcessor) cancelledEventSlackAttachment(n *remote.Notification, timezone string) *model.SlackAttachment {
+	sa := processor.newSlackAttachment(n)
+	sa.Title = "(cancelled) " + strings.TrimPrefix(sa.Title, "Canceled: ")
+	sa.Color = "#d24b4e"
+	sa.Text = ""
+
+	fields := eventToFields(n.Event, timezone)
+	sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+		Title: FieldWhen,
+		Value: strings.Join(fields[FieldWhen].Strings(), ", "),
+		Short: true,
+	})
+	if message := strings.TrimSpace(n.Event.BodyPreview); message != "" {
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: "Message from the organizer",
+			Value: message,
+		})
+	}
+	return sa
+}
+
+// clearStaleActions removes the buttons of the earlier notifications of an
+// event that was cancelled or deleted, since they can no longer be used. The
+// post to be replaced by the caller, if any, is skipped.
+func (processor *notificationProcessor) clearStaleActions(mattermostUserID string, prior *store.Event, status, skipPostID string) {
+	for _, postID := range prior.PostIDs {
+		if postID == skipPostID {
+			continue
+		}
+
+		post, err := processor.PluginAPI.GetPost(postID)
+		if err != nil {
+			processor.Logger.With(bot.LogContext{
+				"MattermostUserID": mattermostUserID,
+				"PostID":           postID,
+			}).Debugf("webhook notification: failed to load a stale notification. err=%v", err)
+			continue
+		}
+
+		sas := post.Attachments()
+		for _, sa := range sas {
+			if len(sa.Actions) == 0 {
+				continue
+			}
+			sa.Actions = nil
+			sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+				Title: "Status",
+				Value: status,
+			})
+		}
+		model.ParseSlackAttachment(post, sas)
+		err = processor.Poster.UpdatePost(post)
+		if err != nil {
+			processor.Logger.With(bot.LogContext{
+				"MattermostUserID": mattermostUserID,
+				"PostID":           postID,
+			}).Warnf("webhook notification: failed to update a stale notification. err=%v", err)
+		}
+	}
+	prior.PostIDs = nil
+}
+
+// processDeletedEvent cleans up what was kept for an event deleted from the
+// creator's calendar: the event record, its notification buttons, snoozed
+// reminders and pending digest entry. The status is synced again when the
+// event made the creator busy.
+func (processor *notificationProcessor) processDeletedEvent(creator *store.User, eventID string) error {
+	log := processor.Logger.With(bot.LogContext{
+		"MattermostUserID": creator.MattermostUserID,
+		"EventID":          eventID,
+	})
+
+	err := processor.Store.DeleteUserSnoozedReminder(creator.MattermostUserID, eventID)
+	if err != nil && err != store.ErrNotFound {
+		log.Warnf("webhook notification: failed to delete the snoozed reminders of a deleted event. err=%v", err)
+	}
+
+	prior, err := processor.Store.LoadUserEventByRemoteID(creator.MattermostUserID, eventID)
+	if err == store.ErrNotFound {
+		log.Debugf("webhook notification: deleted event was not stored.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	processor.clearStaleActions(creator.MattermostUserID, prior, "This event has been deleted.", "")
+	processor.removeFromDigest(creator.MattermostUserID, prior.Remote.ICalUID)
+
+	err = processor.Store.DeleteUserEvent(creator.MattermostUserID, prior.Remote.ICalUID)
+	if err != nil {
+		return err
+	}
+
+	if creator.Settings.UpdateStatus && isActiveEvent(creator.ActiveEvents, prior.Remote.ICalUID) {
+		_, err = New(processor.Env, creator.MattermostUserID).Sync(creator.MattermostUserID)
+		if err != nil {
+			log.Warnf("webhook notification: failed to sync the status after an event was deleted. err=%v", err)
+		}
+	}
+
+	log.Debugf("webhook notification: cleaned up deleted event.")
+	return nil
+}
+
+// removeFromDigest drops the pending digest entry of a deleted event.
+func (processor *notificationProcessor) removeFromDigest(mattermostUserID, iCalUID string) {
+	err := processor.Store.ModifyDigest(mattermostUserID, func(digest *store.Digest) {
+		events := []*store.DigestEvent{}
+		for _, de := range digest.Events {
+			if de.Event.ICalUID != iCalUID {
+				events = append(events, de)
+			}
+		}
+		digest.Events = events
+	})
+	if err != nil {
+		processor.Logger.With(bot.LogContext{
+			"MattermostUserID": mattermostUserID,
+		}).Warnf("webhook notification: failed to remove a deleted event from the digest. err=%v", err)
+	}
+}
+
+// isActiveEvent tells if the status sync counted the event as making the
+// user busy. Active events are kept as "<iCalUID> <start>".
+func isActiveEvent(activeEvents []string, iCalUID string) bool {
+	for _, h := range activeEvents {
+		if strings.HasPrefix(h, fmt.Sprintf("%s ", iCalUID)) {
+			return true
+		}
+	}
+	return false
+}
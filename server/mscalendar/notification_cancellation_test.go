@@ -0,0 +1,141 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+)
+
+func newTestPostWithActions(eventID string) *model.Post {
+	post := &model.Post{Id: "prior_post_id"}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{{
+		Title:   "event_subject",
+		Actions: NewPostActionForEventResponse(eventID, ResponseNone, "url"),
+	}})
+	return post
+}
+
+func TestProcessCancelledEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockRemote := mock_remote.NewMockRemote(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+	mockClient := mock_remote.NewMockClient(ctrl)
+
+	n := newTestNotification("stored_client_state", false)
+	n.IsBare = false
+	n.Event.IsCancelled = true
+	n.Event.Subject = "Canceled: event_subject"
+	n.Event.BodyPreview = "Sorry, something came up."
+
+	mockStore.EXPECT().LoadSubscription("remote_subscription_id").Return(newTestSubscription(), nil)
+	mockStore.EXPECT().LoadUser("creator_mm_id").Return(newTestUser(), nil)
+	mockRemote.EXPECT().MakeClient(context.Background(), &oauth2.Token{AccessToken: "creator_oauth_token"}).Return(mockClient)
+	mockClient.EXPECT().GetMailboxSettings("remote_user_id").Return(&remote.MailboxSettings{TimeZone: "Eastern Standard Time"}, nil)
+	mockStore.EXPECT().LoadUserEvent("creator_mm_id", "remote_event_uid").Return(&store.Event{
+		Remote:  newTestEvent("event_location_display_name", "event_subject"),
+		PostIDs: []string{"prior_post_id"},
+	}, nil)
+
+	mockPluginAPI.EXPECT().GetPost("prior_post_id").Return(newTestPostWithActions("remote_event_id"), nil)
+	mockPoster.EXPECT().UpdatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+		sas := post.Attachments()
+		require.Len(t, sas, 1)
+		require.Empty(t, sas[0].Actions)
+		require.Equal(t, "This event has been cancelled.", sas[0].Fields[0].Value)
+		return nil
+	})
+	mockPoster.EXPECT().DMWithAttachments("creator_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID string, attachments ...*model.SlackAttachment) (string, error) {
+		require.Equal(t, "(cancelled) event_subject", attachments[0].Title)
+		require.Empty(t, attachments[0].Actions)
+		require.Equal(t, "Sorry, something came up.", attachments[0].Fields[1].Value)
+		return "post_id", nil
+	})
+	mockStore.EXPECT().StoreUserEvent("creator_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID string, event *store.Event) error {
+		require.Empty(t, event.PostIDs)
+		require.True(t, event.Remote.IsCancelled)
+		return nil
+	})
+
+	processor := &notificationProcessor{
+		Env: Env{
+			Config: &config.Config{PluginVersion: "x.x.x"},
+			Dependencies: &Dependencies{
+				Store:     mockStore,
+				Logger:    &bot.NilLogger{},
+				Poster:    mockPoster,
+				Remote:    mockRemote,
+				PluginAPI: mockPluginAPI,
+			},
+		},
+	}
+	require.NoError(t, processor.processNotification(n))
+}
+
+func TestProcessDeletedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+	mockStore.EXPECT().DeleteUserSnoozedReminder("creator_mm_id", "remote_event_id").Return(nil)
+	mockStore.EXPECT().LoadUserEventByRemoteID("creator_mm_id", "remote_event_id").Return(&store.Event{
+		Remote:  newTestEvent("event_location_display_name", "event_subject"),
+		PostIDs: []string{"prior_post_id"},
+	}, nil)
+	mockPluginAPI.EXPECT().GetPost("prior_post_id").Return(newTestPostWithActions("remote_event_id"), nil)
+	mockPoster.EXPECT().UpdatePost(gomock.Any()).Return(nil)
+	mockStore.EXPECT().ModifyDigest("creator_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID string, modify func(*store.Digest)) error {
+		digest := &store.Digest{
+			Events: []*store.DigestEvent{
+				{Event: &remote.Event{ICalUID: "remote_event_uid"}},
+				{Event: &remote.Event{ICalUID: "other_uid"}},
+			},
+		}
+		modify(digest)
+		require.Len(t, digest.Events, 1)
+		require.Equal(t, "other_uid", digest.Events[0].Event.ICalUID)
+		return nil
+	})
+	mockStore.EXPECT().DeleteUserEvent("creator_mm_id", "remote_event_uid").Return(nil)
+
+	processor := &notificationProcessor{
+		Env: Env{
+			Config: &config.Config{},
+			Dependencies: &Dependencies{
+				Store:     mockStore,
+				Logger:    &bot.NilLogger{},
+				Poster:    mockPoster,
+				PluginAPI: mockPluginAPI,
+			},
+		},
+	}
+	require.NoError(t, processor.processDeletedEvent(newTestUser(), "remote_event_id"))
+}
+
+func TestIsActiveEvent(t *testing.T) {
+	active := []string{"uid_1 2020-03-11T09:00:00Z", "uid_2 2020-03-11T10:00:00Z"}
+	require.True(t, isActiveEvent(active, "uid_2"))
+	require.False(t, isActiveEvent(active, "uid"))
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+type NotificationFilters interface {
+	GetNotificationFilters(user *User) (*store.NotificationFilters, error)
+	MuteOrganizer(user *User, email string, muted bool) error
+	MuteKeyword(user *User, keyword string, muted bool) error
+	OpenMutedDialog(user *User, triggerID, postID string) error
+	SetMuted(user *User, organizers, keywords []string) error
+}
+
+// The fields of the dialog editing the muted organizers and keywords, which
+// take one value per line.
+const (
+	MutedDialogOrganizersField = "muted_organizers"
+	MutedDialogKeywordsField   = "muted_keywords"
+)
+
+// importantChangeFields are the fields of the changes users can choose to be
+// notified about.
+var importantChangeFields = map[string]string{
+	store.ImportantChangeLocation:  FieldLocation,
+	store.ImportantChangeAttendees: FieldAttendees,
+	store.ImportantChangeBody:      FieldBodyPreview,
+}
+
+func (m *mscalendar) GetNotificationFilters(user *User) (*store.NotificationFilters, error) {
+	err := m.Filter(withRemoteUser(user))
+	if err != nil {
+		return nil, err
+	}
+	if user.Settings.NotificationFilters == nil {
+		return &store.NotificationFilters{}, nil
+	}
+	return user.Settings.NotificationFilters, nil
+}
+
+// MuteOrganizer stops notifications of the events organized by the email
+// address, or resumes them.
+func (m *mscalendar) MuteOrganizer(user *User, email string, muted bool) error {
+	return m.updateMuted(user, func(filters *store.NotificationFilters) error {
+		var err error
+		filters.MutedOrganizers, err = setMuted(filters.MutedOrganizers, strings.ToLower(email), muted)
+		return err
+	})
+}
+
+// MuteKeyword stops notifications of the events with the keyword in their
+// subject, or resumes them.
+func (m *mscalendar) MuteKeyword(user *User, keyword string, muted bool) error {
+	return m.updateMuted(user, func(filters *store.NotificationFilters) error {
+		var err error
+		filters.MutedKeywords, err = setMuted(filters.MutedKeywords, strings.ToLower(keyword), muted)
+		return err
+	})
+}
+
+// OpenMutedDialog opens the dialog to edit the muted organizers and keywords,
+// from the settings panel post postID, which is updated on submit.
+func (m *mscalendar) OpenMutedDialog(user *User, triggerID, postID string) error {
+	filters, err := m.GetNotificationFilters(user)
+	if err != nil {
+		return err
+	}
+
+	return m.PluginAPI.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerID,
+		URL:       m.Config.PluginURL + config.PathDialogs + config.PathEditMuted,
+		Dialog: model.Dialog{
+			Title: "Muted Organizers and Keywords",
+			Elements: []model.DialogElement{
+				{
+					DisplayName: "Organizers",
+					Name:        MutedDialogOrganizersField,
+					Type:        "textarea",
+					Optional:    true,
+					Default:     strings.Join(filters.MutedOrganizers, "\n"),
+					Placeholder: "alice@example.com",
+					HelpText:    "One email address per line.",
+				},
+				{
+					DisplayName: "Keywords",
+					Name:        MutedDialogKeywordsField,
+					Type:        "textarea",
+					Optional:    true,
+					Default:     strings.Join(filters.MutedKeywords, "\n"),
+					Placeholder: "standup",
+					HelpText:    "One keyword per line, matched in the subject of events regardless of case.",
+				},
+			},
+			SubmitLabel: "Save",
+			State:       postID,
+		},
+	})
+}
+
+// SetMuted replaces the muted organizers and keywords.
+func (m *mscalendar) SetMuted(user *User, organizers, keywords []string) error {
+	mutedOrganizers := []string{}
+	for _, email := range organizers {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		if !strings.Contains(email, "@") {
+			return fmt.Errorf("%s is not an email address", email)
+		}
+		mutedOrganizers = appendMuted(mutedOrganizers, email)
+	}
+	mutedKeywords := []string{}
+	for _, keyword := range keywords {
+		mutedKeywords = appendMuted(mutedKeywords, keyword)
+	}
+
+	return m.updateMuted(user, func(filters *store.NotificationFilters) error {
+		filters.MutedOrganizers = nil
+		if len(mutedOrganizers) > 0 {
+			filters.MutedOrganizers = mutedOrganizers
+		}
+		filters.MutedKeywords = nil
+		if len(mutedKeywords) > 0 {
+			filters.MutedKeywords = mutedKeywords
+		}
+		return nil
+	})
+}
+
+// appendMuted adds the value, in lower case, unless it is empty or already
+// there.
+func appendMuted(values []string, value string) []string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func (m *mscalendar) updateMuted(user *User, update func(filters *store.NotificationFilters) error) error {
+	err := m.Filter(withRemoteUser(user))
+	if err != nil {
+		return err
+	}
+
+	if user.Settings.NotificationFilters == nil {
+		user.Settings.NotificationFilters = &store.NotificationFilters{}
+	}
+	err = update(user.Settings.NotificationFilters)
+	if err != nil {
+		return err
+	}
+	return m.Store.StoreUser(user.User)
+}
+
+func setMuted(values []string, value string, muted bool) ([]string, error) {
+	if value == "" {
+		return nil, fmt.Errorf("nothing to mute")
+	}
+	if muted {
+		if containsString(values, value) {
+			return nil, fmt.Errorf("%s is already muted", value)
+		}
+		return append(values, value), nil
+	}
+
+	remaining := []string{}
+	for _, v := range values {
+		if v != value {
+			remaining = append(remaining, v)
+		}
+	}
+	if len(remaining) == len(values) {
+		return nil, fmt.Errorf("%s is not muted", value)
+	}
+	if len(remaining) == 0 {
+		return nil, nil
+	}
+	return remaining, nil
+}
+
+// isNotificationFiltered tells if the user chose not to be notified about
+// the event.
+func isNotificationFiltered(filters *store.NotificationFilters, event *remote.Event) bool {
+	if filters == nil {
+		return false
+	}
+	if filters.HighImportanceOnly && !strings.EqualFold(event.Importance, "high") {
+		return true
+	}
+	if filters.MoreThanAttendees > 0 && len(event.Attendees) <= filters.MoreThanAttendees {
+		return true
+	}
+	if event.Organizer != nil && event.Organizer.EmailAddress != nil &&
+		containsString(filters.MutedOrganizers, strings.ToLower(event.Organizer.EmailAddress.Address)) {
+		return true
+	}
+	subject := strings.ToLower(event.Subject)
+	for _, keyword := range filters.MutedKeywords {
+		if strings.Contains(subject, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// importantChanges are the fields whose changes the user is notified about.
+func importantChanges(filters *store.NotificationFilters) []string {
+	important := append([]string{}, importantNotificationChanges...)
+	if filters == nil {
+		return important
+	}
+	for _, change := range filters.ImportantChanges {
+		if field, ok := importantChangeFields[change]; ok {
+			important = append(important, field)
+		}
+	}
+	return important
+}
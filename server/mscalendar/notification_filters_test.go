@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+func TestIsNotificationFiltered(t *testing.T) {
+	event := &remote.Event{
+		Subject:    "Weekly Standup",
+		Importance: "normal",
+		Organizer:  &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: "Alice@example.com"}},
+		Attendees: []*remote.Attendee{
+			{EmailAddress: &remote.EmailAddress{Address: "bob@example.com"}},
+			{EmailAddress: &remote.EmailAddress{Address: "carol@example.com"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filters  *store.NotificationFilters
+		expected bool
+	}{
+		{name: "No filters", filters: nil, expected: false},
+		{name: "Muted organizer", filters: &store.NotificationFilters{MutedOrganizers: []string{"alice@example.com"}}, expected: true},
+		{name: "Other organizer", filters: &store.NotificationFilters{MutedOrganizers: []string{"bob@example.com"}}, expected: false},
+		{name: "Muted keyword", filters: &store.NotificationFilters{MutedKeywords: []string{"standup"}}, expected: true},
+		{name: "High importance only", filters: &store.NotificationFilters{HighImportanceOnly: true}, expected: true},
+		{name: "Enough attendees", filters: &store.NotificationFilters{MoreThanAttendees: 1}, expected: false},
+		{name: "Too few attendees", filters: &store.NotificationFilters{MoreThanAttendees: 2}, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isNotificationFiltered(tc.filters, event))
+		})
+	}
+}
+
+func TestImportantChanges(t *testing.T) {
+	require.Equal(t, []string{FieldSubject, FieldWhen}, importantChanges(nil))
+	require.Equal(t, []string{FieldSubject, FieldWhen, FieldBodyPreview}, importantChanges(&store.NotificationFilters{
+		ImportantChanges: []string{store.ImportantChangeBody},
+	}))
+}
+
+func TestSetMuted(t *testing.T) {
+	values, err := setMuted(nil, "standup", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"standup"}, values)
+
+	_, err = setMuted(values, "standup", true)
+	require.EqualError(t, err, "standup is already muted")
+
+	values, err = setMuted(values, "standup", false)
+	require.NoError(t, err)
+	require.Nil(t, values)
+
+	_, err = setMuted(values, "standup", false)
+	require.EqualError(t, err, "standup is not muted")
+}
+
+func TestSetMutedFromDialog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			Store:  mockStore,
+			Logger: &bot.NilLogger{},
+		}},
+	}
+
+	user := newTestEventUser()
+	user.Settings.NotificationFilters = &store.NotificationFilters{
+		MutedOrganizers:    []string{"bob@example.com"},
+		MutedKeywords:      []string{"lunch"},
+		HighImportanceOnly: true,
+	}
+	mockStore.EXPECT().StoreUser(user.User).Return(nil)
+	err := m.SetMuted(user, []string{" Alice@example.com", "", "alice@example.com"}, []string{"Standup ", "\r"})
+	require.NoError(t, err)
+	require.Equal(t, &store.NotificationFilters{
+		MutedOrganizers:    []string{"alice@example.com"},
+		MutedKeywords:      []string{"standup"},
+		HighImportanceOnly: true,
+	}, user.Settings.NotificationFilters)
+
+	mockStore.EXPECT().StoreUser(user.User).Return(nil)
+	err = m.SetMuted(user, []string{""}, nil)
+	require.NoError(t, err)
+	require.Nil(t, user.Settings.NotificationFilters.MutedOrganizers)
+	require.Nil(t, user.Settings.NotificationFilters.MutedKeywords)
+
+	err = m.SetMuted(user, []string{"alice"}, nil)
+	require.EqualError(t, err, "alice is not an email address")
+}
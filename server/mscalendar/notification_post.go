@@ -0,0 +1,97 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+// maxChangeHistory is the number of changes listed on the notification of an
+// event, the oldest being dropped first.
+const maxChangeHistory = 10
+
+const FieldChangeHistory = "Change history"
+
+// postNotification updates the last notification of the event in place, or
+// DMs a new one when the event is new or its notification was deleted. The
+// change is added to the history of the event, and replied in the thread of
+// the notification when the creator chose to. It returns the ID of the post.
+func (processor *notificationProcessor) postNotification(creator *store.User, prior *store.Event, sa *model.SlackAttachment, change, timezone string) (string, error) {
+	if change != "" {
+		now := remote.NewDateTime(time.Now().UTC(), "UTC").In(timezone).Time()
+		prior.ChangeHistory = append(prior.ChangeHistory, fmt.Sprintf("%s: %s", now.Format("Jan 2, 3:04PM"), change))
+		if len(prior.ChangeHistory) > maxChangeHistory {
+			prior.ChangeHistory = prior.ChangeHistory[len(prior.ChangeHistory)-maxChangeHistory:]
+		}
+	}
+	if len(prior.ChangeHistory) > 0 {
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: FieldChangeHistory,
+			Value: strings.Join(prior.ChangeHistory, "\n"),
+		})
+	}
+
+	if prior.NotificationPostID != "" && change != "" {
+		postID, err := processor.updateNotificationPost(creator, prior.NotificationPostID, sa, change)
+		if err == nil {
+			return postID, nil
+		}
+		processor.Logger.With(bot.LogContext{
+			"MattermostUserID": creator.MattermostUserID,
+			"PostID":           prior.NotificationPostID,
+		}).Debugf("webhook notification: failed to update the notification in place, posting a new one. err=%v", err)
+	}
+
+	postID, err := processor.Poster.DMWithAttachments(creator.MattermostUserID, sa)
+	if err != nil {
+		return "", err
+	}
+	prior.NotificationPostID = postID
+	return postID, nil
+}
+
+func (processor *notificationProcessor) updateNotificationPost(creator *store.User, postID string, sa *model.SlackAttachment, change string) (string, error) {
+	post, err := processor.PluginAPI.GetPost(postID)
+	if err != nil {
+		return "", err
+	}
+	if post.DeleteAt != 0 {
+		return "", fmt.Errorf("post was deleted")
+	}
+
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{sa})
+	err = processor.Poster.UpdatePost(post)
+	if err != nil {
+		return "", err
+	}
+
+	if creator.Settings.ReplyInNotificationThread {
+		_, err = processor.Poster.PostInChannel(post.ChannelId, post.Id, "**%s**: %s.", sa.Title, change)
+		if err != nil {
+			processor.Logger.With(bot.LogContext{
+				"MattermostUserID": creator.MattermostUserID,
+				"PostID":           post.Id,
+			}).Warnf("webhook notification: failed to reply in the thread of the notification. err=%v", err)
+		}
+	}
+	return post.Id, nil
+}
+
+// changedFields are the titles of the fields of the notification of an
+// updated event.
+func changedFields(sa *model.SlackAttachment) []string {
+	changes := []string{}
+	for _, f := range sa.Fields {
+		changes = append(changes, f.Title)
+	}
+	return changes
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+)
+
+func TestPostNotification(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		prior              *store.Event
+		change             string
+		replyInThread      bool
+		setupMock          func(mockPoster *mock_bot.MockPoster, mockPluginAPI *mock_plugin_api.MockPluginAPI)
+		expectedPostID     string
+		expectedHistoryLen int
+	}{
+		{
+			name:   "new event is DMed",
+			prior:  &store.Event{},
+			change: "",
+			setupMock: func(mockPoster *mock_bot.MockPoster, mockPluginAPI *mock_plugin_api.MockPluginAPI) {
+				mockPoster.EXPECT().DMWithAttachments("creator_mm_id", gomock.Any()).Return("new_post_id", nil)
+			},
+			expectedPostID:     "new_post_id",
+			expectedHistoryLen: 0,
+		},
+		{
+			name:   "update edits the last notification",
+			prior:  &store.Event{NotificationPostID: "prior_post_id", ChangeHistory: []string{"Jan 2, 3:04PM: Changed Location"}},
+			change: "Changed When",
+			setupMock: func(mockPoster *mock_bot.MockPoster, mockPluginAPI *mock_plugin_api.MockPluginAPI) {
+				mockPluginAPI.EXPECT().GetPost("prior_post_id").Return(&model.Post{Id: "prior_post_id", ChannelId: "dm_channel_id"}, nil)
+				mockPoster.EXPECT().UpdatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+					sas := post.Attachments()
+					require.Len(t, sas, 1)
+					history := sas[0].Fields[len(sas[0].Fields)-1]
+					require.Equal(t, FieldChangeHistory, history.Title)
+					require.Contains(t, history.Value, "Changed Location\n")
+					require.Contains(t, history.Value, "Changed When")
+					return nil
+				})
+			},
+			expectedPostID:     "prior_post_id",
+			expectedHistoryLen: 2,
+		},
+		{
+			name:          "update replies in the thread",
+			prior:         &store.Event{NotificationPostID: "prior_post_id"},
+			change:        "Changed When",
+			replyInThread: true,
+			setupMock: func(mockPoster *mock_bot.MockPoster, mockPluginAPI *mock_plugin_api.MockPluginAPI) {
+				mockPluginAPI.EXPECT().GetPost("prior_post_id").Return(&model.Post{Id: "prior_post_id", ChannelId: "dm_channel_id"}, nil)
+				mockPoster.EXPECT().UpdatePost(gomock.Any()).Return(nil)
+				mockPoster.EXPECT().PostInChannel("dm_channel_id", "prior_post_id", "**%s**: %s.", "(updated) event_subject", "Changed When").Return("reply_id", nil)
+			},
+			expectedPostID:     "prior_post_id",
+			expectedHistoryLen: 1,
+		},
+		{
+			name:   "deleted notification is posted again",
+			prior:  &store.Event{NotificationPostID: "prior_post_id"},
+			change: "Changed When",
+			setupMock: func(mockPoster *mock_bot.MockPoster, mockPluginAPI *mock_plugin_api.MockPluginAPI) {
+				mockPluginAPI.EXPECT().GetPost("prior_post_id").Return(nil, errors.New("not found"))
+				mockPoster.EXPECT().DMWithAttachments("creator_mm_id", gomock.Any()).Return("new_post_id", nil)
+			},
+			expectedPostID:     "new_post_id",
+			expectedHistoryLen: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPoster := mock_bot.NewMockPoster(ctrl)
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+			tc.setupMock(mockPoster, mockPluginAPI)
+
+			processor := &notificationProcessor{
+				Env: Env{
+					Config: &config.Config{},
+					Dependencies: &Dependencies{
+						Logger:    &bot.NilLogger{},
+						Poster:    mockPoster,
+						PluginAPI: mockPluginAPI,
+					},
+				},
+			}
+
+			creator := newTestUser()
+			creator.Settings.ReplyInNotificationThread = tc.replyInThread
+			sa := &model.SlackAttachment{Title: "(updated) event_subject"}
+
+			postID, err := processor.postNotification(creator, tc.prior, sa, tc.change, "Eastern Standard Time")
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPostID, postID)
+			require.Equal(t, tc.expectedPostID, tc.prior.NotificationPostID)
+			require.Len(t, tc.prior.ChangeHistory, tc.expectedHistoryLen)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// The kinds of online meetings that can be added to the events created from
+// Mattermost.
+const (
+	OnlineMeetingNone   = ""
+	OnlineMeetingTeams  = "teams"
+	OnlineMeetingCalls  = "calls"
+	OnlineMeetingCustom = "custom"
+)
+
+const teamsOnlineMeetingProvider = "teamsForBusiness"
+
+var joinURLPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`https://teams\.microsoft\.com/l/meetup-join/[^\s"'<>]+`),
+	regexp.MustCompile(`https://(?:[\w-]+\.)*zoom\.us/(?:j|my|w)/[^\s"'<>]+`),
+}
+
+// AddOnlineMeeting adds an online meeting to the event before it is created.
+// Teams meetings are created by Microsoft, which adds the join link to the
+// invitation. Calls meetings link to the call of the channel, and custom meetings to the
+// link configured by the admin; these links are added to the body of the event.
+func (m *mscalendar) AddOnlineMeeting(user *User, event *remote.Event, meetingType, channelID string) error {
+	switch meetingType {
+	case OnlineMeetingNone:
+		return nil
+	case OnlineMeetingTeams:
+		event.IsOnlineMeeting = true
+		event.OnlineMeetingProvider = teamsOnlineMeetingProvider
+		return nil
+	case OnlineMeetingCalls:
+		link, err := m.callLink(channelID)
+		if err != nil {
+			return err
+		}
+		appendJoinLink(event, link)
+		return nil
+	case OnlineMeetingCustom:
+		if m.Config.MeetingURLTemplate == "" {
+			return errors.New("custom meetings are not configured, please contact your system administrator")
+		}
+		err := m.Filter(withUserExpanded(user))
+		if err != nil {
+			return err
+		}
+		link := strings.ReplaceAll(m.Config.MeetingURLTemplate, "{random}", model.NewId())
+		link = strings.ReplaceAll(link, "{username}", user.MattermostUser.Username)
+		appendJoinLink(event, link)
+		return nil
+	default:
+		return errors.Errorf("%q is not a kind of online meeting, please use %s, %s or %s", meetingType, OnlineMeetingTeams, OnlineMeetingCalls, OnlineMeetingCustom)
+	}
+}
+
+// callLink is the link to the call of the channel. It goes through the
+// plugin, which redirects to the channel, so that it is not mistaken for any
+// other link to a channel.
+func (m *mscalendar) callLink(channelID string) (string, error) {
+	channel, err := m.PluginAPI.GetMattermostChannel(channelID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the channel")
+	}
+	if channel.TeamId == "" {
+		return "", errors.New("a call link can only be added from a channel of a team")
+	}
+	return fmt.Sprintf("%s%s/%s", m.Config.PluginURL, config.PathJoinCall, channelID), nil
+}
+
+func appendJoinLink(event *remote.Event, link string) {
+	if event.Body == nil {
+		event.Body = &remote.ItemBody{ContentType: "text"}
+	}
+	if strings.EqualFold(event.Body.ContentType, "html") {
+		event.Body.Content += fmt.Sprintf(`<p>Join the meeting: <a href="%s">%[1]s</a></p>`, html.EscapeString(link))
+		return
+	}
+	if event.Body.Content != "" {
+		event.Body.Content += "\n\n"
+	}
+	event.Body.Content += "Join the meeting: " + link
+}
+
+// getJoinURL finds the link to join an online meeting. The link provided by
+// the online meeting provider is preferred, then any Teams, Zoom, Mattermost
+// call or custom meeting link found in the location or body of the event.
+func getJoinURL(event *remote.Event, pluginURL, meetingURLTemplate string) string {
+	if event.OnlineMeeting != nil && event.OnlineMeeting.JoinURL != "" {
+		return event.OnlineMeeting.JoinURL
+	}
+	if event.OnlineMeetingURL != "" {
+		return event.OnlineMeetingURL
+	}
+
+	patterns := joinURLPatterns
+	if pluginURL != "" {
+		callsPattern := regexp.MustCompile(regexp.QuoteMeta(pluginURL+config.PathJoinCall) + `/\w+`)
+		patterns = append([]*regexp.Regexp{callsPattern}, patterns...)
+	}
+	if prefix := strings.SplitN(meetingURLTemplate, "{", 2)[0]; strings.Contains(prefix, "://") {
+		patterns = append(patterns, regexp.MustCompile(regexp.QuoteMeta(prefix)+`[^\s"'<>]*`))
+	}
+
+	texts := []string{}
+	if event.Location != nil {
+		texts = append(texts, event.Location.DisplayName)
+	}
+	if event.Body != nil {
+		texts = append(texts, event.Body.Content)
+	}
+
+	for _, text := range texts {
+		for _, pattern := range patterns {
+			if found := pattern.FindString(text); found != "" {
+				return strings.ReplaceAll(found, "&amp;", "&")
+			}
+		}
+	}
+	return ""
+}
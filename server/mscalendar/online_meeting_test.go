@@ -0,0 +1,143 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+func TestGetJoinURL(t *testing.T) {
+	for name, tc := range map[string]struct {
+		event    *remote.Event
+		expected string
+	}{
+		"No online meeting": {
+			event:    &remote.Event{Location: &remote.Location{DisplayName: "Room 1"}, Body: &remote.ItemBody{Content: "Agenda"}},
+			expected: "",
+		},
+		"Online meeting join URL is preferred": {
+			event: &remote.Event{
+				OnlineMeeting: &remote.OnlineMeetingInfo{JoinURL: "https://teams.microsoft.com/l/meetup-join/abc"},
+				Body:          &remote.ItemBody{Content: "https://zoom.us/j/123"},
+			},
+			expected: "https://teams.microsoft.com/l/meetup-join/abc",
+		},
+		"Zoom link in location": {
+			event:    &remote.Event{Location: &remote.Location{DisplayName: "https://company.zoom.us/j/123?pwd=abc"}},
+			expected: "https://company.zoom.us/j/123?pwd=abc",
+		},
+		"Teams link in HTML body": {
+			event:    &remote.Event{Body: &remote.ItemBody{Content: `<a href="https://teams.microsoft.com/l/meetup-join/abc?context=1&amp;x=2">Join</a>`}},
+			expected: "https://teams.microsoft.com/l/meetup-join/abc?context=1&x=2",
+		},
+		"Mattermost call link in body": {
+			event:    &remote.Event{Body: &remote.ItemBody{Content: "Join the meeting: https://mattermost.example.com/plugins/mscalendar/call/channel_id"}},
+			expected: "https://mattermost.example.com/plugins/mscalendar/call/channel_id",
+		},
+		"Permalink to a channel is not a call link": {
+			event:    &remote.Event{Body: &remote.ItemBody{Content: "Notes in https://mattermost.example.com/team/channels/town-square"}},
+			expected: "",
+		},
+		"Custom meeting link in body": {
+			event:    &remote.Event{Body: &remote.ItemBody{Content: "Join the meeting: https://meet.example.com/abc123"}},
+			expected: "https://meet.example.com/abc123",
+		},
+		"Call link of another Mattermost server is ignored": {
+			event:    &remote.Event{Body: &remote.ItemBody{Content: "https://other.example.com/plugins/mscalendar/call/channel_id"}},
+			expected: "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, getJoinURL(tc.event, "https://mattermost.example.com/plugins/mscalendar", "https://meet.example.com/{random}"))
+		})
+	}
+}
+
+func TestAddOnlineMeeting(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meetingType   string
+		template      string
+		body          *remote.ItemBody
+		expected      *remote.Event
+		err           string
+		runAssertions func(api *mock_plugin_api.MockPluginAPI)
+	}{
+		"None": {
+			meetingType: OnlineMeetingNone,
+			expected:    &remote.Event{},
+		},
+		"Teams": {
+			meetingType: OnlineMeetingTeams,
+			expected:    &remote.Event{IsOnlineMeeting: true, OnlineMeetingProvider: "teamsForBusiness"},
+		},
+		"Calls link of the channel": {
+			meetingType: OnlineMeetingCalls,
+			body:        &remote.ItemBody{Content: "Agenda", ContentType: "text"},
+			expected:    &remote.Event{Body: &remote.ItemBody{Content: "Agenda\n\nJoin the meeting: https://mattermost.example.com/plugins/mscalendar/call/channel_id", ContentType: "text"}},
+			runAssertions: func(api *mock_plugin_api.MockPluginAPI) {
+				api.EXPECT().GetMattermostChannel("channel_id").Return(&model.Channel{Name: "town-square", TeamId: "team_id"}, nil)
+			},
+		},
+		"Calls link from a direct message": {
+			meetingType: OnlineMeetingCalls,
+			err:         "a call link can only be added from a channel of a team",
+			runAssertions: func(api *mock_plugin_api.MockPluginAPI) {
+				api.EXPECT().GetMattermostChannel("channel_id").Return(&model.Channel{Name: "dm"}, nil)
+			},
+		},
+		"Custom link": {
+			meetingType: OnlineMeetingCustom,
+			template:    "https://meet.example.com/{username}",
+			body:        &remote.ItemBody{Content: "<p>Agenda</p>", ContentType: "HTML"},
+			expected:    &remote.Event{Body: &remote.ItemBody{Content: `<p>Agenda</p><p>Join the meeting: <a href="https://meet.example.com/user">https://meet.example.com/user</a></p>`, ContentType: "HTML"}},
+		},
+		"Custom link not configured": {
+			meetingType: OnlineMeetingCustom,
+			err:         "custom meetings are not configured, please contact your system administrator",
+		},
+		"Unknown": {
+			meetingType: "skype",
+			err:         `"skype" is not a kind of online meeting, please use teams, calls or custom`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+			if tc.runAssertions != nil {
+				tc.runAssertions(mockPluginAPI)
+			}
+
+			conf := &config.Config{MattermostSiteURL: "https://mattermost.example.com/"}
+			conf.PluginURL = "https://mattermost.example.com/plugins/mscalendar"
+			conf.MeetingURLTemplate = tc.template
+			m := &mscalendar{
+				Env: Env{
+					Config:       conf,
+					Dependencies: &Dependencies{PluginAPI: mockPluginAPI},
+				},
+			}
+			user := newTestEventUser()
+			user.MattermostUser.Username = "user"
+
+			event := &remote.Event{Body: tc.body}
+			err := m.AddOnlineMeeting(user, event, tc.meetingType, "channel_id")
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, event)
+		})
+	}
+}
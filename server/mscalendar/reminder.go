@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+const ReminderSnoozeDuration = 5 * time.Minute
+
+type Reminders interface {
+	SnoozeReminder(user *User, eventID string) (time.Time, error)
+	DismissReminder(user *User, eventID string) error
+}
+
+func (m *mscalendar) SnoozeReminder(user *User, eventID string) (time.Time, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	remindAt := time.Now().Add(ReminderSnoozeDuration)
+	err = m.Store.StoreUserSnoozedReminder(user.MattermostUserID, &store.SnoozedReminder{
+		Event:    event,
+		RemindAt: remindAt,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return remindAt, nil
+}
+
+func (m *mscalendar) DismissReminder(user *User, eventID string) error {
+	return m.Store.DeleteUserSnoozedReminder(user.MattermostUserID, eventID)
+}
+
+// deliverSnoozedReminders posts the snoozed reminders that are due by the
+// next status sync.
+func (m *mscalendar) deliverSnoozedReminders(user *store.User) {
+	if !user.Settings.ReceiveReminders {
+		return
+	}
+
+	reminders, err := m.Store.LoadUserSnoozedReminders(user.MattermostUserID)
+	if err == store.ErrNotFound {
+		return
+	}
+	if err != nil {
+		m.Logger.Warnf("deliverSnoozedReminders error loading snoozed reminders. err=%v", err)
+		return
+	}
+
+	var timezone string
+	due := time.Now().Add(StatusSyncJobInterval / 2)
+	for _, r := range reminders {
+		if r.RemindAt.After(due) {
+			continue
+		}
+
+		if timezone == "" {
+			timezone, err = m.GetTimezoneByID(user.MattermostUserID)
+			if err != nil {
+				m.Logger.Warnf("deliverSnoozedReminders error getting timezone. err=%v", err)
+				return
+			}
+		}
+
+		err = m.Store.DeleteUserSnoozedReminder(user.MattermostUserID, r.Event.ID)
+		if err != nil {
+			m.Logger.Warnf("deliverSnoozedReminders error deleting snoozed reminder. err=%v", err)
+			continue
+		}
+
+		err = m.postReminder(user.MattermostUserID, r.Event, timezone)
+		if err != nil {
+			m.Logger.Warnf("deliverSnoozedReminders error posting reminder. err=%v", err)
+		}
+	}
+}
+
+func (m *mscalendar) postReminder(mattermostUserID string, event *remote.Event, timezone string) error {
+	joinURL := getJoinURL(event, m.Config.PluginURL, m.Config.MeetingURLTemplate)
+	sa, err := views.RenderUpcomingEventAttachment(event, timezone, joinURL)
+	if err != nil {
+		return err
+	}
+
+	sa.Actions = m.newPostActionsForReminder(event.ID)
+	_, err = m.Poster.DMWithAttachments(mattermostUserID, sa)
+	return err
+}
+
+// newPostActionsForReminder are the actions of a reminder. The link to join
+// the meeting is on the attachment, as post actions can't open links.
+func (m *mscalendar) newPostActionsForReminder(eventID string) []*model.PostAction {
+	return []*model.PostAction{{
+		Name: fmt.Sprintf("Snooze %d min", int(ReminderSnoozeDuration.Minutes())),
+		Integration: &model.PostActionIntegration{
+			URL: m.actionURL(config.PathSnoozeReminder),
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+			},
+		},
+	}, {
+		Name: "Dismiss",
+		Integration: &model.PostActionIntegration{
+			URL: m.actionURL(config.PathDismissReminder),
+			Context: map[string]interface{}{
+				config.EventIDKey: eventID,
+			},
+		},
+	}}
+}
+
+func (m *mscalendar) actionURL(action string) string {
+	return fmt.Sprintf("%s%s%s", m.Config.PluginURLPath, config.PathPostAction, action)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// roomScheduleInterval is the granularity, in minutes, of the room schedules.
+const roomScheduleInterval = 15
+
+type Rooms interface {
+	FindFreeRooms(user *User, building string, start, end time.Time, minCapacity int) ([]*remote.Room, error)
+	GetRoomByName(user *User, name string) (*remote.Room, error)
+	BookRoom(user *User, eventID, roomName string) (*remote.Event, error)
+}
+
+// FindFreeRooms lists the rooms free from start to end, with at least
+// minCapacity seats. The building matches either the name of a room list or
+// the building of the rooms, ignoring case.
+func (m *mscalendar) FindFreeRooms(user *User, building string, start, end time.Time, minCapacity int) ([]*remote.Room, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := m.getBuildingRooms(building)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []*remote.Room{}
+	emails := []string{}
+	for _, room := range rooms {
+		if room.EmailAddress == "" || room.Capacity < minCapacity {
+			continue
+		}
+		candidates = append(candidates, room)
+		emails = append(emails, room.EmailAddress)
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	schedules, err := m.client.GetRoomsSchedule(user.Remote.ID, emails, remote.NewDateTime(start.UTC(), "UTC"), remote.NewDateTime(end.UTC(), "UTC"), roomScheduleInterval)
+	if err != nil {
+		return nil, err
+	}
+	free := map[string]bool{}
+	for _, s := range schedules {
+		if s.Error == nil && isFreeAvailabilityView(s.AvailabilityView) {
+			free[strings.ToLower(s.ScheduleID)] = true
+		}
+	}
+
+	freeRooms := []*remote.Room{}
+	for _, room := range candidates {
+		if free[strings.ToLower(room.EmailAddress)] {
+			freeRooms = append(freeRooms, room)
+		}
+	}
+	sort.Slice(freeRooms, func(i, j int) bool {
+		if freeRooms[i].Capacity != freeRooms[j].Capacity {
+			return freeRooms[i].Capacity < freeRooms[j].Capacity
+		}
+		return freeRooms[i].DisplayName < freeRooms[j].DisplayName
+	})
+	return freeRooms, nil
+}
+
+// GetRoomByName finds the room by display name or email address, ignoring
+// case.
+func (m *mscalendar) GetRoomByName(user *User, name string) (*remote.Room, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := m.client.GetRooms("")
+	if err != nil {
+		return nil, err
+	}
+	for _, room := range rooms {
+		if strings.EqualFold(room.DisplayName, name) || strings.EqualFold(room.EmailAddress, name) {
+			return room, nil
+		}
+	}
+	return nil, errors.Errorf("room %q was not found", name)
+}
+
+// BookRoom invites the room to the event, and makes it the location of the
+// event.
+func (m *mscalendar) BookRoom(user *User, eventID, roomName string) (*remote.Event, error) {
+	room, err := m.GetRoomByName(user, roomName)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsOrganizer {
+		return nil, errors.New("only the organizer can book a room for the event")
+	}
+
+	update := &remote.Event{
+		ID:        event.ID,
+		Attendees: event.Attendees,
+	}
+	AddRoomToEvent(update, room)
+	return m.client.UpdateEvent(user.Remote.ID, update)
+}
+
+// AddRoomToEvent invites the room to the event as a resource, and makes it
+// the location of the event.
+func AddRoomToEvent(event *remote.Event, room *remote.Room) {
+	event.Location = &remote.Location{
+		DisplayName:          room.DisplayName,
+		LocationEmailAddress: room.EmailAddress,
+		LocationType:         "conferenceRoom",
+	}
+
+	for _, a := range event.Attendees {
+		if a.EmailAddress != nil && strings.EqualFold(a.EmailAddress.Address, room.EmailAddress) {
+			return
+		}
+	}
+	event.Attendees = append(event.Attendees, &remote.Attendee{
+		Type: "resource",
+		EmailAddress: &remote.EmailAddress{
+			Address: room.EmailAddress,
+			Name:    room.DisplayName,
+		},
+	})
+}
+
+func (m *mscalendar) getBuildingRooms(building string) ([]*remote.Room, error) {
+	if building == "" {
+		return m.client.GetRooms("")
+	}
+
+	roomLists, err := m.client.GetRoomLists()
+	if err != nil {
+		return nil, err
+	}
+	for _, roomList := range roomLists {
+		if strings.EqualFold(roomList.DisplayName, building) || strings.EqualFold(roomList.EmailAddress, building) {
+			return m.client.GetRooms(roomList.EmailAddress)
+		}
+	}
+
+	rooms, err := m.client.GetRooms("")
+	if err != nil {
+		return nil, err
+	}
+	inBuilding := []*remote.Room{}
+	for _, room := range rooms {
+		if strings.EqualFold(room.Building, building) {
+			inBuilding = append(inBuilding, room)
+		}
+	}
+	return inBuilding, nil
+}
+
+func isFreeAvailabilityView(view remote.AvailabilityView) bool {
+	if view == "" {
+		return false
+	}
+	for _, c := range view {
+		if c != remote.AvailabilityViewFree {
+			return false
+		}
+	}
+	return true
+}
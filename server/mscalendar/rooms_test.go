@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+)
+
+func TestFindFreeRooms(t *testing.T) {
+	start := time.Date(2020, 2, 12, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	small := &remote.Room{ID: "small", DisplayName: "Small", EmailAddress: "small@example.com", Capacity: 4, Building: "HQ"}
+	large := &remote.Room{ID: "large", DisplayName: "Large", EmailAddress: "large@example.com", Capacity: 12, Building: "HQ"}
+	busy := &remote.Room{ID: "busy", DisplayName: "Busy", EmailAddress: "busy@example.com", Capacity: 8, Building: "HQ"}
+	annex := &remote.Room{ID: "annex", DisplayName: "Annex", EmailAddress: "annex@example.com", Capacity: 8, Building: "Annex"}
+	schedules := []*remote.ScheduleInformation{
+		{ScheduleID: "Small@example.com", AvailabilityView: "0000"},
+		{ScheduleID: "large@example.com", AvailabilityView: "0000"},
+		{ScheduleID: "busy@example.com", AvailabilityView: "0020"},
+		{ScheduleID: "annex@example.com", AvailabilityView: "0000"},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		building      string
+		capacity      int
+		expected      []*remote.Room
+		runAssertions func(client *mock_remote.MockClient)
+	}{
+		{
+			name:     "All free rooms, smallest first",
+			expected: []*remote.Room{small, annex, large},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().GetRooms("").Return([]*remote.Room{large, busy, small, annex}, nil)
+				client.EXPECT().GetRoomsSchedule("user_remote_id", []string{"large@example.com", "busy@example.com", "small@example.com", "annex@example.com"}, gomock.Any(), gomock.Any(), roomScheduleInterval).Return(schedules, nil)
+			},
+		},
+		{
+			name:     "Room list and capacity",
+			building: "headquarters",
+			capacity: 6,
+			expected: []*remote.Room{large},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().GetRoomLists().Return([]*remote.RoomList{{DisplayName: "Headquarters", EmailAddress: "hq@example.com"}}, nil)
+				client.EXPECT().GetRooms("hq@example.com").Return([]*remote.Room{large, busy, small}, nil)
+				client.EXPECT().GetRoomsSchedule("user_remote_id", []string{"large@example.com", "busy@example.com"}, gomock.Any(), gomock.Any(), roomScheduleInterval).Return(schedules, nil)
+			},
+		},
+		{
+			name:     "Building of the rooms",
+			building: "annex",
+			expected: []*remote.Room{annex},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().GetRoomLists().Return([]*remote.RoomList{}, nil)
+				client.EXPECT().GetRooms("").Return([]*remote.Room{large, annex}, nil)
+				client.EXPECT().GetRoomsSchedule("user_remote_id", []string{"annex@example.com"}, gomock.Any(), gomock.Any(), roomScheduleInterval).Return(schedules, nil)
+			},
+		},
+		{
+			name:     "No room large enough",
+			capacity: 20,
+			expected: []*remote.Room{},
+			runAssertions: func(client *mock_remote.MockClient) {
+				client.EXPECT().GetRooms("").Return([]*remote.Room{large, small}, nil)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			tc.runAssertions(mockClient)
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{}},
+				client: mockClient,
+			}
+
+			rooms, err := m.FindFreeRooms(newTestEventUser(), tc.building, start, end, tc.capacity)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rooms)
+		})
+	}
+}
+
+func TestAddRoomToEvent(t *testing.T) {
+	room := &remote.Room{DisplayName: "Large", EmailAddress: "large@example.com"}
+	event := &remote.Event{}
+
+	AddRoomToEvent(event, room)
+	AddRoomToEvent(event, room)
+
+	require.Equal(t, "large@example.com", event.Location.LocationEmailAddress)
+	require.Len(t, event.Attendees, 1)
+	require.Equal(t, "resource", event.Attendees[0].Type)
+}
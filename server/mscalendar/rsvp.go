@@ -0,0 +1,276 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+type RSVPTracker interface {
+	NudgeNonResponders(user *User, eventID string) (int, error)
+}
+
+// rsvpGroups are the groups of attendees shown by the RSVP tracker, in order.
+var rsvpGroups = []struct {
+	title     string
+	responses []string
+}{
+	{"Accepted", []string{ResponseYes}},
+	{"Tentative", []string{ResponseMaybe}},
+	{"Declined", []string{ResponseNo}},
+	{"Not responded", []string{ResponseNone, "none", ""}},
+}
+
+// rsvpAttendees are the attendees whose responses are tracked: people, not
+// rooms and equipment, other than the organizer.
+func rsvpAttendees(event *remote.Event) []*remote.Attendee {
+	organizer := ""
+	if event.Organizer != nil && event.Organizer.EmailAddress != nil {
+		organizer = strings.ToLower(event.Organizer.EmailAddress.Address)
+	}
+
+	attendees := []*remote.Attendee{}
+	for _, a := range event.Attendees {
+		if a.Type == "resource" || a.EmailAddress == nil || strings.ToLower(a.EmailAddress.Address) == organizer {
+			continue
+		}
+		attendees = append(attendees, a)
+	}
+	return attendees
+}
+
+func attendeeResponse(a *remote.Attendee) string {
+	if a.Status == nil {
+		return ""
+	}
+	return a.Status.Response
+}
+
+func isNonResponder(a *remote.Attendee) bool {
+	switch attendeeResponse(a) {
+	case ResponseNone, "none", "":
+		return true
+	}
+	return false
+}
+
+// rsvpResponses maps the addresses of the attendees to their responses.
+func rsvpResponses(event *remote.Event) map[string]string {
+	responses := map[string]string{}
+	if event == nil {
+		return responses
+	}
+	for _, a := range rsvpAttendees(event) {
+		responses[strings.ToLower(a.EmailAddress.Address)] = attendeeResponse(a)
+	}
+	return responses
+}
+
+func responsesChanged(prior, current map[string]string) bool {
+	if len(prior) != len(current) {
+		return true
+	}
+	for address, response := range current {
+		if r, ok := prior[address]; !ok || r != response {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRSVPTrackerAttachment shows the responses of the attendees of an event
+// the user organized, with a button to nudge those who have not responded.
+func NewRSVPTrackerAttachment(event *remote.Event, timezone, nudgeURL string) *model.SlackAttachment {
+	attendees := rsvpAttendees(event)
+	title := "RSVPs: " + views.EnsureSubject(event.Subject)
+
+	counts := []string{}
+	sa := &model.SlackAttachment{
+		Title:     title,
+		TitleLink: event.Weblink,
+		Fallback:  title,
+	}
+	nonResponders := 0
+	for _, group := range rsvpGroups {
+		names := []string{}
+		for _, a := range attendees {
+			if containsString(group.responses, attendeeResponse(a)) {
+				names = append(names, attendeeName(a))
+			}
+		}
+		counts = append(counts, fmt.Sprintf("**%d** %s", len(names), strings.ToLower(group.title)))
+		if group.title == "Not responded" {
+			nonResponders = len(names)
+		}
+		if len(names) == 0 {
+			continue
+		}
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: group.title,
+			Value: strings.Join(names, ", "),
+			Short: true,
+		})
+	}
+
+	sa.Text = strings.Join(counts, " · ")
+	if event.Start != nil {
+		start := event.Start.In(timezone).Time()
+		sa.Text = start.Format("Monday, January 02 · "+time.Kitchen) + "\n" + sa.Text
+	}
+
+	if nonResponders > 0 {
+		sa.Actions = []*model.PostAction{{
+			Name: "Nudge non-responders",
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL: nudgeURL,
+				Context: map[string]interface{}{
+					config.EventIDKey: event.ID,
+				},
+			},
+		}}
+	}
+	return sa
+}
+
+func attendeeName(a *remote.Attendee) string {
+	if a.EmailAddress.Name != "" {
+		return a.EmailAddress.Name
+	}
+	return a.EmailAddress.Address
+}
+
+// updateRSVPTracker posts the RSVP tracker of an event the creator organized
+// when the responses of its attendees change, or updates it in place when it
+// was posted before. It tells if the tracker changed.
+func (processor *notificationProcessor) updateRSVPTracker(creator *store.User, event *remote.Event, prior *store.Event, timezone string) bool {
+	if !event.IsOrganizer || event.IsCancelled || prior == nil || len(rsvpAttendees(event)) == 0 {
+		return false
+	}
+	if !responsesChanged(rsvpResponses(prior.Remote), rsvpResponses(event)) {
+		return false
+	}
+
+	log := processor.Logger.With(bot.LogContext{
+		"MattermostUserID": creator.MattermostUserID,
+		"EventID":          event.ID,
+	})
+	sa := NewRSVPTrackerAttachment(event, timezone, processor.actionURL(config.PathNudgeAttendees))
+	if prior.RSVPTrackerPostID != "" {
+		post, err := processor.PluginAPI.GetPost(prior.RSVPTrackerPostID)
+		if err == nil {
+			model.ParseSlackAttachment(post, []*model.SlackAttachment{sa})
+			err = processor.Poster.UpdatePost(post)
+			if err == nil {
+				return true
+			}
+		}
+		log.Warnf("webhook notification: failed to update the RSVP tracker, posting a new one. err=%v", err)
+	}
+
+	postID, err := processor.Poster.DMWithAttachments(creator.MattermostUserID, sa)
+	if err != nil {
+		log.Warnf("webhook notification: failed to post the RSVP tracker. err=%v", err)
+		return false
+	}
+	prior.RSVPTrackerPostID = postID
+	return true
+}
+
+// NudgeNonResponders reminds the connected attendees who have not responded
+// to an event the user organized, with a selector to respond to their copy
+// of the invitation when it can be found. It returns the number of attendees
+// reminded.
+func (m *mscalendar) NudgeNonResponders(user *User, eventID string) (int, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	event, err := m.client.GetEvent(user.Remote.ID, eventID)
+	if err != nil {
+		return 0, err
+	}
+	if !event.IsOrganizer {
+		return 0, fmt.Errorf("only the organizer can nudge the attendees")
+	}
+
+	index, err := m.Store.LoadUserIndex()
+	if err != nil {
+		return 0, err
+	}
+	byEmail := map[string]*store.UserShort{}
+	for _, u := range index {
+		byEmail[strings.ToLower(u.Email)] = u
+	}
+
+	timezone, _ := m.GetTimezone(user)
+	when := ""
+	if event.Start != nil {
+		when = " on " + event.Start.In(timezone).Time().Format("Monday, January 02 · "+time.Kitchen+" MST")
+	}
+	link, err := views.RenderEventLink(event)
+	if err != nil {
+		link = views.EnsureSubject(event.Subject)
+	}
+
+	nudged := 0
+	for _, a := range rsvpAttendees(event) {
+		if !isNonResponder(a) {
+			continue
+		}
+		u := byEmail[strings.ToLower(a.EmailAddress.Address)]
+		if u == nil || u.MattermostUserID == user.MattermostUserID {
+			continue
+		}
+
+		sa := &model.SlackAttachment{
+			Title:    "Please respond",
+			Text:     fmt.Sprintf("%s is waiting for your response to %s%s.", user.Markdown(), link, when),
+			Fallback: fmt.Sprintf("%s is waiting for your response to %s.", user.Markdown(), views.EnsureSubject(event.Subject)),
+		}
+		if attendeeEventID := m.findAttendeeEventID(u.MattermostUserID, event.ICalUID); attendeeEventID != "" {
+			sa.Actions = NewPostActionForEventResponse(attendeeEventID, ResponseNone, m.actionURL(config.PathRespond))
+		}
+		_, err = m.Poster.DMWithAttachments(u.MattermostUserID, sa)
+		if err != nil {
+			m.Logger.Warnf("Failed to nudge %s. err=%v", u.MattermostUserID, err)
+			continue
+		}
+		nudged++
+	}
+	return nudged, nil
+}
+
+// findAttendeeEventID finds the attendee's copy of the event, which has an
+// ID of its own.
+func (m *mscalendar) findAttendeeEventID(mattermostUserID, iCalUID string) string {
+	if iCalUID == "" {
+		return ""
+	}
+	attendee, err := m.Store.LoadUser(mattermostUserID)
+	if err != nil || attendee.Remote == nil || attendee.OAuth2Token == nil {
+		return ""
+	}
+	client := m.Remote.MakeClient(context.Background(), attendee.OAuth2Token)
+	events, err := client.GetEventsByICalUID(attendee.Remote.ID, iCalUID)
+	if err != nil || len(events) == 0 {
+		return ""
+	}
+	return events[0].ID
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+)
+
+func newTestRSVPEvent() *remote.Event {
+	attendee := func(name, address, response string) *remote.Attendee {
+		return &remote.Attendee{
+			Type:         "required",
+			EmailAddress: &remote.EmailAddress{Name: name, Address: address},
+			Status:       &remote.EventResponseStatus{Response: response},
+		}
+	}
+	return &remote.Event{
+		ID:          "event_id",
+		ICalUID:     "ical_uid",
+		Subject:     "Planning",
+		IsOrganizer: true,
+		Organizer:   &remote.Attendee{EmailAddress: &remote.EmailAddress{Address: "organizer@example.com"}},
+		Attendees: []*remote.Attendee{
+			attendee("Organizer", "organizer@example.com", ResponseYes),
+			attendee("Alice", "alice@example.com", ResponseYes),
+			attendee("Bob", "Bob@example.com", ResponseNone),
+			attendee("", "carol@example.com", ResponseNone),
+			attendee("Dan", "dan@example.com", ResponseNo),
+			{Type: "resource", EmailAddress: &remote.EmailAddress{Name: "Room 1", Address: "room@example.com"}},
+		},
+	}
+}
+
+func TestNewRSVPTrackerAttachment(t *testing.T) {
+	event := newTestRSVPEvent()
+	sa := NewRSVPTrackerAttachment(event, "UTC", "nudge_url")
+	require.Equal(t, "RSVPs: Planning", sa.Title)
+	require.Equal(t, "**1** accepted · **0** tentative · **1** declined · **2** not responded", sa.Text)
+	require.Equal(t, []*model.SlackAttachmentField{
+		{Title: "Accepted", Value: "Alice", Short: true},
+		{Title: "Declined", Value: "Dan", Short: true},
+		{Title: "Not responded", Value: "Bob, carol@example.com", Short: true},
+	}, sa.Fields)
+	require.Len(t, sa.Actions, 1)
+	require.Equal(t, "event_id", sa.Actions[0].Integration.Context[config.EventIDKey])
+
+	for _, a := range event.Attendees {
+		a.Status = &remote.EventResponseStatus{Response: ResponseYes}
+	}
+	sa = NewRSVPTrackerAttachment(event, "UTC", "nudge_url")
+	require.Empty(t, sa.Actions)
+}
+
+func TestNudgeNonResponders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_remote.NewMockClient(ctrl)
+	mockAttendeeClient := mock_remote.NewMockClient(ctrl)
+	mockRemote := mock_remote.NewMockRemote(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockLogger := mock_bot.NewMockLogger(ctrl)
+
+	token := &oauth2.Token{AccessToken: "bob_token"}
+	mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(newTestRSVPEvent(), nil)
+	mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+	mockStore.EXPECT().LoadUserIndex().Return(store.UserIndex{
+		{MattermostUserID: "user_mm_id", Email: "organizer@example.com"},
+		{MattermostUserID: "alice_mm_id", Email: "alice@example.com"},
+		{MattermostUserID: "bob_mm_id", Email: "bob@example.com"},
+	}, nil)
+	mockStore.EXPECT().LoadUser("bob_mm_id").Return(&store.User{Remote: &remote.User{ID: "bob_remote_id"}, OAuth2Token: token}, nil)
+	mockRemote.EXPECT().MakeClient(context.Background(), token).Return(mockAttendeeClient)
+	mockAttendeeClient.EXPECT().GetEventsByICalUID("bob_remote_id", "ical_uid").Return([]*remote.Event{{ID: "bob_event_id"}}, nil)
+	mockPoster.EXPECT().DMWithAttachments("bob_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID string, attachments ...*model.SlackAttachment) (string, error) {
+		require.Len(t, attachments, 1)
+		require.NotEmpty(t, attachments[0].Actions)
+		require.Equal(t, "bob_event_id", attachments[0].Actions[0].Integration.Context[config.EventIDKey])
+		return "post_id", nil
+	})
+
+	m := &mscalendar{
+		Env: Env{
+			Config: &config.Config{PluginURLPath: "/plugins/mscalendar"},
+			Dependencies: &Dependencies{
+				Store:  mockStore,
+				Remote: mockRemote,
+				Poster: mockPoster,
+				Logger: mockLogger,
+			},
+		},
+		client: mockClient,
+	}
+	nudged, err := m.NudgeNonResponders(newTestEventUser(), "event_id")
+	require.NoError(t, err)
+	require.Equal(t, 1, nudged)
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+const (
+	EventDialogAttendeesField     = "attendees"
+	EventDialogOnlineMeetingField = "online_meeting"
+)
+
+// maxSubjectLength is the longest subject taken from the first line of a post.
+const maxSubjectLength = 255
+
+// OpenCreateEventFromPostDialog opens the dialog to schedule a meeting about
+// a post, pre-filled with its first line as subject and the connected users
+// participating in its thread as attendees.
+func (m *mscalendar) OpenCreateEventFromPostDialog(user *User, triggerID, postID string) error {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return err
+	}
+
+	post, err := m.getReadablePost(user, postID)
+	if err != nil {
+		return err
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return err
+	}
+
+	usernames := []string{}
+	for _, mattermostUserID := range m.threadParticipants(post) {
+		if mattermostUserID == user.MattermostUserID {
+			continue
+		}
+		_, err = m.Store.LoadUser(mattermostUserID)
+		if err != nil {
+			continue
+		}
+		participant, err := m.PluginAPI.GetMattermostUser(mattermostUserID)
+		if err != nil || participant.IsBot {
+			continue
+		}
+		usernames = append(usernames, "@"+participant.Username)
+	}
+
+	start := nextHalfHour(time.Now(), timezone)
+	helpText := eventTimeHelp(timezone)
+	elements := []model.DialogElement{
+		{
+			DisplayName: "Subject",
+			Name:        EventDialogSubjectField,
+			Type:        "text",
+			Default:     subjectFromMessage(post.Message),
+		},
+		{
+			DisplayName: "Start",
+			Name:        EventDialogStartField,
+			Type:        "text",
+			Default:     start.Format(EventTimeLayout),
+			HelpText:    helpText,
+		},
+		{
+			DisplayName: "End",
+			Name:        EventDialogEndField,
+			Type:        "text",
+			Default:     start.Add(30 * time.Minute).Format(EventTimeLayout),
+			HelpText:    helpText,
+		},
+		{
+			DisplayName: "Location",
+			Name:        EventDialogLocationField,
+			Type:        "text",
+			Optional:    true,
+		},
+		{
+			DisplayName: "Attendees",
+			Name:        EventDialogAttendeesField,
+			Type:        "text",
+			Optional:    true,
+			Default:     strings.Join(usernames, " "),
+			HelpText:    "Usernames of the connected users to invite, separated by spaces.",
+		},
+		m.onlineMeetingDialogElement(),
+	}
+
+	return m.openEventDialog(triggerID, config.PathCreateEventFromPost, "Schedule meeting", "Create", elements, &EventDialogState{
+		PostID: postID,
+	})
+}
+
+// getReadablePost gets the post, if the user can read its channel.
+func (m *mscalendar) getReadablePost(user *User, postID string) (*model.Post, error) {
+	post, err := m.PluginAPI.GetPost(postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the post")
+	}
+	if !m.PluginAPI.HasPermissionToChannel(user.MattermostUserID, post.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		return nil, errors.New("you do not have access to the post")
+	}
+	return post, nil
+}
+
+// CreateEventFromPost creates the event with a link back to the post and an
+// online meeting of meetingType, invites the connected users among
+// mattermostUserIDs, and replies into the thread of the post.
+func (m *mscalendar) CreateEventFromPost(user *User, postID string, event *remote.Event, mattermostUserIDs []string, meetingType string) (*remote.Event, error) {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := m.getReadablePost(user, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	permalink := fmt.Sprintf("%s/_redirect/pl/%s", m.Config.MattermostSiteURL, post.Id)
+	event.Body = &remote.ItemBody{
+		Content:     fmt.Sprintf("Scheduled from Mattermost: %s\n\n%s", permalink, post.Message),
+		ContentType: "text",
+	}
+	err = m.AddOnlineMeeting(user, event, meetingType, post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mattermostUserID := range mattermostUserIDs {
+		attendee, err := m.Store.LoadUser(mattermostUserID)
+		if err != nil || attendee.Remote == nil || attendee.Remote.Mail == "" {
+			continue
+		}
+		event.Attendees = append(event.Attendees, &remote.Attendee{
+			Type: "required",
+			EmailAddress: &remote.EmailAddress{
+				Address: attendee.Remote.Mail,
+				Name:    attendee.Remote.DisplayName,
+			},
+		})
+	}
+
+	created, err := m.CreateEvent(user, event, mattermostUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone, _ := m.GetTimezone(user)
+	link, err := views.RenderEventLink(created)
+	if err != nil {
+		link = created.Subject
+	}
+	start := created.Start.In(timezone).Time()
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+	message := fmt.Sprintf("@%s scheduled %s for %s.", user.MattermostUser.Username, link, start.Format("Monday, January 02 · "+time.Kitchen))
+	if joinURL := getJoinURL(created, m.Config.PluginURL, m.Config.MeetingURLTemplate); joinURL != "" {
+		message += fmt.Sprintf(" [Join the meeting](%s)", joinURL)
+	}
+	_, err = m.Poster.PostInChannel(post.ChannelId, rootID, "%s", message)
+	if err != nil {
+		m.Logger.Warnf("Failed to reply to the post the event was scheduled from. err=%v", err)
+	}
+	return created, nil
+}
+
+// onlineMeetingDialogElement lets the user add an online meeting to the
+// event, offering custom meetings only when the admin configured them.
+func (m *mscalendar) onlineMeetingDialogElement() model.DialogElement {
+	options := []*model.PostActionOptions{
+		{Text: "Microsoft Teams", Value: OnlineMeetingTeams},
+		{Text: "Call in this channel", Value: OnlineMeetingCalls},
+	}
+	if m.Config.MeetingURLTemplate != "" {
+		options = append(options, &model.PostActionOptions{Text: "Custom meeting link", Value: OnlineMeetingCustom})
+	}
+
+	return model.DialogElement{
+		DisplayName: "Online meeting",
+		Name:        EventDialogOnlineMeetingField,
+		Type:        "select",
+		Optional:    true,
+		Options:     options,
+		HelpText:    "Add a link to join the meeting online.",
+	}
+}
+
+// threadParticipants returns the authors of the posts in the thread of post,
+// in the order they first posted.
+func (m *mscalendar) threadParticipants(post *model.Post) []string {
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+
+	participants := []string{post.UserId}
+	thread, err := m.PluginAPI.GetPostThread(rootID)
+	if err != nil {
+		m.Logger.Warnf("Failed to get the thread of post %s. err=%v", post.Id, err)
+		return participants
+	}
+
+	thread.SortByCreateAt()
+	seen := map[string]bool{post.UserId: true}
+	for i := len(thread.Order) - 1; i >= 0; i-- {
+		p := thread.Posts[thread.Order[i]]
+		if p == nil || seen[p.UserId] {
+			continue
+		}
+		seen[p.UserId] = true
+		participants = append(participants, p.UserId)
+	}
+	return participants
+}
+
+func subjectFromMessage(message string) string {
+	subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0])
+	subject = strings.TrimLeft(subject, "#> ")
+	if runes := []rune(subject); len(runes) > maxSubjectLength {
+		subject = strings.TrimSpace(string(runes[:maxSubjectLength]))
+	}
+	return subject
+}
+
+// nextHalfHour returns the next half hour after now, in the time zone.
+func nextHalfHour(now time.Time, timezone string) time.Time {
+	t := remote.NewDateTime(now.UTC(), "UTC").In(timezone).Time()
+	return t.Truncate(30 * time.Minute).Add(30 * time.Minute)
+}
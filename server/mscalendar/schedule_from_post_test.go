@@ -0,0 +1,38 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+func TestScheduleFromPostWithoutChannelAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+	mockPluginAPI.EXPECT().GetPost("post_id").Return(&model.Post{Id: "post_id", ChannelId: "channel_id", Message: "Secret"}, nil).Times(2)
+	mockPluginAPI.EXPECT().HasPermissionToChannel("user_mm_id", "channel_id", model.PERMISSION_READ_CHANNEL).Return(false).Times(2)
+
+	m := &mscalendar{
+		Env: Env{Dependencies: &Dependencies{
+			PluginAPI: mockPluginAPI,
+			Logger:    &bot.NilLogger{},
+		}},
+	}
+
+	err := m.OpenCreateEventFromPostDialog(newTestEventUser(), "trigger_id", "post_id")
+	require.EqualError(t, err, "you do not have access to the post")
+
+	_, err = m.CreateEventFromPost(newTestEventUser(), "post_id", &remote.Event{Subject: "Secret"}, nil, "")
+	require.EqualError(t, err, "you do not have access to the post")
+}
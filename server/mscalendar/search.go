@@ -0,0 +1,138 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// SearchPageSize is the number of events in a page of search results.
+const SearchPageSize = 10
+
+// SearchIncompleteNote is added to the results of a search over a range with
+// too many events to all be searched.
+const SearchIncompleteNote = "The search range has too many events to search them all, so results may be incomplete. Narrow it with `--from` and `--to`."
+
+// Keys of the context of the search pagination buttons.
+const (
+	SearchQueryKey = "query"
+	SearchFromKey  = "from"
+	SearchToKey    = "to"
+	SearchPageKey  = "page"
+)
+
+type Search interface {
+	SearchEvents(user *User, query string, from, to time.Time, page int) (*SearchResults, error)
+	NewSearchResultsAttachment(results *SearchResults, timezone string) (*model.SlackAttachment, error)
+	PostSearchResults(user *User, results *SearchResults, timezone string) error
+}
+
+// SearchResults is a page of the events matching Query. Incomplete tells
+// that only the first events of the range were searched.
+type SearchResults struct {
+	Query      string
+	From       time.Time
+	To         time.Time
+	Page       int
+	Events     []*remote.Event
+	HasMore    bool
+	Incomplete bool
+}
+
+func (m *mscalendar) SearchEvents(user *User, query string, from, to time.Time, page int) (*SearchResults, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// One more event than the page tells if there is a next page
+	events, incomplete, err := m.client.SearchEvents(user.Remote.ID, &remote.SearchEventsParams{
+		Query:     query,
+		StartTime: from,
+		EndTime:   to,
+		Top:       SearchPageSize + 1,
+		Skip:      page * SearchPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := &SearchResults{
+		Query:      query,
+		From:       from,
+		To:         to,
+		Page:       page,
+		Events:     events,
+		Incomplete: incomplete,
+	}
+	if len(events) > SearchPageSize {
+		results.Events = events[:SearchPageSize]
+		results.HasMore = true
+	}
+	return results, nil
+}
+
+// NewSearchResultsAttachment renders a page of results, with buttons to move
+// to the previous and next pages.
+func (m *mscalendar) NewSearchResultsAttachment(results *SearchResults, timezone string) (*model.SlackAttachment, error) {
+	text, err := views.RenderCalendarView(results.Events, timezone)
+	if err != nil {
+		return nil, err
+	}
+	if results.Incomplete {
+		text += "\n" + SearchIncompleteNote
+	}
+	title := fmt.Sprintf("Events matching \"%s\" (page %d)", results.Query, results.Page+1)
+
+	action := func(name string, page int) *model.PostAction {
+		return &model.PostAction{
+			Name: name,
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL: m.actionURL(config.PathSearchEvents),
+				Context: map[string]interface{}{
+					SearchQueryKey: results.Query,
+					SearchFromKey:  results.From.Format(time.RFC3339),
+					SearchToKey:    results.To.Format(time.RFC3339),
+					SearchPageKey:  page,
+				},
+			},
+		}
+	}
+	actions := []*model.PostAction{}
+	if results.Page > 0 {
+		actions = append(actions, action("Previous", results.Page-1))
+	}
+	if results.HasMore {
+		actions = append(actions, action("Next", results.Page+1))
+	}
+
+	return &model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Fallback: title,
+		Actions:  actions,
+	}, nil
+}
+
+// PostSearchResults sends the page of results to the user in a direct message,
+// where the pagination buttons can update it.
+func (m *mscalendar) PostSearchResults(user *User, results *SearchResults, timezone string) error {
+	sa, err := m.NewSearchResultsAttachment(results, timezone)
+	if err != nil {
+		return err
+	}
+	_, err = m.Poster.DMWithAttachments(user.MattermostUserID, sa)
+	return err
+}
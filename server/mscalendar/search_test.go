@@ -0,0 +1,67 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+)
+
+func TestSearchEvents(t *testing.T) {
+	from := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	newEvents := func(n int) []*remote.Event {
+		events := []*remote.Event{}
+		for i := 0; i < n; i++ {
+			events = append(events, &remote.Event{ID: string(rune('a' + i))})
+		}
+		return events
+	}
+
+	for _, tc := range []struct {
+		name          string
+		page          int
+		found         int
+		expectedSkip  int
+		expectedCount int
+		hasMore       bool
+		incomplete    bool
+	}{
+		{name: "Single page", found: 3, expectedCount: 3},
+		{name: "Full last page", page: 1, found: SearchPageSize, expectedSkip: SearchPageSize, expectedCount: SearchPageSize},
+		{name: "More pages", page: 2, found: SearchPageSize + 1, expectedSkip: 2 * SearchPageSize, expectedCount: SearchPageSize, hasMore: true},
+		{name: "Incomplete", found: 3, expectedCount: 3, incomplete: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_remote.NewMockClient(ctrl)
+			mockClient.EXPECT().SearchEvents("user_remote_id", &remote.SearchEventsParams{
+				Query:     "planning",
+				StartTime: from,
+				EndTime:   to,
+				Top:       SearchPageSize + 1,
+				Skip:      tc.expectedSkip,
+			}).Return(newEvents(tc.found), tc.incomplete, nil)
+
+			m := &mscalendar{
+				Env:    Env{Dependencies: &Dependencies{}},
+				client: mockClient,
+			}
+			results, err := m.SearchEvents(newTestEventUser(), "planning", from, to, tc.page)
+			require.NoError(t, err)
+			require.Equal(t, tc.page, results.Page)
+			require.Len(t, results.Events, tc.expectedCount)
+			require.Equal(t, tc.hasMore, results.HasMore)
+			require.Equal(t, tc.incomplete, results.Incomplete)
+		})
+	}
+}
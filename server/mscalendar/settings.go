@@ -9,6 +9,7 @@ import (
 type Settings interface {
 	PrintSettings(userID string)
 	ClearSettingsPosts(userID string)
+	UpdateSettingsPost(userID, postID string)
 }
 
 func (m *mscalendar) PrintSettings(userID string) {
@@ -22,6 +23,21 @@ func (m *mscalendar) ClearSettingsPosts(userID string) {
 	}
 }
 
+// UpdateSettingsPost refreshes the settings panel post after a setting was
+// changed outside of it, like in a dialog.
+func (m *mscalendar) UpdateSettingsPost(userID, postID string) {
+	post, err := m.SettingsPanel.ToPost(userID)
+	if err != nil {
+		m.Logger.Warnf("Error creating the settings post. err=%v", err)
+		return
+	}
+	post.Id = postID
+	err = m.Poster.UpdatePost(post)
+	if err != nil {
+		m.Logger.Warnf("Error updating the settings post. err=%v", err)
+	}
+}
+
 func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingStore settingspanel.SettingStore, settingsHandler, pluginURL string, getCal func(userID string) MSCalendar) settingspanel.Panel {
 	settings := []settingspanel.Setting{}
 	settings = append(settings, settingspanel.NewBoolSetting(
@@ -45,6 +61,7 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		store.UpdateStatusSettingID,
 		settingStore,
 	))
+	settings = append(settings, NewCalendarsSetting(settingStore, getCal))
 	settings = append(settings, settingspanel.NewBoolSetting(
 		store.ReceiveRemindersSettingID,
 		"Receive Reminders",
@@ -52,6 +69,14 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		"",
 		settingStore,
 	))
+	settings = append(settings, settingspanel.NewOptionSetting(
+		store.ReminderLeadTimeSettingID,
+		"Reminder Time",
+		"How long before an event starts do you want to be reminded?\nChoose \"Use Outlook's reminder\" to follow the reminder set on each event in Outlook.",
+		store.ReceiveRemindersSettingID,
+		store.ReminderLeadTimeOptions,
+		settingStore,
+	))
 	settings = append(settings, settingspanel.NewBoolSetting(
 		store.AutoRespondSettingID,
 		"Auto Respond",
@@ -66,10 +91,71 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		"",
 		settingStore,
 	))
+	settings = append(settings, settingspanel.NewOptionSetting(
+		store.DailyFocusHoursSettingID,
+		"Daily Focus Time",
+		"How many hours of focus time do you want booked in your calendar every working day?\nThey are booked each morning in your free time, in blocks of at least an hour. You are set to \"Do Not Disturb\" and DMs are answered automatically during focus time.",
+		"",
+		store.DailyFocusHoursOptions,
+		settingStore,
+	))
 	settings = append(settings, NewNotificationsSetting(getCal))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.HighImportanceOnlySettingID,
+		"Only High Importance Events",
+		"Do you want to be notified only about events marked as high importance?",
+		store.NotificationsSettingID,
+		settingStore,
+	))
+	settings = append(settings, settingspanel.NewOptionSetting(
+		store.MoreThanAttendeesSettingID,
+		"Number of Attendees",
+		"How many attendees must an event have for you to be notified about it?",
+		store.NotificationsSettingID,
+		store.MoreThanAttendeesOptions,
+		settingStore,
+	))
+	settings = append(settings, NewImportantChangesSetting(settingStore))
+	settings = append(settings, settingspanel.NewOptionSetting(
+		store.NotificationDigestSettingID,
+		"Notification Digest",
+		"Do you want to receive the notifications of events together, in a digest?\nChanges to the time of events starting within 2 hours are still sent immediately.",
+		store.NotificationsSettingID,
+		store.NotificationDigestOptions,
+		settingStore,
+	))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.NotificationThreadSettingID,
+		"Reply in Thread on Updates",
+		"Notifications of events are updated in place when the events change. Do you also want a reply in their thread, for the change to show as unread?",
+		store.NotificationsSettingID,
+		settingStore,
+	))
+	settings = append(settings, NewMutedNotificationsSetting(settingStore, pluginURL))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.OfferICSImportSettingID,
+		"Calendar Files",
+		"Do you want to be offered, in a DM, to add the events of the calendar files you post in channels to your calendar? Calendar files sent to the bot are always offered.",
+		"",
+		settingStore,
+	))
 	settings = append(settings, NewDailySummarySetting(
 		settingStore,
 		func(userID string) (string, error) { return getCal(userID).GetTimezone(NewUser(userID)) },
 	))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.WeeklySummarySettingID,
+		"Weekly Summary",
+		"Do you want to receive a summary of your week on Mondays, at the time of your daily summary?",
+		"",
+		settingStore,
+	))
+	settings = append(settings, settingspanel.NewBoolSetting(
+		store.NextWeekPreviewSettingID,
+		"Next Week Preview",
+		"Do you want to receive a preview of the next week on Fridays, at the time of your daily summary?",
+		"",
+		settingStore,
+	))
 	return settingspanel.NewSettingsPanel(settings, bot, bot, panelStore, settingsHandler, pluginURL)
 }
@@ -0,0 +1,131 @@
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/settingspanel"
+)
+
+type calendarsSetting struct {
+	title        string
+	description  string
+	id           string
+	dependsOn    string
+	settingStore settingspanel.SettingStore
+	getCal       func(string) MSCalendar
+}
+
+// NewCalendarsSetting lets the user choose the calendars that count for the
+// status, reminders and summaries. Selecting a calendar adds it, or removes it
+// when already selected.
+func NewCalendarsSetting(settingStore settingspanel.SettingStore, getCal func(string) MSCalendar) settingspanel.Setting {
+	return &calendarsSetting{
+		title:        "Calendars",
+		description:  "Which calendars do you want to use for your status, reminders and summaries?\nSelect a calendar to add it, or to remove it if it is already used.",
+		id:           store.CalendarsSettingID,
+		dependsOn:    "",
+		settingStore: settingStore,
+		getCal:       getCal,
+	}
+}
+
+func (s *calendarsSetting) Set(userID string, value interface{}) error {
+	return s.settingStore.SetSetting(userID, s.id, value)
+}
+
+func (s *calendarsSetting) Get(userID string) (interface{}, error) {
+	return s.settingStore.GetSetting(userID, s.id)
+}
+
+func (s *calendarsSetting) GetID() string {
+	return s.id
+}
+
+func (s *calendarsSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *calendarsSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *calendarsSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *calendarsSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("Setting: %s", s.title)
+	currentValueMessage := "Disabled"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		value, err := s.Get(userID)
+		if err != nil {
+			return nil, err
+		}
+		selected, _ := value.([]string)
+
+		// The selection can still be reset when the calendars can't be listed
+		calendars, err := s.getCal(userID).GetCalendars(NewUser(userID))
+		if err != nil {
+			calendars = nil
+		}
+
+		isSelected := map[string]bool{}
+		for _, id := range selected {
+			isSelected[id] = true
+		}
+		names := []string{}
+		options := []*model.PostActionOptions{{
+			Text:  "Only the default calendar",
+			Value: store.DefaultCalendarsOption,
+		}}
+		for _, cal := range calendars {
+			text := cal.Name
+			if isSelected[cal.ID] {
+				names = append(names, cal.Name)
+				text = "✓ " + cal.Name
+			}
+			options = append(options, &model.PostActionOptions{
+				Text:  text,
+				Value: cal.ID,
+			})
+		}
+
+		currentTextValue := "Default calendar"
+		if len(names) > 0 {
+			currentTextValue = strings.Join(names, ", ")
+		}
+		currentValueMessage = fmt.Sprintf("Current value: %s", currentTextValue)
+
+		actions = []*model.PostAction{{
+			Name: "Add or remove a calendar:",
+			Integration: &model.PostActionIntegration{
+				URL: settingHandler,
+				Context: map[string]interface{}{
+					settingspanel.ContextIDKey: s.id,
+				},
+			},
+			Type:    model.POST_ACTION_TYPE_SELECT,
+			Options: options,
+		}}
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *calendarsSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
@@ -0,0 +1,114 @@
+package mscalendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/settingspanel"
+)
+
+type importantChangesSetting struct {
+	title        string
+	description  string
+	id           string
+	dependsOn    string
+	settingStore settingspanel.SettingStore
+}
+
+// NewImportantChangesSetting lets the user choose the changes of events they
+// are notified about, on top of the subject and time. Selecting a change adds
+// it, or removes it when already selected.
+func NewImportantChangesSetting(settingStore settingspanel.SettingStore) settingspanel.Setting {
+	return &importantChangesSetting{
+		title:        "Notify on Changes Of",
+		description:  "Besides the subject and time, which changes to events do you want to be notified about?\nSelect a change to add it, or to remove it if it is already notified.",
+		id:           store.ImportantChangesSettingID,
+		dependsOn:    store.NotificationsSettingID,
+		settingStore: settingStore,
+	}
+}
+
+func (s *importantChangesSetting) Set(userID string, value interface{}) error {
+	return s.settingStore.SetSetting(userID, s.id, value)
+}
+
+func (s *importantChangesSetting) Get(userID string) (interface{}, error) {
+	return s.settingStore.GetSetting(userID, s.id)
+}
+
+func (s *importantChangesSetting) GetID() string {
+	return s.id
+}
+
+func (s *importantChangesSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *importantChangesSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *importantChangesSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *importantChangesSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("Setting: %s", s.title)
+	currentValueMessage := "Disabled"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		value, err := s.Get(userID)
+		if err != nil {
+			return nil, err
+		}
+		selected, _ := value.([]string)
+
+		options := []*model.PostActionOptions{}
+		for _, change := range store.ImportantChangeOptions {
+			text := change
+			if containsString(selected, change) {
+				text = "✓ " + change
+			}
+			options = append(options, &model.PostActionOptions{
+				Text:  text,
+				Value: change,
+			})
+		}
+
+		currentTextValue := "Subject and time only"
+		if len(selected) > 0 {
+			currentTextValue = "Subject, time, " + strings.Join(selected, ", ")
+		}
+		currentValueMessage = fmt.Sprintf("Current value: %s", currentTextValue)
+
+		actions = []*model.PostAction{{
+			Name: "Add or remove a change:",
+			Integration: &model.PostActionIntegration{
+				URL: settingHandler,
+				Context: map[string]interface{}{
+					settingspanel.ContextIDKey: s.id,
+				},
+			},
+			Type:    model.POST_ACTION_TYPE_SELECT,
+			Options: options,
+		}}
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *importantChangesSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
@@ -0,0 +1,95 @@
+package mscalendar
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/settingspanel"
+)
+
+type mutedNotificationsSetting struct {
+	title        string
+	description  string
+	id           string
+	dependsOn    string
+	editURL      string
+	settingStore settingspanel.SettingStore
+}
+
+// NewMutedNotificationsSetting shows the muted organizers and keywords, with
+// a button opening a dialog to edit them.
+func NewMutedNotificationsSetting(settingStore settingspanel.SettingStore, pluginURL string) settingspanel.Setting {
+	return &mutedNotificationsSetting{
+		title:        "Muted Organizers and Keywords",
+		description:  "Which organizers, or keywords in the subject of events, do you want to stop being notified about?\nYou can also use `/mscalendar mute` and `/mscalendar unmute`.",
+		id:           store.MutedNotificationsSettingID,
+		dependsOn:    store.NotificationsSettingID,
+		editURL:      pluginURL + config.PathPostAction + config.PathEditMuted,
+		settingStore: settingStore,
+	}
+}
+
+// Set does nothing, since the muted organizers and keywords are submitted in
+// a dialog.
+func (s *mutedNotificationsSetting) Set(userID string, value interface{}) error {
+	return nil
+}
+
+func (s *mutedNotificationsSetting) Get(userID string) (interface{}, error) {
+	return s.settingStore.GetSetting(userID, s.id)
+}
+
+func (s *mutedNotificationsSetting) GetID() string {
+	return s.id
+}
+
+func (s *mutedNotificationsSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *mutedNotificationsSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *mutedNotificationsSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *mutedNotificationsSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("Setting: %s", s.title)
+	currentValueMessage := "Disabled"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		value, err := s.Get(userID)
+		if err != nil {
+			return nil, err
+		}
+		currentValueMessage = fmt.Sprintf("Current value: %s", value)
+
+		actions = []*model.PostAction{{
+			Name: "Edit",
+			Type: model.POST_ACTION_TYPE_BUTTON,
+			Integration: &model.PostActionIntegration{
+				URL: s.editURL,
+			},
+		}}
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *mutedNotificationsSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
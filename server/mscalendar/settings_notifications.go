@@ -5,6 +5,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/v5/model"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/settingspanel"
 )
 
@@ -20,7 +21,7 @@ func NewNotificationsSetting(getCal func(string) MSCalendar) settingspanel.Setti
 	return &notificationSetting{
 		title:       "Receive notifications of new events",
 		description: "Do you want to subscribe to new events and receive a message when they are created?",
-		id:          "new_or_updated_event_setting",
+		id:          store.NotificationsSettingID,
 		dependsOn:   "",
 		getCal:      getCal,
 	}
@@ -4,11 +4,24 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/mattermost/mattermost-server/v5/model"
+
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 )
 
+var attendeeResponses = []struct {
+	response string
+	pretty   string
+}{
+	{"accepted", "accepted"},
+	{"tentativelyAccepted", "tentative"},
+	{"declined", "declined"},
+	{"notResponded", "not responded"},
+}
+
 func RenderCalendarView(events []*remote.Event, timeZone string) (string, error) {
 	if len(events) == 0 {
 		return "You have no upcoming events.", nil
@@ -41,6 +54,50 @@ func RenderCalendarView(events []*remote.Event, timeZone string) (string, error)
 	return resp, nil
 }
 
+// RenderDailySummary renders the calendar view of the day, followed by the
+// conflicts between the events.
+func RenderDailySummary(events []*remote.Event, timeZone string) (string, error) {
+	resp, err := RenderCalendarView(events, timeZone)
+	if err != nil {
+		return "", err
+	}
+
+	conflicts, err := renderConflicts(remote.FindConflicts(events), time.Kitchen)
+	if err != nil {
+		return "", err
+	}
+	return resp + conflicts, nil
+}
+
+func renderConflicts(conflicts []*remote.EventConflict, timeFormat string) (string, error) {
+	if len(conflicts) == 0 {
+		return "", nil
+	}
+
+	resp := "\n\n**Conflicts**"
+	for _, c := range conflicts {
+		event, err := RenderEventLink(c.Event)
+		if err != nil {
+			return "", err
+		}
+		with, err := RenderEventLink(c.With)
+		if err != nil {
+			return "", err
+		}
+		resp += fmt.Sprintf("\n- %s: %s overlaps with %s", c.With.Start.Time().Format(timeFormat), event, with)
+	}
+	return resp, nil
+}
+
+// RenderEventLink renders the subject of the event as a link to the event.
+func RenderEventLink(event *remote.Event) (string, error) {
+	link, err := url.QueryUnescape(event.Weblink)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("[%s](%s)", EnsureSubject(event.Subject), link), nil
+}
+
 func renderTableHeader() string {
 	return `| Time | Subject |
 | :--: | :-- |`
@@ -52,7 +109,7 @@ func renderEvent(event *remote.Event, asRow bool, timeZone string) (string, erro
 
 	format := "(%s - %s) [%s](%s)"
 	if asRow {
-		format = "| %s - %s | [%s](%s) |"
+		format = "| %s - %s | [%s](%s)%s |"
 	}
 
 	link, err := url.QueryUnescape(event.Weblink)
@@ -62,9 +119,56 @@ func renderEvent(event *remote.Event, asRow bool, timeZone string) (string, erro
 
 	subject := EnsureSubject(event.Subject)
 
+	if asRow {
+		return fmt.Sprintf(format, start, end, subject, link, renderEventDetails(event)), nil
+	}
 	return fmt.Sprintf(format, start, end, subject, link), nil
 }
 
+// renderEventDetails renders the availability, location and online meeting
+// link shown after the subject in the calendar view.
+func renderEventDetails(event *remote.Event) string {
+	details := []string{}
+	if availability := prettyAvailability(event); availability != "" {
+		details = append(details, "_"+availability+"_")
+	}
+	if event.Location != nil && event.Location.DisplayName != "" {
+		details = append(details, strings.ReplaceAll(event.Location.DisplayName, "|", "\\|"))
+	}
+	if joinURL := onlineMeetingURL(event); joinURL != "" {
+		details = append(details, fmt.Sprintf("[Join](%s)", joinURL))
+	}
+
+	if len(details) == 0 {
+		return ""
+	}
+	return " · " + strings.Join(details, " · ")
+}
+
+func prettyAvailability(event *remote.Event) string {
+	if event.ResponseStatus != nil && event.ResponseStatus.Response == "declined" {
+		return "declined"
+	}
+	switch event.ShowAs {
+	case "tentative":
+		return "tentative"
+	case "free":
+		return "free"
+	case "oof":
+		return "out of office"
+	case "workingElsewhere":
+		return "working elsewhere"
+	}
+	return ""
+}
+
+func onlineMeetingURL(event *remote.Event) string {
+	if event.OnlineMeeting != nil && event.OnlineMeeting.JoinURL != "" {
+		return event.OnlineMeeting.JoinURL
+	}
+	return event.OnlineMeetingURL
+}
+
 func groupEventsByDate(events []*remote.Event) [][]*remote.Event {
 	groups := map[string][]*remote.Event{}
 
@@ -102,6 +206,86 @@ func RenderUpcomingEvent(event *remote.Event, timeZone string) (string, error) {
 	return message + eventString, nil
 }
 
+// RenderUpcomingEventAttachment renders the reminder of an upcoming event.
+// joinURL is shown as a link to join the meeting when not empty.
+func RenderUpcomingEventAttachment(event *remote.Event, timeZone, joinURL string) (*model.SlackAttachment, error) {
+	fallback, err := RenderUpcomingEvent(event, timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := url.QueryUnescape(event.Weblink)
+	if err != nil {
+		return nil, err
+	}
+
+	pretext := "You have an upcoming event:"
+	if event.Start.Time().Before(time.Now()) {
+		pretext = "You have an ongoing event:"
+	}
+
+	start := event.Start.In(timeZone).Time().Format(time.Kitchen)
+	end := event.End.In(timeZone).Time().Format(time.Kitchen)
+	sa := &model.SlackAttachment{
+		Pretext:   pretext,
+		Title:     EnsureSubject(event.Subject),
+		TitleLink: link,
+		Fallback:  fallback,
+		Fields: []*model.SlackAttachmentField{{
+			Title: "When",
+			Value: start + " - " + end,
+			Short: true,
+		}},
+	}
+
+	if event.Location != nil && event.Location.DisplayName != "" {
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: "Location",
+			Value: event.Location.DisplayName,
+			Short: true,
+		})
+	}
+
+	if joinURL != "" {
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: "Online meeting",
+			Value: fmt.Sprintf("[Join meeting](%s)", joinURL),
+			Short: true,
+		})
+	}
+
+	if responses := RenderAttendeeResponseCounts(event.Attendees); responses != "" {
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: "Attendees",
+			Value: responses,
+			Short: true,
+		})
+	}
+
+	return sa, nil
+}
+
+// RenderAttendeeResponseCounts summarizes the attendees' responses, e.g.
+// "3 accepted, 1 declined".
+func RenderAttendeeResponseCounts(attendees []*remote.Attendee) string {
+	counts := map[string]int{}
+	for _, a := range attendees {
+		response := "notResponded"
+		if a.Status != nil && a.Status.Response != "" && a.Status.Response != "none" {
+			response = a.Status.Response
+		}
+		counts[response]++
+	}
+
+	parts := []string{}
+	for _, r := range attendeeResponses {
+		if counts[r.response] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[r.response], r.pretty))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func EnsureSubject(s string) string {
 	if s == "" {
 		return "(No subject)"
@@ -0,0 +1,120 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// FreeBusyRow is the schedule of one user in the free/busy timeline. Error
+// explains why the schedule is missing, if it is.
+type FreeBusyRow struct {
+	Name             string
+	AvailabilityView remote.AvailabilityView
+	Items            []*remote.ScheduleItem
+	Error            string
+}
+
+// blocksPerColumn is the number of blocks shown in each column of the
+// timeline, an hour for 30-minute blocks.
+const blocksPerColumn = 2
+
+var availabilityBlocks = map[rune]string{
+	remote.AvailabilityViewFree:             "🟩",
+	remote.AvailabilityViewTentative:        "🟨",
+	remote.AvailabilityViewBusy:             "🟥",
+	remote.AvailabilityViewOutOfOffice:      "🟪",
+	remote.AvailabilityViewWorkingElsewhere: "🟦",
+}
+
+const unknownAvailabilityBlock = "⬜"
+
+var prettyScheduleStatuses = map[string]string{
+	remote.ScheduleStatusTentative:        "tentative",
+	remote.ScheduleStatusBusy:             "busy",
+	remote.ScheduleStatusOof:              "out of office",
+	remote.ScheduleStatusWorkingElsewhere: "working elsewhere",
+}
+
+// RenderFreeBusy renders the schedules as a timeline of numBlocks blocks from
+// start, followed by the items of the schedules. Subjects and locations of
+// private items are never shown.
+func RenderFreeBusy(rows []*FreeBusyRow, start time.Time, interval time.Duration, numBlocks int, timeZone string) string {
+	header := "| |"
+	separator := "|:--|"
+	for block := 0; block < numBlocks; block += blocksPerColumn {
+		t := start.Add(time.Duration(block) * interval)
+		label := t.Format("3PM")
+		if t.Minute() != 0 {
+			label = t.Format("3:04PM")
+		}
+		header += " " + label + " |"
+		separator += ":--|"
+	}
+
+	resp := fmt.Sprintf("Times are shown in %s\n\n%s\n%s", timeZone, header, separator)
+	for _, row := range rows {
+		line := "| " + row.Name + " |"
+		for block := 0; block < numBlocks; block += blocksPerColumn {
+			line += " "
+			for b := block; b < block+blocksPerColumn && b < numBlocks; b++ {
+				line += renderAvailabilityBlock(row, b)
+			}
+			line += " |"
+		}
+		resp += "\n" + line
+	}
+	resp += "\n\n🟩 free · 🟨 tentative · 🟥 busy · 🟪 out of office · 🟦 working elsewhere · ⬜ unknown"
+
+	details := ""
+	for _, row := range rows {
+		if row.Error != "" {
+			details += fmt.Sprintf("\n- %s: %s", row.Name, row.Error)
+			continue
+		}
+		for _, item := range row.Items {
+			details += fmt.Sprintf("\n- %s: %s", row.Name, renderFreeBusyItem(item, timeZone))
+		}
+	}
+	if details != "" {
+		resp += "\n" + details
+	}
+	return resp
+}
+
+func renderAvailabilityBlock(row *FreeBusyRow, block int) string {
+	if row.Error != "" || block >= len(row.AvailabilityView) {
+		return unknownAvailabilityBlock
+	}
+	if b, ok := availabilityBlocks[rune(row.AvailabilityView[block])]; ok {
+		return b
+	}
+	return unknownAvailabilityBlock
+}
+
+func renderFreeBusyItem(item *remote.ScheduleItem, timeZone string) string {
+	parts := []string{}
+	if item.Start != nil && item.End != nil {
+		start := item.Start.In(timeZone).Time()
+		end := item.End.In(timeZone).Time()
+		parts = append(parts, start.Format(time.Kitchen)+" - "+end.Format(time.Kitchen))
+	}
+
+	status := prettyScheduleStatuses[item.Status]
+	if status == "" {
+		status = item.Status
+	}
+	parts = append(parts, "_"+status+"_")
+
+	if !item.IsPrivate {
+		if item.Subject != "" {
+			parts = append(parts, item.Subject)
+		}
+		if item.Location != "" {
+			parts = append(parts, item.Location)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,203 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// Focus time is counted as the free blocks of at least minFocusTimeBlock
+// during working hours.
+const (
+	workdayStartHour  = 9
+	workdayEndHour    = 17
+	minFocusTimeBlock = time.Hour
+)
+
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// RenderWeeklySummary renders the meetings per day, the time spent in
+// meetings and left for focus, the conflicts and the pending invitations of
+// the week starting at weekStart.
+func RenderWeeklySummary(title string, events []*remote.Event, weekStart time.Time, timeZone string) (string, error) {
+	if timeZone != "" {
+		for _, e := range events {
+			e.Start = e.Start.In(timeZone)
+			e.End = e.End.In(timeZone)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+
+	resp := fmt.Sprintf("#### %s: %s - %s\n", title, weekStart.Format("Monday January 02"), weekStart.AddDate(0, 0, 6).Format("Monday January 02"))
+	if timeZone != "" {
+		resp += "Times are shown in " + timeZone + "\n"
+	}
+	resp += "\n| Day | Meetings | In meetings | Focus time |\n| :-- | :--: | :--: | :--: |"
+
+	totalMeetings := 0
+	var totalBusy, totalFocus time.Duration
+	for i := 0; i < 7; i++ {
+		dayStart := weekStart.AddDate(0, 0, i)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		meetings := 0
+		busy := []interval{}
+		for _, e := range events {
+			if !remote.IsAttending(e) {
+				continue
+			}
+			start, end := e.Start.Time(), e.End.Time()
+			if !start.Before(dayEnd) || !end.After(dayStart) {
+				continue
+			}
+			if !start.Before(dayStart) {
+				meetings++
+			}
+			busy = append(busy, interval{start, end})
+		}
+		busy = mergeIntervals(busy)
+
+		weekend := dayStart.Weekday() == time.Saturday || dayStart.Weekday() == time.Sunday
+		if weekend && meetings == 0 {
+			continue
+		}
+
+		busyTime := totalDuration(busy, dayStart, dayEnd)
+		focus := "-"
+		if !weekend {
+			workStart := dayStart.Add(workdayStartHour * time.Hour)
+			workEnd := dayStart.Add(workdayEndHour * time.Hour)
+			focusTime := focusDuration(busy, workStart, workEnd)
+			totalFocus += focusTime
+			focus = renderDuration(focusTime)
+		}
+
+		totalMeetings += meetings
+		totalBusy += busyTime
+		resp += fmt.Sprintf("\n| %s | %d | %s | %s |", dayStart.Format("Monday January 02"), meetings, renderDuration(busyTime), focus)
+	}
+
+	resp += fmt.Sprintf("\n\n**Total:** %d meetings, %s in meetings, %s of focus time.", totalMeetings, renderDuration(totalBusy), renderDuration(totalFocus))
+
+	conflicts, err := renderConflicts(remote.FindConflicts(events), "Monday 3:04PM")
+	if err != nil {
+		return "", err
+	}
+	resp += conflicts
+
+	pending := []*remote.Event{}
+	for _, e := range events {
+		if IsPendingInvitation(e) {
+			pending = append(pending, e)
+		}
+	}
+	if len(pending) > 0 {
+		resp += fmt.Sprintf("\n\n**Pending invitations (%d)**", len(pending))
+		for _, e := range pending {
+			link, err := RenderEventLink(e)
+			if err != nil {
+				return "", err
+			}
+			resp += fmt.Sprintf("\n- %s: %s", e.Start.Time().Format("Monday January 02 3:04PM"), link)
+		}
+	}
+
+	return resp, nil
+}
+
+// IsPendingInvitation reports whether the user has not yet responded to an
+// invitation to the event.
+func IsPendingInvitation(e *remote.Event) bool {
+	if e.IsCancelled || e.IsOrganizer || e.ResponseStatus == nil {
+		return false
+	}
+	return e.ResponseStatus.Response == "notResponded"
+}
+
+// mergeIntervals sorts the intervals and joins the overlapping ones.
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	merged := []interval{}
+	for _, in := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !in.start.After(merged[last].end) {
+			if in.end.After(merged[last].end) {
+				merged[last].end = in.end
+			}
+			continue
+		}
+		merged = append(merged, in)
+	}
+	return merged
+}
+
+// totalDuration sums the merged intervals clipped to [from, to).
+func totalDuration(merged []interval, from, to time.Time) time.Duration {
+	var total time.Duration
+	for _, in := range merged {
+		start, end := in.start, in.end
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// focusDuration sums the free blocks between the merged busy intervals within
+// [from, to) that are at least minFocusTimeBlock long.
+func focusDuration(merged []interval, from, to time.Time) time.Duration {
+	var total time.Duration
+	free := from
+	addBlock := func(end time.Time) {
+		if end.Sub(free) >= minFocusTimeBlock {
+			total += end.Sub(free)
+		}
+	}
+	for _, in := range merged {
+		if !in.end.After(free) {
+			continue
+		}
+		if !in.start.Before(to) {
+			break
+		}
+		if in.start.After(free) {
+			addBlock(in.start)
+		}
+		free = in.end
+	}
+	if free.Before(to) {
+		addBlock(to)
+	}
+	return total
+}
+
+func renderDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+}
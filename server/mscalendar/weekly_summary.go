@@ -0,0 +1,215 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package mscalendar
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+)
+
+const (
+	weeklySummaryTitle   = "Your week"
+	nextWeekPreviewTitle = "Next week"
+)
+
+func (m *mscalendar) SetWeeklySummaryEnabled(user *User, enable bool) (*store.DailySummaryUserSettings, error) {
+	return m.updateDailySummarySettings(user, func(dsum *store.DailySummaryUserSettings) {
+		dsum.WeeklyEnable = enable
+	})
+}
+
+func (m *mscalendar) SetNextWeekPreviewEnabled(user *User, enable bool) (*store.DailySummaryUserSettings, error) {
+	return m.updateDailySummarySettings(user, func(dsum *store.DailySummaryUserSettings) {
+		dsum.NextWeekPreviewEnable = enable
+	})
+}
+
+func (m *mscalendar) updateDailySummarySettings(user *User, update func(*store.DailySummaryUserSettings)) (*store.DailySummaryUserSettings, error) {
+	err := m.Filter(withUserExpanded(user))
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Settings.DailySummary == nil {
+		user.Settings.DailySummary = store.DefaultDailySummaryUserSettings()
+	}
+
+	dsum := user.Settings.DailySummary
+	update(dsum)
+
+	err = m.Store.StoreUser(user.User)
+	if err != nil {
+		return nil, err
+	}
+	return dsum, nil
+}
+
+func (m *mscalendar) GetWeeklySummaryForUser(user *User, nextWeek bool) (string, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return "", err
+	}
+
+	start, end := getWeekHoursForTimezone(time.Now(), timezone, nextWeek)
+	events, err := m.getSelectedCalendarsView(user, start, end)
+	if err != nil {
+		return "Failed to get calendar events", err
+	}
+
+	return views.RenderWeeklySummary(weeklySummaryTitleFor(nextWeek), events, start, timezone)
+}
+
+// ProcessAllWeeklySummary posts the weekly summary on Mondays and the next
+// week preview on Fridays to the users who enabled them, at the time of their
+// daily summary.
+func (m *mscalendar) ProcessAllWeeklySummary(now time.Time) error {
+	userIndex, err := m.Store.LoadUserIndex()
+	if err != nil {
+		return err
+	}
+	if len(userIndex) == 0 {
+		return nil
+	}
+
+	err = m.Filter(withSuperuserClient)
+	if err != nil {
+		return err
+	}
+
+	requests := []*remote.ViewCalendarParams{}
+	byRemoteID := map[string]*store.User{}
+	nextWeekByRemoteID := map[string]bool{}
+	for _, user := range userIndex {
+		storeUser, storeErr := m.Store.LoadUser(user.MattermostUserID)
+		if storeErr != nil {
+			m.Logger.Warnf("Error loading user %s for weekly summary. err=%v", user.MattermostUserID, storeErr)
+			continue
+		}
+
+		dsum := storeUser.Settings.DailySummary
+		if dsum == nil {
+			continue
+		}
+
+		nextWeek := false
+		shouldPost, shouldPostErr := shouldPostWeeklySummary(dsum, now)
+		if shouldPostErr == nil && !shouldPost {
+			nextWeek = true
+			shouldPost, shouldPostErr = shouldPostNextWeekPreview(dsum, now)
+		}
+		if shouldPostErr != nil {
+			m.Logger.Warnf("Error posting weekly summary for user %s. err=%v", user.MattermostUserID, shouldPostErr)
+			continue
+		}
+		if !shouldPost {
+			continue
+		}
+
+		byRemoteID[storeUser.Remote.ID] = storeUser
+		nextWeekByRemoteID[storeUser.Remote.ID] = nextWeek
+
+		start, end := getWeekHoursForTimezone(now, dsum.Timezone, nextWeek)
+		requests = append(requests, &remote.ViewCalendarParams{
+			RemoteUserID: storeUser.Remote.ID,
+			CalendarIDs:  storeUser.Settings.CalendarIDs,
+			StartTime:    start,
+			EndTime:      end,
+		})
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	responses, err := m.client.DoBatchViewCalendarRequests(requests)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range responses {
+		user := byRemoteID[res.RemoteUserID]
+		if user == nil {
+			// Should never reach this point
+			continue
+		}
+		if res.Error != nil {
+			m.Logger.Warnf("Error getting user %s calendar for weekly summary. err=%s %s", user.MattermostUserID, res.Error.Code, res.Error.Message)
+			continue
+		}
+
+		dsum := user.Settings.DailySummary
+		nextWeek := nextWeekByRemoteID[res.RemoteUserID]
+		start, _ := getWeekHoursForTimezone(now, dsum.Timezone, nextWeek)
+		postStr, err := views.RenderWeeklySummary(weeklySummaryTitleFor(nextWeek), res.Events, start, dsum.Timezone)
+		if err != nil {
+			m.Logger.Warnf("Error rendering user %s weekly summary. err=%v", user.MattermostUserID, err)
+			continue
+		}
+
+		_, err = m.Poster.DM(user.MattermostUserID, postStr)
+		if err != nil {
+			m.Logger.Warnf("Error posting weekly summary for user %s. err=%v", user.MattermostUserID, err)
+			continue
+		}
+
+		err = m.Store.StoreWeeklySummaryPostTime(user.MattermostUserID, nextWeek, time.Now())
+		if err != nil {
+			m.Logger.Warnf("Error storing weekly summary post time for user %s. err=%v", user.MattermostUserID, err)
+		}
+	}
+
+	m.Logger.Infof("Processed weekly summary for %d users", len(responses))
+	return nil
+}
+
+func shouldPostWeeklySummary(dsum *store.DailySummaryUserSettings, now time.Time) (bool, error) {
+	if dsum == nil || !dsum.WeeklyEnable {
+		return false, nil
+	}
+
+	return shouldPostSummary(dsum, dsum.LastWeeklyPostTime, now, func(day time.Weekday) bool {
+		return day == time.Monday
+	})
+}
+
+func shouldPostNextWeekPreview(dsum *store.DailySummaryUserSettings, now time.Time) (bool, error) {
+	if dsum == nil || !dsum.NextWeekPreviewEnable {
+		return false, nil
+	}
+
+	return shouldPostSummary(dsum, dsum.LastNextWeekPreviewPostTime, now, func(day time.Weekday) bool {
+		return day == time.Friday
+	})
+}
+
+// getWeekHoursForTimezone returns the bounds of the week, Monday to Sunday,
+// containing now, or of the following week.
+func getWeekHoursForTimezone(now time.Time, timezone string, nextWeek bool) (start, end time.Time) {
+	t := remote.NewDateTime(now.UTC(), "UTC").In(timezone).Time()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	start = time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+	if nextWeek {
+		start = start.AddDate(0, 0, 7)
+	}
+	end = start.AddDate(0, 0, 7)
+	return start, end
+}
+
+func weeklySummaryTitleFor(nextWeek bool) string {
+	if nextWeek {
+		return nextWeekPreviewTitle
+	}
+	return weeklySummaryTitle
+}
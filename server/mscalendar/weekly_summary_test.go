@@ -0,0 +1,227 @@
+package mscalendar
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/mscalendar/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/tracker"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot/mock_bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/telemetry"
+)
+
+func TestProcessAllWeeklySummary(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		day           int
+		dsum          *store.DailySummaryUserSettings
+		runAssertions func(deps *Dependencies, client remote.Client)
+	}{
+		{
+			name: "Weekly summary disabled",
+			day:  10, // Monday
+			dsum: &store.DailySummaryUserSettings{
+				PostTime: "9:00AM",
+				Timezone: "Eastern Standard Time",
+			},
+			runAssertions: func(deps *Dependencies, client remote.Client) {
+				mockClient := client.(*mock_remote.MockClient)
+				mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "User receives their weekly summary on Monday",
+			day:  10,
+			dsum: &store.DailySummaryUserSettings{
+				WeeklyEnable: true,
+				PostTime:     "9:00AM",
+				Timezone:     "Eastern Standard Time",
+			},
+			runAssertions: func(deps *Dependencies, client remote.Client) {
+				mockClient := client.(*mock_remote.MockClient)
+				mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).DoAndReturn(func(params []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+					require.Len(t, params, 1)
+					require.Equal(t, time.Monday, params[0].StartTime.Weekday())
+					require.Equal(t, 10, params[0].StartTime.Day())
+					require.Equal(t, 17, params[0].EndTime.Day())
+					return []*remote.ViewCalendarResponse{{
+						RemoteUserID: "user1_remote_id",
+						Events: []*remote.Event{{
+							Subject:        "The subject",
+							Start:          remote.NewDateTime(time.Date(2020, 2, 11, 14, 0, 0, 0, time.UTC), "UTC"),
+							End:            remote.NewDateTime(time.Date(2020, 2, 11, 16, 0, 0, 0, time.UTC), "UTC"),
+							ResponseStatus: &remote.EventResponseStatus{Response: "accepted"},
+						}},
+					}}, nil
+				})
+
+				mockPoster := deps.Poster.(*mock_bot.MockPoster)
+				mockPoster.EXPECT().DM("user1_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID, message string, args ...interface{}) (string, error) {
+					require.True(t, strings.HasPrefix(message, "#### Your week: Monday February 10 - Sunday February 16"))
+					require.Contains(t, message, "| Tuesday February 11 | 1 | 2h | 6h |")
+					return "postID1", nil
+				})
+
+				s := deps.Store.(*mock_store.MockStore)
+				s.EXPECT().StoreWeeklySummaryPostTime("user1_mm_id", false, gomock.Any()).Return(nil)
+
+				mockLogger := deps.Logger.(*mock_bot.MockLogger)
+				mockLogger.EXPECT().Infof("Processed weekly summary for %d users", 1)
+			},
+		},
+		{
+			name: "User receives their next week preview on Friday",
+			day:  14, // Friday
+			dsum: &store.DailySummaryUserSettings{
+				WeeklyEnable:          true,
+				NextWeekPreviewEnable: true,
+				PostTime:              "9:00AM",
+				Timezone:              "Eastern Standard Time",
+			},
+			runAssertions: func(deps *Dependencies, client remote.Client) {
+				mockClient := client.(*mock_remote.MockClient)
+				mockClient.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).DoAndReturn(func(params []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+					require.Len(t, params, 1)
+					require.Equal(t, 17, params[0].StartTime.Day())
+					return []*remote.ViewCalendarResponse{{
+						RemoteUserID: "user1_remote_id",
+						Events:       []*remote.Event{},
+					}}, nil
+				})
+
+				mockPoster := deps.Poster.(*mock_bot.MockPoster)
+				mockPoster.EXPECT().DM("user1_mm_id", gomock.Any()).DoAndReturn(func(mattermostUserID, message string, args ...interface{}) (string, error) {
+					require.True(t, strings.HasPrefix(message, "#### Next week: Monday February 17 - Sunday February 23"))
+					return "postID1", nil
+				})
+
+				s := deps.Store.(*mock_store.MockStore)
+				s.EXPECT().StoreWeeklySummaryPostTime("user1_mm_id", true, gomock.Any()).Return(nil)
+
+				mockLogger := deps.Logger.(*mock_bot.MockLogger)
+				mockLogger.EXPECT().Infof("Processed weekly summary for %d users", 1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock_store.NewMockStore(ctrl)
+			poster := mock_bot.NewMockPoster(ctrl)
+			mockRemote := mock_remote.NewMockRemote(ctrl)
+			mockClient := mock_remote.NewMockClient(ctrl)
+			mockPluginAPI := mock_plugin_api.NewMockPluginAPI(ctrl)
+
+			logger := mock_bot.NewMockLogger(ctrl)
+			env := Env{
+				Dependencies: &Dependencies{
+					Store:     s,
+					Logger:    logger,
+					Poster:    poster,
+					Remote:    mockRemote,
+					PluginAPI: mockPluginAPI,
+					Tracker:   tracker.New(telemetry.NewTracker(nil, "", "", "", "", "", true, logger)),
+				},
+			}
+
+			s.EXPECT().LoadUserIndex().Return(store.UserIndex{{
+				MattermostUserID: "user1_mm_id",
+				RemoteID:         "user1_remote_id",
+			}}, nil)
+			s.EXPECT().LoadUser("user1_mm_id").Return(&store.User{
+				MattermostUserID: "user1_mm_id",
+				Remote:           &remote.User{ID: "user1_remote_id"},
+				Settings: store.Settings{
+					DailySummary: tc.dsum,
+				},
+			}, nil)
+			mockRemote.EXPECT().MakeSuperuserClient(context.Background()).Return(mockClient, nil).Times(1)
+
+			tc.runAssertions(env.Dependencies, mockClient)
+
+			loc, err := time.LoadLocation("EST")
+			require.Nil(t, err)
+			moment := time.Date(2020, 2, tc.day, 9, 0, 0, 0, loc)
+
+			err = New(env, "").ProcessAllWeeklySummary(moment)
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestShouldPostWeeklySummary(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		day              int
+		weekly           bool
+		preview          bool
+		lastWeeklyPost   string
+		shouldRunWeekly  bool
+		shouldRunPreview bool
+	}{
+		{
+			name:            "Weekly summary on Monday",
+			day:             10,
+			weekly:          true,
+			preview:         true,
+			shouldRunWeekly: true,
+		},
+		{
+			name:            "Weekly summary already posted",
+			day:             10,
+			weekly:          true,
+			lastWeeklyPost:  time.Date(2020, 2, 10, 13, 59, 0, 0, time.UTC).Format(time.RFC3339),
+			shouldRunWeekly: false,
+		},
+		{
+			name:    "Nothing on Wednesday",
+			day:     12,
+			weekly:  true,
+			preview: true,
+		},
+		{
+			name:             "Next week preview on Friday",
+			day:              14,
+			weekly:           true,
+			preview:          true,
+			shouldRunPreview: true,
+		},
+		{
+			name:   "Next week preview disabled",
+			day:    14,
+			weekly: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			loc, err := time.LoadLocation("EST")
+			require.Nil(t, err)
+			moment := time.Date(2020, 2, tc.day, 9, 0, 0, 0, loc)
+
+			dsum := &store.DailySummaryUserSettings{
+				WeeklyEnable:          tc.weekly,
+				NextWeekPreviewEnable: tc.preview,
+				PostTime:              "9:00AM",
+				Timezone:              "Eastern Standard Time",
+				LastWeeklyPostTime:    tc.lastWeeklyPost,
+			}
+
+			shouldRun, err := shouldPostWeeklySummary(dsum, moment)
+			require.Nil(t, err)
+			require.Equal(t, tc.shouldRunWeekly, shouldRun)
+
+			shouldRun, err = shouldPostNextWeekPreview(dsum, moment)
+			require.Nil(t, err)
+			require.Equal(t, tc.shouldRunPreview, shouldRun)
+		})
+	}
+}
@@ -195,6 +195,9 @@ func (p *Plugin) OnConfigurationChange() (err error) {
 			e.jobManager = jobs.NewJobManager(p.API, e.Env)
 			e.jobManager.AddJob(jobs.NewStatusSyncJob())
 			e.jobManager.AddJob(jobs.NewDailySummaryJob())
+			e.jobManager.AddJob(jobs.NewWeeklySummaryJob())
+			e.jobManager.AddJob(jobs.NewDigestJob())
+			e.jobManager.AddJob(jobs.NewFocusJob())
 			e.jobManager.AddJob(jobs.NewRenewJob())
 		}
 	})
@@ -321,4 +324,11 @@ func (p *Plugin) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
 	if err != nil {
 		p.API.LogError(err.Error())
 	}
+
+	if len(post.FileIds) > 0 && post.UserId != env.bot.MattermostUserID() {
+		err = m.OfferICSImport(post, env.bot.MattermostUserID())
+		if err != nil {
+			p.API.LogError(err.Error())
+		}
+	}
 }
@@ -15,8 +15,11 @@ type Calendar struct {
 	Owner        *User   `json:"owner,omitempty"`
 }
 
+// ViewCalendarParams requests the events of the user's default calendar, or
+// of the calendars in CalendarIDs when set.
 type ViewCalendarParams struct {
 	RemoteUserID string
+	CalendarIDs  []string
 	StartTime    time.Time
 	EndTime      time.Time
 }
@@ -9,26 +9,35 @@ import (
 )
 
 type Client interface {
-	AcceptEvent(remoteUserID, eventID string) error
+	AcceptEvent(remoteUserID, eventID string, options *EventResponseOptions) error
 	CallFormPost(method, path string, in url.Values, out interface{}) (responseData []byte, err error)
 	CallJSON(method, path string, in, out interface{}) (responseData []byte, err error)
+	CancelEvent(remoteUserID, eventID, message string) error
 	CreateCalendar(remoteUserID string, calendar *Calendar) (*Calendar, error)
 	CreateEvent(remoteUserID string, calendarEvent *Event) (*Event, error)
 	CreateMySubscription(notificationURL string) (*Subscription, error)
-	DeclineEvent(remoteUserID, eventID string) error
+	DeclineEvent(remoteUserID, eventID string, options *EventResponseOptions) error
 	DeleteCalendar(remoteUserID, calendarID string) error
+	DeleteEvent(remoteUserID, eventID string) error
 	DeleteSubscription(subscriptionID string) error
 	FindMeetingTimes(remoteUserID string, meetingParams *FindMeetingTimesParameters) (*MeetingTimeSuggestionResults, error)
 	GetCalendars(remoteUserID string) ([]*Calendar, error)
 	GetDefaultCalendarView(remoteUserID string, startTime, endTime time.Time) ([]*Event, error)
+	GetCalendarView(remoteUserID, calendarID string, startTime, endTime time.Time) ([]*Event, error)
 	DoBatchViewCalendarRequests([]*ViewCalendarParams) ([]*ViewCalendarResponse, error)
 	GetEvent(remoteUserID, eventID string) (*Event, error)
+	GetEventsByICalUID(remoteUserID, iCalUID string) ([]*Event, error)
 	GetMailboxSettings(remoteUserID string) (*MailboxSettings, error)
 	GetMe() (*User, error)
+	GetRoomLists() ([]*RoomList, error)
+	GetRooms(roomListEmail string) ([]*Room, error)
+	GetRoomsSchedule(remoteUserID string, roomEmails []string, startTime, endTime *DateTime, availabilityViewInterval int) ([]*ScheduleInformation, error)
 	GetNotificationData(*Notification) (*Notification, error)
 	GetSchedule(requests []*ScheduleUserInfo, startTime, endTime *DateTime, availabilityViewInterval int) ([]*ScheduleInformation, error)
 	ListSubscriptions() ([]*Subscription, error)
+	SearchEvents(remoteUserID string, params *SearchEventsParams) (events []*Event, incomplete bool, err error)
 	RenewSubscription(subscriptionID string) (*Subscription, error)
-	TentativelyAcceptEvent(remoteUserID, eventID string) error
+	TentativelyAcceptEvent(remoteUserID, eventID string, options *EventResponseOptions) error
+	UpdateEvent(remoteUserID string, event *Event) (*Event, error)
 	GetSuperuserToken() (string, error)
 }
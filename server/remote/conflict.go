@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package remote
+
+import (
+	"sort"
+)
+
+// EventConflict is a pair of events the user is attending that overlap in time.
+type EventConflict struct {
+	Event *Event
+	With  *Event
+}
+
+// FindConflicts returns the overlapping pairs among the events the user has
+// accepted, tentatively accepted or organized, ordered by start time.
+func FindConflicts(events []*Event) []*EventConflict {
+	attending := []*Event{}
+	for _, e := range events {
+		if IsAttending(e) {
+			attending = append(attending, e)
+		}
+	}
+
+	sort.SliceStable(attending, func(i, j int) bool {
+		return attending[i].Start.Time().Before(attending[j].Start.Time())
+	})
+
+	conflicts := []*EventConflict{}
+	for i, e := range attending {
+		end := e.End.Time()
+		for _, other := range attending[i+1:] {
+			if !other.Start.Time().Before(end) {
+				break
+			}
+			conflicts = append(conflicts, &EventConflict{Event: e, With: other})
+		}
+	}
+	return conflicts
+}
+
+// IsAttending reports whether the event blocks the user's time: it is not
+// cancelled, all day or shown as free, and the user has not declined it.
+func IsAttending(e *Event) bool {
+	if e == nil || e.Start == nil || e.End == nil {
+		return false
+	}
+	if e.IsCancelled || e.IsAllDay || e.ShowAs == "free" {
+		return false
+	}
+	if e.IsOrganizer || e.ResponseStatus == nil {
+		return true
+	}
+	switch e.ResponseStatus.Response {
+	case "accepted", "tentativelyAccepted", "organizer":
+		return true
+	}
+	return false
+}
+
+// FindConflictsWith returns the events the user is attending that overlap
+// with event, which may be an invitation the user has not responded to yet.
+// Nothing conflicts with cancelled, all day, free or declined events.
+func FindConflictsWith(event *Event, events []*Event) []*Event {
+	if event == nil || event.Start == nil || event.End == nil {
+		return nil
+	}
+	if event.IsCancelled || event.IsAllDay || event.ShowAs == "free" {
+		return nil
+	}
+	if event.ResponseStatus != nil && event.ResponseStatus.Response == "declined" {
+		return nil
+	}
+
+	start, end := event.Start.Time(), event.End.Time()
+	conflicts := []*Event{}
+	for _, other := range events {
+		if other.ID == event.ID || !IsAttending(other) {
+			continue
+		}
+		if other.Start.Time().Before(end) && start.Before(other.End.Time()) {
+			conflicts = append(conflicts, other)
+		}
+	}
+	return conflicts
+}
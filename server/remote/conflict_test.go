@@ -0,0 +1,131 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindConflicts(t *testing.T) {
+	at := func(hour, minute int) *DateTime {
+		return NewDateTime(time.Date(2020, 2, 12, hour, minute, 0, 0, time.UTC), "UTC")
+	}
+	event := func(id string, start, end *DateTime, response string) *Event {
+		return &Event{
+			ID:             id,
+			Start:          start,
+			End:            end,
+			ResponseStatus: &EventResponseStatus{Response: response},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		events   []*Event
+		expected [][2]string
+	}{
+		{
+			name: "Back to back events",
+			events: []*Event{
+				event("a", at(9, 0), at(10, 0), "accepted"),
+				event("b", at(10, 0), at(11, 0), "accepted"),
+			},
+			expected: [][2]string{},
+		},
+		{
+			name: "Overlapping events",
+			events: []*Event{
+				event("b", at(9, 30), at(10, 30), "tentativelyAccepted"),
+				event("a", at(9, 0), at(11, 0), "organizer"),
+				event("c", at(10, 0), at(12, 0), "accepted"),
+			},
+			expected: [][2]string{{"a", "b"}, {"a", "c"}, {"b", "c"}},
+		},
+		{
+			name: "Declined and not responded events",
+			events: []*Event{
+				event("a", at(9, 0), at(10, 0), "accepted"),
+				event("b", at(9, 0), at(10, 0), "declined"),
+				event("c", at(9, 0), at(10, 0), "notResponded"),
+			},
+			expected: [][2]string{},
+		},
+		{
+			name: "Cancelled and free events",
+			events: []*Event{
+				event("a", at(9, 0), at(10, 0), "accepted"),
+				{ID: "b", Start: at(9, 0), End: at(10, 0), IsCancelled: true},
+				{ID: "c", Start: at(9, 0), End: at(10, 0), ShowAs: "free"},
+			},
+			expected: [][2]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conflicts := FindConflicts(tc.events)
+			actual := [][2]string{}
+			for _, c := range conflicts {
+				actual = append(actual, [2]string{c.Event.ID, c.With.ID})
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFindConflictsWith(t *testing.T) {
+	at := func(hour, minute int) *DateTime {
+		return NewDateTime(time.Date(2020, 2, 12, hour, minute, 0, 0, time.UTC), "UTC")
+	}
+	event := func(id string, start, end *DateTime, response string) *Event {
+		return &Event{
+			ID:             id,
+			Start:          start,
+			End:            end,
+			ResponseStatus: &EventResponseStatus{Response: response},
+		}
+	}
+	others := []*Event{
+		event("a", at(9, 0), at(10, 0), "accepted"),
+		event("b", at(10, 30), at(11, 30), "tentativelyAccepted"),
+		event("c", at(10, 0), at(11, 0), "declined"),
+		event("new", at(10, 0), at(11, 0), "notResponded"),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		event    *Event
+		expected []string
+	}{
+		{
+			name:     "New invitation",
+			event:    event("new", at(9, 30), at(11, 0), "notResponded"),
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "Back to back",
+			event:    event("new", at(10, 0), at(10, 30), "accepted"),
+			expected: []string{},
+		},
+		{
+			name:     "Declined event",
+			event:    event("new", at(9, 30), at(11, 0), "declined"),
+			expected: []string{},
+		},
+		{
+			name:     "Event without times",
+			event:    &Event{ID: "new"},
+			expected: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := []string{}
+			for _, e := range FindConflictsWith(tc.event, others) {
+				actual = append(actual, e.ID)
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
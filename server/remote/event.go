@@ -3,6 +3,8 @@
 
 package remote
 
+import "time"
+
 type Event struct {
 	ID                         string               `json:"id,omitempty"`
 	ICalUID                    string               `json:"iCalUId,omitempty"`
@@ -12,9 +14,12 @@ type Event struct {
 	Importance                 string               `json:"importance,omitempty"`
 	IsAllDay                   bool                 `json:"isAllDay,omitempty"`
 	IsCancelled                bool                 `json:"isCancelled,omitempty"`
+	IsOnlineMeeting            bool                 `json:"isOnlineMeeting,omitempty"`
 	IsOrganizer                bool                 `json:"isOrganizer,omitempty"`
+	IsReminderOn               bool                 `json:"isReminderOn,omitempty"`
 	ResponseRequested          bool                 `json:"responseRequested,omitempty"`
 	ShowAs                     string               `json:"showAs,omitempty"`
+	Sensitivity                string               `json:"sensitivity,omitempty"`
 	Weblink                    string               `json:"weblink,omitempty"`
 	Start                      *DateTime            `json:"start,omitempty"`
 	End                        *DateTime            `json:"end,omitempty"`
@@ -23,6 +28,61 @@ type Event struct {
 	ResponseStatus             *EventResponseStatus `json:"responseStatus,omitempty"`
 	Attendees                  []*Attendee          `json:"attendees,omitempty"`
 	Organizer                  *Attendee            `json:"organizer,omitempty"`
+	OnlineMeetingProvider      string               `json:"onlineMeetingProvider,omitempty"`
+	OnlineMeetingURL           string               `json:"onlineMeetingUrl,omitempty"`
+	OnlineMeeting              *OnlineMeetingInfo   `json:"onlineMeeting,omitempty"`
+	Recurrence                 *PatternedRecurrence `json:"recurrence,omitempty"`
+
+	// Type is singleInstance, occurrence, exception or seriesMaster. The
+	// occurrences and exceptions of a series have its ICalUID, and the
+	// OriginalStart they had in it, like 2020-03-10T15:00:00Z, and the ID of
+	// the series as SeriesMasterID.
+	Type           string `json:"type,omitempty"`
+	OriginalStart  string `json:"originalStart,omitempty"`
+	SeriesMasterID string `json:"seriesMasterId,omitempty"`
+}
+
+// EventResponseOptions are the optional parameters of a response to an
+// invitation. Without options, the response is sent to the organizer with no
+// comment. ProposedNewTime is ignored when accepting.
+type EventResponseOptions struct {
+	Comment         string
+	SendResponse    bool
+	ProposedNewTime *TimeSlot
+}
+
+// PatternedRecurrence is the recurrence of a series of events.
+type PatternedRecurrence struct {
+	Pattern *RecurrencePattern `json:"pattern"`
+	Range   *RecurrenceRange   `json:"range"`
+}
+
+// RecurrencePattern says how often the event repeats. Type is daily, weekly,
+// absoluteMonthly, relativeMonthly, absoluteYearly or relativeYearly; relative
+// patterns repeat on the Index (first, second, third, fourth or last) of the
+// DaysOfWeek.
+type RecurrencePattern struct {
+	Type           string   `json:"type"`
+	Interval       int      `json:"interval"`
+	DaysOfWeek     []string `json:"daysOfWeek,omitempty"`
+	DayOfMonth     int      `json:"dayOfMonth,omitempty"`
+	Month          int      `json:"month,omitempty"`
+	Index          string   `json:"index,omitempty"`
+	FirstDayOfWeek string   `json:"firstDayOfWeek,omitempty"`
+}
+
+// RecurrenceRange says when the series ends. Type is endDate, noEnd or
+// numbered. Dates are like 2006-01-02.
+type RecurrenceRange struct {
+	Type                string `json:"type"`
+	StartDate           string `json:"startDate"`
+	EndDate             string `json:"endDate,omitempty"`
+	NumberOfOccurrences int    `json:"numberOfOccurrences,omitempty"`
+	RecurrenceTimeZone  string `json:"recurrenceTimeZone,omitempty"`
+}
+
+type OnlineMeetingInfo struct {
+	JoinURL string `json:"joinUrl,omitempty"`
 }
 
 type ItemBody struct {
@@ -36,10 +96,11 @@ type EventResponseStatus struct {
 }
 
 type Location struct {
-	DisplayName  string       `json:"displayName,omitempty"`
-	Address      *Address     `json:"address"`
-	Coordinates  *Coordinates `json:"coordinates"`
-	LocationType string       `json:"locationType"`
+	DisplayName          string       `json:"displayName,omitempty"`
+	LocationEmailAddress string       `json:"locationEmailAddress,omitempty"`
+	Address              *Address     `json:"address"`
+	Coordinates          *Coordinates `json:"coordinates"`
+	LocationType         string       `json:"locationType"`
 }
 
 type Address struct {
@@ -60,3 +121,14 @@ type Attendee struct {
 	Status       *EventResponseStatus `json:"status,omitempty"`
 	EmailAddress *EmailAddress        `json:"emailAddress,omitempty"`
 }
+
+// SearchEventsParams selects the events whose subject, body, location,
+// organizer or attendees contain Query, between StartTime and EndTime. Top and
+// Skip page the results, ordered by start.
+type SearchEventsParams struct {
+	Query     string
+	StartTime time.Time
+	EndTime   time.Time
+	Top       int
+	Skip      int
+}
@@ -51,7 +51,9 @@ type LocationConstraint struct {
 	SuggestLocation *bool                    `json:"suggestLocation,omitempty"`
 }
 
+// LocationConstraintItem is a location, with whether to check the
+// availability of the room it designates.
 type LocationConstraintItem struct {
-	Location            *Location
+	*Location
 	ResolveAvailability *bool `json:"resolveAvailability,omitempty"`
 }
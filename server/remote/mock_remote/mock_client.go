@@ -36,17 +36,17 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // AcceptEvent mocks base method
-func (m *MockClient) AcceptEvent(arg0, arg1 string) error {
+func (m *MockClient) AcceptEvent(arg0, arg1 string, arg2 *remote.EventResponseOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AcceptEvent", arg0, arg1)
+	ret := m.ctrl.Call(m, "AcceptEvent", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AcceptEvent indicates an expected call of AcceptEvent
-func (mr *MockClientMockRecorder) AcceptEvent(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) AcceptEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptEvent", reflect.TypeOf((*MockClient)(nil).AcceptEvent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptEvent", reflect.TypeOf((*MockClient)(nil).AcceptEvent), arg0, arg1, arg2)
 }
 
 // CallFormPost mocks base method
@@ -79,6 +79,20 @@ func (mr *MockClientMockRecorder) CallJSON(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallJSON", reflect.TypeOf((*MockClient)(nil).CallJSON), arg0, arg1, arg2, arg3)
 }
 
+// CancelEvent mocks base method
+func (m *MockClient) CancelEvent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEvent indicates an expected call of CancelEvent
+func (mr *MockClientMockRecorder) CancelEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockClient)(nil).CancelEvent), arg0, arg1, arg2)
+}
+
 // CreateCalendar mocks base method
 func (m *MockClient) CreateCalendar(arg0 string, arg1 *remote.Calendar) (*remote.Calendar, error) {
 	m.ctrl.T.Helper()
@@ -125,17 +139,17 @@ func (mr *MockClientMockRecorder) CreateMySubscription(arg0 interface{}) *gomock
 }
 
 // DeclineEvent mocks base method
-func (m *MockClient) DeclineEvent(arg0, arg1 string) error {
+func (m *MockClient) DeclineEvent(arg0, arg1 string, arg2 *remote.EventResponseOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeclineEvent", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeclineEvent", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeclineEvent indicates an expected call of DeclineEvent
-func (mr *MockClientMockRecorder) DeclineEvent(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeclineEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineEvent", reflect.TypeOf((*MockClient)(nil).DeclineEvent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineEvent", reflect.TypeOf((*MockClient)(nil).DeclineEvent), arg0, arg1, arg2)
 }
 
 // DeleteCalendar mocks base method
@@ -152,6 +166,20 @@ func (mr *MockClientMockRecorder) DeleteCalendar(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendar", reflect.TypeOf((*MockClient)(nil).DeleteCalendar), arg0, arg1)
 }
 
+// DeleteEvent mocks base method
+func (m *MockClient) DeleteEvent(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent
+func (mr *MockClientMockRecorder) DeleteEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockClient)(nil).DeleteEvent), arg0, arg1)
+}
+
 // DeleteSubscription mocks base method
 func (m *MockClient) DeleteSubscription(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -196,6 +224,21 @@ func (mr *MockClientMockRecorder) FindMeetingTimes(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMeetingTimes", reflect.TypeOf((*MockClient)(nil).FindMeetingTimes), arg0, arg1)
 }
 
+// GetCalendarView mocks base method
+func (m *MockClient) GetCalendarView(arg0, arg1 string, arg2, arg3 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarView", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarView indicates an expected call of GetCalendarView
+func (mr *MockClientMockRecorder) GetCalendarView(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarView", reflect.TypeOf((*MockClient)(nil).GetCalendarView), arg0, arg1, arg2, arg3)
+}
+
 // GetCalendars mocks base method
 func (m *MockClient) GetCalendars(arg0 string) ([]*remote.Calendar, error) {
 	m.ctrl.T.Helper()
@@ -241,6 +284,21 @@ func (mr *MockClientMockRecorder) GetEvent(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockClient)(nil).GetEvent), arg0, arg1)
 }
 
+// GetEventsByICalUID mocks base method
+func (m *MockClient) GetEventsByICalUID(arg0, arg1 string) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsByICalUID", arg0, arg1)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsByICalUID indicates an expected call of GetEventsByICalUID
+func (mr *MockClientMockRecorder) GetEventsByICalUID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsByICalUID", reflect.TypeOf((*MockClient)(nil).GetEventsByICalUID), arg0, arg1)
+}
+
 // GetMailboxSettings mocks base method
 func (m *MockClient) GetMailboxSettings(arg0 string) (*remote.MailboxSettings, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +344,51 @@ func (mr *MockClientMockRecorder) GetNotificationData(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationData", reflect.TypeOf((*MockClient)(nil).GetNotificationData), arg0)
 }
 
+// GetRoomLists mocks base method
+func (m *MockClient) GetRoomLists() ([]*remote.RoomList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomLists")
+	ret0, _ := ret[0].([]*remote.RoomList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomLists indicates an expected call of GetRoomLists
+func (mr *MockClientMockRecorder) GetRoomLists() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomLists", reflect.TypeOf((*MockClient)(nil).GetRoomLists))
+}
+
+// GetRooms mocks base method
+func (m *MockClient) GetRooms(arg0 string) ([]*remote.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRooms", arg0)
+	ret0, _ := ret[0].([]*remote.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRooms indicates an expected call of GetRooms
+func (mr *MockClientMockRecorder) GetRooms(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRooms", reflect.TypeOf((*MockClient)(nil).GetRooms), arg0)
+}
+
+// GetRoomsSchedule mocks base method
+func (m *MockClient) GetRoomsSchedule(arg0 string, arg1 []string, arg2, arg3 *remote.DateTime, arg4 int) ([]*remote.ScheduleInformation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomsSchedule", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*remote.ScheduleInformation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomsSchedule indicates an expected call of GetRoomsSchedule
+func (mr *MockClientMockRecorder) GetRoomsSchedule(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomsSchedule", reflect.TypeOf((*MockClient)(nil).GetRoomsSchedule), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetSchedule mocks base method
 func (m *MockClient) GetSchedule(arg0 []*remote.ScheduleUserInfo, arg1, arg2 *remote.DateTime, arg3 int) ([]*remote.ScheduleInformation, error) {
 	m.ctrl.T.Helper()
@@ -346,16 +449,47 @@ func (mr *MockClientMockRecorder) RenewSubscription(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewSubscription", reflect.TypeOf((*MockClient)(nil).RenewSubscription), arg0)
 }
 
+// SearchEvents mocks base method
+func (m *MockClient) SearchEvents(arg0 string, arg1 *remote.SearchEventsParams) ([]*remote.Event, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEvents", arg0, arg1)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchEvents indicates an expected call of SearchEvents
+func (mr *MockClientMockRecorder) SearchEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEvents", reflect.TypeOf((*MockClient)(nil).SearchEvents), arg0, arg1)
+}
+
 // TentativelyAcceptEvent mocks base method
-func (m *MockClient) TentativelyAcceptEvent(arg0, arg1 string) error {
+func (m *MockClient) TentativelyAcceptEvent(arg0, arg1 string, arg2 *remote.EventResponseOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TentativelyAcceptEvent", arg0, arg1)
+	ret := m.ctrl.Call(m, "TentativelyAcceptEvent", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // TentativelyAcceptEvent indicates an expected call of TentativelyAcceptEvent
-func (mr *MockClientMockRecorder) TentativelyAcceptEvent(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) TentativelyAcceptEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockClient)(nil).TentativelyAcceptEvent), arg0, arg1, arg2)
+}
+
+// UpdateEvent mocks base method
+func (m *MockClient) UpdateEvent(arg0 string, arg1 *remote.Event) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent
+func (mr *MockClientMockRecorder) UpdateEvent(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockClient)(nil).TentativelyAcceptEvent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockClient)(nil).UpdateEvent), arg0, arg1)
 }
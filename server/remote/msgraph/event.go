@@ -5,13 +5,19 @@ package msgraph
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
-	msgraph "github.com/yaegashi/msgraph.go/v1.0"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 )
 
+type eventResponseRequest struct {
+	Comment         string           `json:"comment,omitempty"`
+	SendResponse    bool             `json:"sendResponse"`
+	ProposedNewTime *remote.TimeSlot `json:"proposedNewTime,omitempty"`
+}
+
 func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
 	e := &remote.Event{}
 
@@ -23,29 +29,54 @@ func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
 	return e, nil
 }
 
-func (c *client) AcceptEvent(remoteUserID, eventID string) error {
-	dummy := &msgraph.EventAcceptRequestParameter{}
-	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Accept(dummy).Request().Post(c.ctx)
+func (c *client) AcceptEvent(remoteUserID, eventID string, options *remote.EventResponseOptions) error {
+	req := newEventResponseRequest(options)
+	req.ProposedNewTime = nil
+	err := c.respondToEvent(remoteUserID, eventID, "/accept", req)
 	if err != nil {
 		return errors.Wrap(err, "msgraph Accept Event")
 	}
 	return nil
 }
 
-func (c *client) DeclineEvent(remoteUserID, eventID string) error {
-	dummy := &msgraph.EventDeclineRequestParameter{}
-	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Decline(dummy).Request().Post(c.ctx)
+func (c *client) DeclineEvent(remoteUserID, eventID string, options *remote.EventResponseOptions) error {
+	err := c.respondToEvent(remoteUserID, eventID, "/decline", newEventResponseRequest(options))
 	if err != nil {
 		return errors.Wrap(err, "msgraph DeclineEvent")
 	}
 	return nil
 }
 
-func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string) error {
-	dummy := &msgraph.EventTentativelyAcceptRequestParameter{}
-	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).TentativelyAccept(dummy).Request().Post(c.ctx)
+func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string, options *remote.EventResponseOptions) error {
+	err := c.respondToEvent(remoteUserID, eventID, "/tentativelyAccept", newEventResponseRequest(options))
 	if err != nil {
 		return errors.Wrap(err, "msgraph TentativelyAcceptEvent")
 	}
 	return nil
 }
+
+func (c *client) respondToEvent(remoteUserID, eventID, action string, req *eventResponseRequest) error {
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Request().JSONRequest(
+		c.ctx, http.MethodPost, action, req, nil)
+	if err != nil && !isAcceptedError(err) {
+		return err
+	}
+	return nil
+}
+
+// isAcceptedError checks for the 202 Accepted response of the event actions,
+// which has no content and is reported as an error.
+func isAcceptedError(err error) bool {
+	return strings.Contains(err.Error(), "202 Accepted")
+}
+
+func newEventResponseRequest(options *remote.EventResponseOptions) *eventResponseRequest {
+	if options == nil {
+		return &eventResponseRequest{SendResponse: true}
+	}
+	return &eventResponseRequest{
+		Comment:         options.Comment,
+		SendResponse:    options.SendResponse,
+		ProposedNewTime: options.ProposedNewTime,
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+)
+
+func TestRespondToEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError string
+	}{
+		{
+			name:       "Accepted",
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:          "Error",
+			statusCode:    http.StatusBadRequest,
+			body:          "Bad request",
+			expectedError: "msgraph Accept Event: 400 Bad Request: Bad request",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) *http.Response {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/v1.0/users/user_remote_id/events/event_id/accept", r.URL.Path)
+				return &http.Response{
+					StatusCode: tc.statusCode,
+					Status:     fmt.Sprintf("%d %s", tc.statusCode, http.StatusText(tc.statusCode)),
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(tc.body))),
+				}
+			})}
+			c := &client{
+				httpClient: httpClient,
+				rbuilder:   msgraph.NewClient(httpClient),
+			}
+
+			err := c.AcceptEvent("user_remote_id", "event_id", nil)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
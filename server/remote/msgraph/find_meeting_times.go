@@ -4,6 +4,7 @@
 package msgraph
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -11,11 +12,23 @@ import (
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
 )
 
+// findMeetingTimesRequest sends the meeting duration as an ISO 8601 duration,
+// as expected by Microsoft Graph.
+type findMeetingTimesRequest struct {
+	*remote.FindMeetingTimesParameters
+	MeetingDuration string `json:"meetingDuration,omitempty"`
+}
+
 // FindMeetingTimes finds meeting time suggestions for a calendar event
 func (c *client) FindMeetingTimes(remoteUserID string, params *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
 	meetingsOut := &remote.MeetingTimeSuggestionResults{}
+	in := &findMeetingTimesRequest{FindMeetingTimesParameters: params}
+	if params.MeetingDuration != nil {
+		in.MeetingDuration = fmt.Sprintf("PT%dM", int(params.MeetingDuration.Minutes()))
+	}
+
 	req := c.rbuilder.Users().ID(remoteUserID).FindMeetingTimes(nil).Request()
-	err := req.JSONRequest(c.ctx, http.MethodPost, "", &params, &meetingsOut)
+	err := req.JSONRequest(c.ctx, http.MethodPost, "", in, &meetingsOut)
 	if err != nil {
 		return nil, errors.Wrap(err, "msgraph FindMeetingTimes")
 	}
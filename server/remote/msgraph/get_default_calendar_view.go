@@ -6,16 +6,29 @@ package msgraph
 import (
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
 )
 
+// calendarViewMaxEvents is the size of the pages of events, large enough to
+// fit a busy week in one.
+const calendarViewMaxEvents = "100"
+
+// calendarViewMaxPages bounds the pages followed for one calendar view.
+const calendarViewMaxPages = 10
+
+// preferTextBody asks for the bodies of the events in text rather than HTML.
+var preferTextBody = map[string]string{"Prefer": `outlook.body-content-type="text"`}
+
 type calendarViewResponse struct {
-	Value []*remote.Event  `json:"value,omitempty"`
-	Error *remote.APIError `json:"error,omitempty"`
+	Value    []*remote.Event  `json:"value,omitempty"`
+	NextLink string           `json:"@odata.nextLink,omitempty"`
+	Error    *remote.APIError `json:"error,omitempty"`
 }
 
 type calendarViewSingleResponse struct {
@@ -39,20 +52,80 @@ func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Tim
 		return nil, errors.Wrap(err, "msgraph GetDefaultCalendarView")
 	}
 
-	return res.Value, nil
+	events, err := c.getCalendarViewNextPages(res, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetDefaultCalendarView")
+	}
+	return events, nil
+}
+
+// GetCalendarView gets the events of one of the user's calendars.
+func (c *client) GetCalendarView(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	paramStr := getQueryParamStringForCalendarView(start, end)
+
+	res := &calendarViewResponse{}
+	err := c.rbuilder.Users().ID(remoteUserID).Calendars().ID(calendarID).CalendarView().Request().JSONRequest(
+		c.ctx, http.MethodGet, paramStr, nil, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetCalendarView")
+	}
+
+	events, err := c.getCalendarViewNextPages(res, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetCalendarView")
+	}
+	return events, nil
 }
 
+// getCalendarViewNextPages follows the next links of the first page of a
+// calendar view, with the headers of the first request, returning the events
+// of all the pages.
+func (c *client) getCalendarViewNextPages(res *calendarViewResponse, headers map[string]string) ([]*remote.Event, error) {
+	events, _, err := c.followCalendarViewNextLinks(res, headers)
+	return events, err
+}
+
+// followCalendarViewNextLinks follows the next links of the first page of a
+// calendar view up to calendarViewMaxPages pages, returning their events and
+// the next link left unfollowed, empty when the view was fetched whole.
+func (c *client) followCalendarViewNextLinks(res *calendarViewResponse, headers map[string]string) ([]*remote.Event, string, error) {
+	events := res.Value
+	next := res.NextLink
+	for page := 1; next != "" && page < calendarViewMaxPages; page++ {
+		nextRes := &calendarViewResponse{}
+		_, err := c.call(http.MethodGet, next, "", headers, nil, nextRes)
+		if err != nil {
+			return nil, "", err
+		}
+		events = append(events, nextRes.Value...)
+		next = nextRes.NextLink
+	}
+	return events, next, nil
+}
+
+// DoBatchViewCalendarRequests gets the events of many users at once, with
+// one response per user merging the events of all the requested calendars.
+// The response has an error only when none of the user's calendars could be
+// fetched, the failing calendars being left out otherwise. The bodies of the
+// events are in text, to be shown in posts.
 func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
 	requests := []*singleRequest{}
+	owners := map[string]*remote.ViewCalendarParams{}
+	requested := map[*remote.ViewCalendarParams]int{}
 	for _, params := range allParams {
-		u := getCalendarViewURL(params)
-		req := &singleRequest{
-			ID:      params.RemoteUserID,
-			URL:     u,
-			Method:  http.MethodGet,
-			Headers: map[string]string{},
+		urls := getCalendarViewURLs(params)
+		requested[params] = len(urls)
+		for _, u := range urls {
+			id := strconv.Itoa(len(requests))
+			owners[id] = params
+			req := &singleRequest{
+				ID:      id,
+				URL:     u,
+				Method:  http.MethodGet,
+				Headers: preferTextBody,
+			}
+			requests = append(requests, req)
 		}
-		requests = append(requests, req)
 	}
 
 	batchRequests := prepareBatchRequests(requests)
@@ -68,29 +141,69 @@ func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarPar
 	}
 
 	result := []*remote.ViewCalendarResponse{}
+	byParams := map[*remote.ViewCalendarParams]*remote.ViewCalendarResponse{}
+	failed := map[*remote.ViewCalendarParams]int{}
 	for _, batchRes := range batchResponses {
 		for _, res := range batchRes.Responses {
-			viewCalRes := &remote.ViewCalendarResponse{
-				RemoteUserID: res.ID,
-				Events:       res.Body.Value,
-				Error:        res.Body.Error,
+			params := owners[res.ID]
+			if params == nil {
+				continue
+			}
+
+			viewCalRes := byParams[params]
+			if viewCalRes == nil {
+				viewCalRes = &remote.ViewCalendarResponse{
+					RemoteUserID: params.RemoteUserID,
+					Events:       []*remote.Event{},
+				}
+				byParams[params] = viewCalRes
+				result = append(result, viewCalRes)
 			}
-			result = append(result, viewCalRes)
+			events, err := c.getCalendarViewNextPages(&res.Body, preferTextBody)
+			if err != nil {
+				res.Body.Error = &remote.APIError{Message: err.Error()}
+			}
+			if res.Body.Error != nil {
+				failed[params]++
+				if viewCalRes.Error == nil {
+					viewCalRes.Error = res.Body.Error
+				}
+				continue
+			}
+			viewCalRes.Events = append(viewCalRes.Events, events...)
+		}
+	}
+
+	for params, viewCalRes := range byParams {
+		if failed[params] == 0 || failed[params] == requested[params] {
+			continue
 		}
+		c.Logger.With(bot.LogContext{
+			"remoteUserID": params.RemoteUserID,
+		}).Warnf("msgraph: failed to get %d of %d calendars: `%s`.", failed[params], requested[params], viewCalRes.Error.Message)
+		viewCalRes.Error = nil
 	}
 
 	return result, nil
 }
 
-func getCalendarViewURL(params *remote.ViewCalendarParams) string {
+func getCalendarViewURLs(params *remote.ViewCalendarParams) []string {
 	paramStr := getQueryParamStringForCalendarView(params.StartTime, params.EndTime)
-	return "/Users/" + params.RemoteUserID + "/calendarView" + paramStr
+	if len(params.CalendarIDs) == 0 {
+		return []string{"/Users/" + params.RemoteUserID + "/calendarView" + paramStr}
+	}
+
+	urls := []string{}
+	for _, calendarID := range params.CalendarIDs {
+		urls = append(urls, "/Users/"+params.RemoteUserID+"/calendars/"+url.PathEscape(calendarID)+"/calendarView"+paramStr)
+	}
+	return urls
 }
 
 func getQueryParamStringForCalendarView(start, end time.Time) string {
 	q := url.Values{}
 	q.Add("startDateTime", start.Format(time.RFC3339))
 	q.Add("endDateTime", end.Format(time.RFC3339))
-	q.Add("$top", "20")
+	q.Add("$top", calendarViewMaxEvents)
 	return "?" + q.Encode()
 }
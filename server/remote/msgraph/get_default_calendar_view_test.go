@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+type roundTripFunc func(r *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r), nil
+}
+
+func TestGetCalendarViewNextPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := &calendarViewResponse{}
+		switch r.URL.Query().Get("$skip") {
+		case "1":
+			res.Value = []*remote.Event{{ID: "event_2"}}
+			res.NextLink = server.URL + "/calendarView?$skip=2"
+		case "2":
+			res.Value = []*remote.Event{{ID: "event_3"}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer server.Close()
+
+	c := &client{httpClient: server.Client()}
+	events, err := c.getCalendarViewNextPages(&calendarViewResponse{
+		Value:    []*remote.Event{{ID: "event_1"}},
+		NextLink: server.URL + "/calendarView?$skip=1",
+	}, nil)
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	require.Equal(t, []string{"event_1", "event_2", "event_3"}, ids)
+
+	_, err = c.getCalendarViewNextPages(&calendarViewResponse{NextLink: server.URL + "/calendarView?$skip=9"}, nil)
+	require.Error(t, err)
+}
+
+func TestDoBatchViewCalendarRequests(t *testing.T) {
+	batchRes := &calendarViewBatchResponse{Responses: []*calendarViewSingleResponse{
+		{ID: "0", Status: http.StatusOK, Body: calendarViewResponse{Value: []*remote.Event{{ID: "work_1"}}}},
+		{ID: "1", Status: http.StatusNotFound, Body: calendarViewResponse{Error: &remote.APIError{Code: "ErrorItemNotFound", Message: "Not found"}}},
+		{ID: "2", Status: http.StatusNotFound, Body: calendarViewResponse{Error: &remote.APIError{Code: "ErrorItemNotFound", Message: "Not found"}}},
+	}}
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) *http.Response {
+		req := fullBatchRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Len(t, req.Requests, 3)
+		for _, single := range req.Requests {
+			// The bodies are posted in meeting threads
+			require.Equal(t, `outlook.body-content-type="text"`, single.Headers["Prefer"])
+		}
+
+		body, _ := json.Marshal(batchRes)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}
+	})}
+
+	c := &client{httpClient: httpClient, Logger: &bot.NilLogger{}}
+	start := time.Now()
+	res, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "user_1", CalendarIDs: []string{"work", "deleted"}, StartTime: start, EndTime: start.Add(time.Hour)},
+		{RemoteUserID: "user_2", StartTime: start, EndTime: start.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	// The failing calendar is left out
+	require.Equal(t, "user_1", res[0].RemoteUserID)
+	require.Nil(t, res[0].Error)
+	require.Equal(t, []*remote.Event{{ID: "work_1"}}, res[0].Events)
+
+	require.Equal(t, "user_2", res[1].RemoteUserID)
+	require.NotNil(t, res[1].Error)
+	require.Empty(t, res[1].Events)
+}
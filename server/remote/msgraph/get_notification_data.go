@@ -17,6 +17,14 @@ func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notific
 	wh := n.Webhook.(*webhook)
 	switch wh.ResourceData.DataType {
 	case "#Microsoft.Graph.Event":
+		if wh.ChangeType == remote.ChangeTypeDeleted {
+			// The event can no longer be fetched
+			n.Event = &remote.Event{ID: wh.ResourceData.ID}
+			n.ChangeType = wh.ChangeType
+			n.IsBare = false
+			break
+		}
+
 		event := remote.Event{}
 		_, err := c.CallJSON(http.MethodGet, wh.Resource, nil, &event)
 		if err != nil {
@@ -0,0 +1,90 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// maxPlaces is the number of rooms or room lists requested at once. The
+// places API does not page its results without $top.
+const maxPlaces = 500
+
+// maxSchedulesPerRequest is the number of rooms whose schedule is requested at
+// once.
+const maxSchedulesPerRequest = 20
+
+func (c *client) GetRoomLists() ([]*remote.RoomList, error) {
+	var v struct {
+		Value []*remote.RoomList `json:"value"`
+	}
+	_, err := c.CallJSON(http.MethodGet, "/places/microsoft.graph.roomlist?$top="+strconv.Itoa(maxPlaces), nil, &v)
+	if isForbiddenError(err) {
+		return nil, remote.ErrRoomsNotPermitted
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetRoomLists")
+	}
+	return v.Value, nil
+}
+
+// GetRooms gets the rooms of the room list, or all the rooms of the
+// organization when roomListEmail is empty.
+func (c *client) GetRooms(roomListEmail string) ([]*remote.Room, error) {
+	var v struct {
+		Value []*remote.Room `json:"value"`
+	}
+	path := "/places/microsoft.graph.room"
+	if roomListEmail != "" {
+		path = "/places/" + url.PathEscape(roomListEmail) + "/microsoft.graph.roomlist/rooms"
+	}
+	_, err := c.CallJSON(http.MethodGet, path+"?$top="+strconv.Itoa(maxPlaces), nil, &v)
+	if isForbiddenError(err) {
+		return nil, remote.ErrRoomsNotPermitted
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetRooms")
+	}
+	return v.Value, nil
+}
+
+// GetRoomsSchedule gets the free/busy schedule of the rooms, as seen by the
+// user.
+func (c *client) GetRoomsSchedule(remoteUserID string, roomEmails []string, startTime, endTime *remote.DateTime, availabilityViewInterval int) ([]*remote.ScheduleInformation, error) {
+	result := []*remote.ScheduleInformation{}
+	for start := 0; start < len(roomEmails); start += maxSchedulesPerRequest {
+		end := start + maxSchedulesPerRequest
+		if end > len(roomEmails) {
+			end = len(roomEmails)
+		}
+
+		params := &getScheduleRequestParams{
+			Schedules:                roomEmails[start:end],
+			StartTime:                startTime,
+			EndTime:                  endTime,
+			AvailabilityViewInterval: availabilityViewInterval,
+		}
+		res := &getScheduleResponse{}
+		_, err := c.CallJSON(http.MethodPost, "/users/"+remoteUserID+"/calendar/getSchedule", params, res)
+		if err != nil {
+			return nil, errors.Wrap(err, "msgraph GetRoomsSchedule")
+		}
+		result = append(result, res.Value...)
+	}
+	return result, nil
+}
+
+// isForbiddenError checks for the 403 Forbidden response of the places API,
+// when the Place.Read.All permission has not been granted.
+func isForbiddenError(err error) bool {
+	errResp, ok := err.(*msgraph.ErrorResponse)
+	return ok && errResp.Response != nil && errResp.Response.StatusCode == http.StatusForbidden
+}
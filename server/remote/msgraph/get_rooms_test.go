@@ -0,0 +1,35 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+func TestGetRoomsForbidden(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusForbidden,
+			Status:     "403 Forbidden",
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"error":{"code":"ErrorAccessDenied","message":"Access is denied."}}`))),
+		}
+	})}
+	c := &client{
+		httpClient: httpClient,
+		rbuilder:   msgraph.NewClient(httpClient),
+	}
+
+	_, err := c.GetRooms("")
+	require.Equal(t, remote.ErrRoomsNotPermitted, err)
+	_, err = c.GetRoomLists()
+	require.Equal(t, remote.ErrRoomsNotPermitted, err)
+}
@@ -2,6 +2,7 @@ package msgraph
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -49,8 +50,12 @@ func (c *client) GetSchedule(requests []*remote.ScheduleUserInfo, startTime, end
 	}
 
 	allRequests := []*singleRequest{}
-	for _, req := range requests {
-		allRequests = append(allRequests, makeSingleRequestForGetSchedule(req, params))
+	for i, req := range requests {
+		single := makeSingleRequestForGetSchedule(req, params)
+		// The same user can look up several schedules, so the IDs of the
+		// requests of a batch cannot be the remote user IDs
+		single.ID = strconv.Itoa(i)
+		allRequests = append(allRequests, single)
 	}
 	batchRequests := prepareBatchRequests(allRequests)
 
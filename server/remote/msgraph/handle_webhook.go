@@ -23,6 +23,7 @@ type webhook struct {
 	SubscriptionID                 string `json:"subscriptionId"`
 	ResourceData                   struct {
 		DataType string `json:"@odata.type"`
+		ID       string `json:"id"`
 	} `json:"resourceData"`
 }
 
@@ -0,0 +1,112 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// SearchEvents finds the user's events whose subject, body, location,
+// organizer or attendees contain the query, ignoring case. Microsoft Graph
+// supports neither $search nor filtering on these for events, and the events
+// endpoint misses the occurrences of recurring events, so the calendar view
+// of the range is matched here. Only the first calendarViewMaxPages pages of
+// the view are searched, and incomplete tells when the range had more events.
+func (c *client) SearchEvents(remoteUserID string, params *remote.SearchEventsParams) (events []*remote.Event, incomplete bool, err error) {
+	if params.StartTime.IsZero() || params.EndTime.IsZero() {
+		return nil, false, errors.New("msgraph SearchEvents: a time range is needed")
+	}
+
+	path := "/users/" + remoteUserID + "/calendarView" + getQueryParamStringForCalendarView(params.StartTime, params.EndTime)
+	res := &calendarViewResponse{}
+	_, err = c.call(http.MethodGet, path, "", preferTextBody, nil, res)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "msgraph SearchEvents")
+	}
+	events, next, err := c.followCalendarViewNextLinks(res, preferTextBody)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "msgraph SearchEvents")
+	}
+
+	return matchSearchEvents(events, params), next != "", nil
+}
+
+// matchSearchEvents returns the page of the events matching the query,
+// ordered by start.
+func matchSearchEvents(events []*remote.Event, params *remote.SearchEventsParams) []*remote.Event {
+	query := strings.ToLower(strings.TrimSpace(params.Query))
+	matches := []*remote.Event{}
+	for _, e := range events {
+		if eventMatches(e, query) {
+			matches = append(matches, e)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Start.Time().Before(matches[j].Start.Time())
+	})
+
+	if params.Skip >= len(matches) {
+		return []*remote.Event{}
+	}
+	matches = matches[params.Skip:]
+	if params.Top > 0 && params.Top < len(matches) {
+		matches = matches[:params.Top]
+	}
+	return matches
+}
+
+// eventMatches checks if the lowercase query is in the subject, body,
+// location, organizer or attendees of the event.
+func eventMatches(e *remote.Event, query string) bool {
+	fields := []string{e.Subject}
+	if e.Body != nil {
+		fields = append(fields, e.Body.Content)
+	}
+	if e.Location != nil {
+		fields = append(fields, e.Location.DisplayName)
+	}
+	people := e.Attendees
+	if e.Organizer != nil {
+		people = append([]*remote.Attendee{e.Organizer}, people...)
+	}
+	for _, p := range people {
+		if p.EmailAddress != nil {
+			fields = append(fields, p.EmailAddress.Name, p.EmailAddress.Address)
+		}
+	}
+
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEventsByICalUID finds the user's events with the iCalendar UID, which
+// all the copies of an invitation share.
+func (c *client) GetEventsByICalUID(remoteUserID, iCalUID string) ([]*remote.Event, error) {
+	values := url.Values{}
+	values.Add("$filter", "iCalUId eq "+odataString(iCalUID))
+	res := &calendarViewResponse{}
+	err := c.rbuilder.Users().ID(remoteUserID).Events().Request().JSONRequest(
+		c.ctx, http.MethodGet, "?"+values.Encode(), nil, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetEventsByICalUID")
+	}
+
+	return res.Value, nil
+}
+
+// odataString quotes the value as an OData string literal.
+func odataString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+func TestSearchEvents(t *testing.T) {
+	day := time.Date(2020, 2, 12, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) *remote.DateTime {
+		return remote.NewDateTime(day.Add(time.Duration(hour)*time.Hour), "UTC")
+	}
+	person := func(name, address string) *remote.Attendee {
+		return &remote.Attendee{EmailAddress: &remote.EmailAddress{Name: name, Address: address}}
+	}
+	events := []*remote.Event{
+		{ID: "subject", Subject: "Q1 Planning", Start: at(15)},
+		{ID: "body", Subject: "Sync", Body: &remote.ItemBody{Content: "Agenda: planning the offsite"}, Start: at(9)},
+		{ID: "location", Subject: "Lunch", Location: &remote.Location{DisplayName: "Planning room"}, Start: at(12)},
+		{ID: "organizer", Subject: "1:1", Organizer: person("Ann Planning", "ann@example.com"), Start: at(10)},
+		{ID: "attendee", Subject: "Review", Attendees: []*remote.Attendee{person("Bob", "bob@planning.example.com")}, Start: at(11)},
+		{ID: "other", Subject: "Standup", Body: &remote.ItemBody{Content: "Daily"}, Start: at(8)},
+	}
+
+	var requests []*http.Request
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) *http.Response {
+		requests = append(requests, r)
+		body, _ := json.Marshal(&calendarViewResponse{Value: events})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}
+	})}
+	c := &client{
+		httpClient: httpClient,
+		rbuilder:   msgraph.NewClient(httpClient),
+	}
+
+	ids := func(events []*remote.Event) []string {
+		result := []string{}
+		for _, e := range events {
+			result = append(result, e.ID)
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name     string
+		top      int
+		skip     int
+		expected []string
+	}{
+		{name: "All matches by start", expected: []string{"body", "organizer", "attendee", "location", "subject"}},
+		{name: "First page", top: 2, expected: []string{"body", "organizer"}},
+		{name: "Last page", top: 2, skip: 4, expected: []string{"subject"}},
+		{name: "After the last page", top: 2, skip: 6, expected: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = nil
+			found, incomplete, err := c.SearchEvents("user_remote_id", &remote.SearchEventsParams{
+				Query:     "PLANNING",
+				StartTime: day,
+				EndTime:   day.Add(24 * time.Hour),
+				Top:       tc.top,
+				Skip:      tc.skip,
+			})
+			require.NoError(t, err)
+			require.False(t, incomplete)
+			require.Equal(t, tc.expected, ids(found))
+
+			require.Len(t, requests, 1)
+			require.Equal(t, "/v1.0/users/user_remote_id/calendarView", requests[0].URL.Path)
+			require.Equal(t, day.Format(time.RFC3339), requests[0].URL.Query().Get("startDateTime"))
+			require.Equal(t, `outlook.body-content-type="text"`, requests[0].Header.Get("Prefer"))
+		})
+	}
+
+	_, _, err := c.SearchEvents("user_remote_id", &remote.SearchEventsParams{Query: "planning"})
+	require.Error(t, err)
+}
+
+func TestSearchEventsIncomplete(t *testing.T) {
+	day := time.Date(2020, 2, 12, 0, 0, 0, 0, time.UTC)
+	pages := 0
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) *http.Response {
+		pages++
+		body, _ := json.Marshal(&calendarViewResponse{
+			Value:    []*remote.Event{{ID: "event", Subject: "Planning", Start: remote.NewDateTime(day, "UTC")}},
+			NextLink: "https://graph.microsoft.com/v1.0/users/user_remote_id/calendarView?$skip=" + strconv.Itoa(pages),
+		})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}
+	})}
+	c := &client{
+		httpClient: httpClient,
+		rbuilder:   msgraph.NewClient(httpClient),
+	}
+
+	found, incomplete, err := c.SearchEvents("user_remote_id", &remote.SearchEventsParams{
+		Query:     "planning",
+		StartTime: day,
+		EndTime:   day.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, incomplete)
+	require.Len(t, found, calendarViewMaxPages)
+	require.Equal(t, calendarViewMaxPages, pages)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package msgraph
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// UpdateEvent updates the fields set in the event
+func (c *client) UpdateEvent(remoteUserID string, in *remote.Event) (*remote.Event, error) {
+	patch := *in
+	patch.ID = ""
+
+	var out = remote.Event{}
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(in.ID).Request().JSONRequest(c.ctx, http.MethodPatch, "", &patch, &out)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph UpdateEvent")
+	}
+	return &out, nil
+}
+
+// CancelEvent cancels a meeting organized by the user, sending the message to the attendees
+func (c *client) CancelEvent(remoteUserID, eventID, message string) error {
+	req := &struct {
+		Comment string `json:"comment,omitempty"`
+	}{message}
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Request().JSONRequest(c.ctx, http.MethodPost, "/cancel", req, nil)
+	if err != nil && !isAcceptedError(err) {
+		return errors.Wrap(err, "msgraph CancelEvent")
+	}
+	return nil
+}
+
+// DeleteEvent removes the event from the user's calendar
+func (c *client) DeleteEvent(remoteUserID, eventID string) error {
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Request().Delete(c.ctx)
+	if err != nil {
+		return errors.Wrap(err, "msgraph DeleteEvent")
+	}
+	return nil
+}
@@ -3,6 +3,12 @@
 
 package remote
 
+const (
+	ChangeTypeCreated = "created"
+	ChangeTypeUpdated = "updated"
+	ChangeTypeDeleted = "deleted"
+)
+
 type Notification struct {
 	// Notification type
 	ChangeType string
@@ -29,7 +35,7 @@ type Notification struct {
 	WebhookRawData []byte
 	Webhook        interface{}
 
-	// Notification data
+	// Notification data. The Event of a deleted event only has its ID.
 	Subscription        *Subscription
 	SubscriptionCreator *User
 	Event               *Event
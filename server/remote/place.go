@@ -0,0 +1,33 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package remote
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrRoomsNotPermitted is returned when the rooms of the organization cannot
+// be looked up, because the Place.Read.All permission, which needs admin
+// consent, has not been granted to the plugin.
+var ErrRoomsNotPermitted = errors.New("meeting rooms cannot be looked up, because the Place.Read.All permission has not been granted to the plugin in Azure. Please ask your system administrator to grant it")
+
+// Room is a meeting room of the organization, bookable by inviting its
+// EmailAddress to an event.
+type Room struct {
+	ID           string `json:"id"`
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	Capacity     int    `json:"capacity,omitempty"`
+	Building     string `json:"building,omitempty"`
+	FloorLabel   string `json:"floorLabel,omitempty"`
+	FloorNumber  *int   `json:"floorNumber,omitempty"`
+	BookingType  string `json:"bookingType,omitempty"`
+}
+
+// RoomList groups the rooms of a building or an area.
+type RoomList struct {
+	ID           string `json:"id"`
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
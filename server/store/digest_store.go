@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/kvstore"
+)
+
+// Digest holds the event notifications of a user that are waiting to be
+// posted together.
+type Digest struct {
+	Events []*DigestEvent
+
+	// Since is when the first of the pending notifications arrived, and
+	// Timezone the user's time zone then, which the digest times follow.
+	Since    time.Time
+	Timezone string
+}
+
+// DigestEvent is the latest version of an event, with the important changes
+// since it was last notified.
+type DigestEvent struct {
+	Event   *remote.Event
+	New     bool     `json:",omitempty"`
+	Changes []string `json:",omitempty"`
+}
+
+type DigestStore interface {
+	LoadDigest(mattermostUserID string) (*Digest, error)
+	ModifyDigest(mattermostUserID string, modify func(digest *Digest)) error
+	TakeDigest(mattermostUserID string, isDue func(digest *Digest) bool) (*Digest, error)
+}
+
+func (s *pluginStore) LoadDigest(mattermostUserID string) (*Digest, error) {
+	digest := Digest{}
+	err := kvstore.LoadJSON(s.digestKV, mattermostUserID, &digest)
+	if err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
+
+// ModifyDigest changes the digest of the user atomically, so notifications
+// added concurrently are not lost. modify gets an empty digest when there is
+// none, and the digest is deleted when it has no events left.
+func (s *pluginStore) ModifyDigest(mattermostUserID string, modify func(digest *Digest)) error {
+	return kvstore.AtomicModify(s.digestKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		digest := &Digest{}
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		modify(digest)
+		if len(digest.Events) == 0 {
+			return nil, nil
+		}
+		return json.Marshal(digest)
+	})
+}
+
+// TakeDigest deletes the digest of the user and returns it, atomically, when
+// isDue tells it is due. It returns nil when there is no digest, or when it is
+// not due.
+func (s *pluginStore) TakeDigest(mattermostUserID string, isDue func(digest *Digest) bool) (*Digest, error) {
+	var taken *Digest
+	err := kvstore.AtomicModify(s.digestKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		taken = nil
+		if storeErr == ErrNotFound {
+			return nil, nil
+		}
+		if storeErr != nil {
+			return initial, storeErr
+		}
+
+		digest := &Digest{}
+		err := json.Unmarshal(initial, digest)
+		if err != nil {
+			return nil, err
+		}
+		if !isDue(digest) {
+			return initial, nil
+		}
+		taken = digest
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return taken, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+)
+
+// interleavingKV is a KV store in memory that runs beforeStore once, right
+// before the next atomic write, like a concurrent change would.
+type interleavingKV struct {
+	data        map[string][]byte
+	beforeStore func()
+}
+
+func (kv *interleavingKV) Load(key string) ([]byte, error) {
+	data, ok := kv.data[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (kv *interleavingKV) Store(key string, data []byte) error {
+	kv.data[key] = data
+	return nil
+}
+
+func (kv *interleavingKV) StoreTTL(key string, data []byte, ttlSeconds int64) error {
+	return kv.Store(key, data)
+}
+
+func (kv *interleavingKV) StoreWithOptions(key string, value []byte, opts model.PluginKVSetOptions) (bool, error) {
+	if kv.beforeStore != nil {
+		beforeStore := kv.beforeStore
+		kv.beforeStore = nil
+		beforeStore()
+	}
+	if opts.Atomic && !bytes.Equal(kv.data[key], opts.OldValue) {
+		return false, nil
+	}
+	if value == nil {
+		delete(kv.data, key)
+		return true, nil
+	}
+	kv.data[key] = value
+	return true, nil
+}
+
+func (kv *interleavingKV) Delete(key string) error {
+	delete(kv.data, key)
+	return nil
+}
+
+func TestDigestInterleaving(t *testing.T) {
+	add := func(s *pluginStore, uid string) {
+		err := s.ModifyDigest("user_mm_id", func(digest *Digest) {
+			digest.Events = append(digest.Events, &DigestEvent{Event: &remote.Event{ICalUID: uid}})
+		})
+		require.NoError(t, err)
+	}
+	take := func(s *pluginStore) *Digest {
+		digest, err := s.TakeDigest("user_mm_id", func(*Digest) bool { return true })
+		require.NoError(t, err)
+		return digest
+	}
+	uids := func(digest *Digest) []string {
+		result := []string{}
+		for _, de := range digest.Events {
+			result = append(result, de.Event.ICalUID)
+		}
+		return result
+	}
+
+	t.Run("Added while taking", func(t *testing.T) {
+		kv := &interleavingKV{data: map[string][]byte{}}
+		s := &pluginStore{digestKV: kv}
+		add(s, "first")
+
+		kv.beforeStore = func() { add(s, "second") }
+		require.Equal(t, []string{"first", "second"}, uids(take(s)))
+		_, err := s.LoadDigest("user_mm_id")
+		require.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Taken while adding", func(t *testing.T) {
+		kv := &interleavingKV{data: map[string][]byte{}}
+		s := &pluginStore{digestKV: kv}
+		add(s, "first")
+
+		var taken *Digest
+		kv.beforeStore = func() { taken = take(s) }
+		add(s, "second")
+		require.Equal(t, []string{"first"}, uids(taken))
+		digest, err := s.LoadDigest("user_mm_id")
+		require.NoError(t, err)
+		require.Equal(t, []string{"second"}, uids(digest))
+	})
+
+	t.Run("Not due", func(t *testing.T) {
+		kv := &interleavingKV{data: map[string][]byte{}}
+		s := &pluginStore{digestKV: kv}
+		add(s, "first")
+
+		digest, err := s.TakeDigest("user_mm_id", func(*Digest) bool { return false })
+		require.NoError(t, err)
+		require.Nil(t, digest)
+		digest, err = s.LoadDigest("user_mm_id")
+		require.NoError(t, err)
+		require.Equal(t, []string{"first"}, uids(digest))
+
+		digest, err = s.TakeDigest("other_mm_id", func(*Digest) bool { return true })
+		require.NoError(t, err)
+		require.Nil(t, digest)
+	})
+}
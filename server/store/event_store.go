@@ -21,16 +21,41 @@ const defaultEventTTL = 30 * 24 * time.Hour  // 30 days
 type Event struct {
 	PluginVersion string
 	Remote        *remote.Event
+
+	// RSVPTrackerPostID is the post showing the responses of the attendees to
+	// the organizer, if it was posted.
+	RSVPTrackerPostID string `json:",omitempty"`
+
+	// PostIDs are the notifications of the event whose response buttons go
+	// stale when it is cancelled.
+	PostIDs []string `json:",omitempty"`
+
+	// NotificationPostID is the last notification of the event, which later
+	// notifications update in place, listing the ChangeHistory.
+	NotificationPostID string   `json:",omitempty"`
+	ChangeHistory      []string `json:",omitempty"`
 }
 
 type EventStore interface {
 	LoadUserEvent(mattermostUserID, eventID string) (*Event, error)
 	StoreUserEvent(mattermostUserID string, event *Event) error
 	DeleteUserEvent(mattermostUserID, eventID string) error
+
+	// LoadUserEventByRemoteID finds an event by its remote ID rather than its
+	// iCalendar UID, as notifications of deleted events only have the former.
+	LoadUserEventByRemoteID(mattermostUserID, remoteID string) (*Event, error)
 }
 
 func eventKey(mattermostUserID, eventID string) string { return mattermostUserID + "_" + eventID }
 
+func (s *pluginStore) LoadUserEventByRemoteID(mattermostUserID, remoteID string) (*Event, error) {
+	iCalUID, err := s.eventRemoteIDKV.Load(eventKey(mattermostUserID, remoteID))
+	if err != nil {
+		return nil, err
+	}
+	return s.LoadUserEvent(mattermostUserID, string(iCalUID))
+}
+
 func (s *pluginStore) LoadUserEvent(mattermostUserID, eventID string) (*Event, error) {
 	event := Event{}
 	err := kvstore.LoadJSON(s.eventKV, eventKey(mattermostUserID, eventID), &event)
@@ -60,6 +85,10 @@ func (s *pluginStore) StoreUserEvent(mattermostUserID string, event *Event) erro
 	if err != nil {
 		return err
 	}
+	err = s.eventRemoteIDKV.StoreTTL(eventKey(mattermostUserID, event.Remote.ID), []byte(event.Remote.ICalUID), ttl)
+	if err != nil {
+		return err
+	}
 
 	s.Logger.With(bot.LogContext{
 		"mattermostUserID": mattermostUserID,
@@ -71,6 +100,13 @@ func (s *pluginStore) StoreUserEvent(mattermostUserID string, event *Event) erro
 }
 
 func (s *pluginStore) DeleteUserEvent(mattermostUserID, eventID string) error {
+	if event, err := s.LoadUserEvent(mattermostUserID, eventID); err == nil && event.Remote != nil {
+		err = s.eventRemoteIDKV.Delete(eventKey(mattermostUserID, event.Remote.ID))
+		if err != nil {
+			return err
+		}
+	}
+
 	err := s.eventKV.Delete(eventKey(mattermostUserID, eventID))
 	if err != nil {
 		return err
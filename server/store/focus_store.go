@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/kvstore"
+)
+
+// FocusTime holds the focus time events of a user, during which the user is
+// set to Do Not Disturb and DMs are answered automatically, and NextBooking,
+// when the daily focus time is booked next. It is kept apart from the user,
+// so the focus time job does not overwrite the changes to the user.
+type FocusTime struct {
+	Sessions    []*FocusSession `json:"sessions,omitempty"`
+	NextBooking time.Time       `json:"next_booking,omitempty"`
+}
+
+// FocusSession is a focus time event. PriorStatus is the status restored when
+// it ends, once Started.
+type FocusSession struct {
+	EventID     string    `json:"event_id"`
+	ICalUID     string    `json:"ical_uid"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Started     bool      `json:"started,omitempty"`
+	PriorStatus string    `json:"prior_status,omitempty"`
+}
+
+type FocusStore interface {
+	LoadFocusTime(mattermostUserID string) (*FocusTime, error)
+	ModifyFocusTime(mattermostUserID string, modify func(focus *FocusTime)) error
+}
+
+// LoadFocusTime returns an empty FocusTime when the user has none.
+func (s *pluginStore) LoadFocusTime(mattermostUserID string) (*FocusTime, error) {
+	focus := FocusTime{}
+	err := kvstore.LoadJSON(s.focusKV, mattermostUserID, &focus)
+	if err == ErrNotFound {
+		return &FocusTime{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &focus, nil
+}
+
+// ModifyFocusTime changes the focus time of the user atomically. modify gets
+// an empty FocusTime when the user has none.
+func (s *pluginStore) ModifyFocusTime(mattermostUserID string, modify func(focus *FocusTime)) error {
+	return kvstore.AtomicModify(s.focusKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		focus := &FocusTime{}
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, focus)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		modify(focus)
+		if len(focus.Sessions) == 0 && focus.NextBooking.IsZero() {
+			return nil, nil
+		}
+		return json.Marshal(focus)
+	})
+}
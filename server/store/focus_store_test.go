@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusTimeInterleaving(t *testing.T) {
+	kv := &interleavingKV{data: map[string][]byte{}}
+	s := &pluginStore{focusKV: kv}
+	now := time.Now().UTC()
+
+	err := s.ModifyFocusTime("user_mm_id", func(focus *FocusTime) {
+		focus.Sessions = append(focus.Sessions, &FocusSession{EventID: "first", Start: now, End: now.Add(time.Hour)})
+	})
+	require.NoError(t, err)
+
+	kv.beforeStore = func() {
+		err = s.ModifyFocusTime("user_mm_id", func(focus *FocusTime) {
+			focus.Sessions = append(focus.Sessions, &FocusSession{EventID: "second", Start: now, End: now.Add(time.Hour)})
+		})
+		require.NoError(t, err)
+	}
+	err = s.ModifyFocusTime("user_mm_id", func(focus *FocusTime) {
+		for _, session := range focus.Sessions {
+			session.Started = true
+		}
+		focus.NextBooking = now.Add(24 * time.Hour)
+	})
+	require.NoError(t, err)
+
+	focus, err := s.LoadFocusTime("user_mm_id")
+	require.NoError(t, err)
+	require.Len(t, focus.Sessions, 2)
+	require.True(t, focus.Sessions[0].Started)
+	require.True(t, focus.Sessions[1].Started)
+	require.Equal(t, now.Add(24*time.Hour), focus.NextBooking)
+
+	err = s.ModifyFocusTime("user_mm_id", func(focus *FocusTime) {
+		focus.Sessions = nil
+		focus.NextBooking = time.Time{}
+	})
+	require.NoError(t, err)
+	_, ok := kv.data["user_mm_id"]
+	require.False(t, ok)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+)
+
+// ICSImportStore remembers the events imported from iCalendar files, by UID,
+// since Microsoft gives the created events UIDs of their own.
+type ICSImportStore interface {
+	LoadUserImportedEventID(mattermostUserID, uid string) (string, error)
+	StoreUserImportedEventID(mattermostUserID, uid, eventID string, eventEnd time.Time) error
+}
+
+func icsImportKey(mattermostUserID, uid string) string { return mattermostUserID + "_" + uid }
+
+func (s *pluginStore) LoadUserImportedEventID(mattermostUserID, uid string) (string, error) {
+	data, err := s.icsImportKV.Load(icsImportKey(mattermostUserID, uid))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// StoreUserImportedEventID keeps the record until ttlAfterEventEnd after the
+// event ends, or forever for a zero eventEnd, as series without an end have.
+func (s *pluginStore) StoreUserImportedEventID(mattermostUserID, uid, eventID string, eventEnd time.Time) error {
+	var err error
+	if eventEnd.IsZero() {
+		err = s.icsImportKV.Store(icsImportKey(mattermostUserID, uid), []byte(eventID))
+	} else {
+		end := eventEnd.Add(ttlAfterEventEnd)
+		if end.Before(time.Now()) {
+			// no point storing expired keys
+			return nil
+		}
+		err = s.icsImportKV.StoreTTL(icsImportKey(mattermostUserID, uid), []byte(eventID), int64(time.Until(end).Seconds()))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.Logger.With(bot.LogContext{
+		"mattermostUserID": mattermostUserID,
+		"eventID":          eventID,
+	}).Debugf("store: stored imported event.")
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/kvstore"
+)
+
+// ChannelBinding has a thread posted in a channel for each occurrence of an
+// event the user organizes, LeadTime before it starts.
+type ChannelBinding struct {
+	ICalUID     string        `json:"ical_uid"`
+	Subject     string        `json:"subject"`
+	ChannelID   string        `json:"channel_id"`
+	ChannelName string        `json:"channel_name"`
+	LeadTime    time.Duration `json:"lead_time"`
+}
+
+// MeetingThread is a thread posted for an occurrence of a bound event, whose
+// organizer is asked for the notes once the occurrence ends.
+type MeetingThread struct {
+	OccurrenceID string    `json:"occurrence_id"`
+	ChannelID    string    `json:"channel_id"`
+	PostID       string    `json:"post_id"`
+	End          time.Time `json:"end"`
+}
+
+type MeetingThreadStore interface {
+	LoadMeetingThreads(mattermostUserID string) ([]*MeetingThread, error)
+	StoreMeetingThreads(mattermostUserID string, threads []*MeetingThread) error
+}
+
+func (s *pluginStore) LoadMeetingThreads(mattermostUserID string) ([]*MeetingThread, error) {
+	threads := []*MeetingThread{}
+	err := kvstore.LoadJSON(s.meetingThreadKV, mattermostUserID, &threads)
+	if err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+func (s *pluginStore) StoreMeetingThreads(mattermostUserID string, threads []*MeetingThread) error {
+	if len(threads) == 0 {
+		return s.meetingThreadKV.Delete(mattermostUserID)
+	}
+	return kvstore.StoreJSON(s.meetingThreadKV, mattermostUserID, threads)
+}
@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	store "github.com/mattermost/mattermost-plugin-mscalendar/server/store"
 	reflect "reflect"
+	time "time"
 )
 
 // MockStore is a mock of Store interface
@@ -103,6 +104,34 @@ func (mr *MockStoreMockRecorder) DeleteUserFromIndex(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserFromIndex", reflect.TypeOf((*MockStore)(nil).DeleteUserFromIndex), arg0)
 }
 
+// DeleteUserSnoozedReminder mocks base method
+func (m *MockStore) DeleteUserSnoozedReminder(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSnoozedReminder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSnoozedReminder indicates an expected call of DeleteUserSnoozedReminder
+func (mr *MockStoreMockRecorder) DeleteUserSnoozedReminder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSnoozedReminder", reflect.TypeOf((*MockStore)(nil).DeleteUserSnoozedReminder), arg0, arg1)
+}
+
+// DeleteUserSnoozedReminders mocks base method
+func (m *MockStore) DeleteUserSnoozedReminders(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSnoozedReminders", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSnoozedReminders indicates an expected call of DeleteUserSnoozedReminders
+func (mr *MockStoreMockRecorder) DeleteUserSnoozedReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSnoozedReminders", reflect.TypeOf((*MockStore)(nil).DeleteUserSnoozedReminders), arg0)
+}
+
 // DeleteUserSubscription mocks base method
 func (m *MockStore) DeleteUserSubscription(arg0 *store.User, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -192,6 +221,51 @@ func (mr *MockStoreMockRecorder) GetSetting(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetting", reflect.TypeOf((*MockStore)(nil).GetSetting), arg0, arg1)
 }
 
+// IsUserReminderSent mocks base method
+func (m *MockStore) IsUserReminderSent(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserReminderSent", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserReminderSent indicates an expected call of IsUserReminderSent
+func (mr *MockStoreMockRecorder) IsUserReminderSent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserReminderSent", reflect.TypeOf((*MockStore)(nil).IsUserReminderSent), arg0, arg1)
+}
+
+// LoadDigest mocks base method
+func (m *MockStore) LoadDigest(arg0 string) (*store.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDigest", arg0)
+	ret0, _ := ret[0].(*store.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadDigest indicates an expected call of LoadDigest
+func (mr *MockStoreMockRecorder) LoadDigest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDigest", reflect.TypeOf((*MockStore)(nil).LoadDigest), arg0)
+}
+
+// LoadFocusTime mocks base method
+func (m *MockStore) LoadFocusTime(arg0 string) (*store.FocusTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFocusTime", arg0)
+	ret0, _ := ret[0].(*store.FocusTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFocusTime indicates an expected call of LoadFocusTime
+func (mr *MockStoreMockRecorder) LoadFocusTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFocusTime", reflect.TypeOf((*MockStore)(nil).LoadFocusTime), arg0)
+}
+
 // LoadMattermostUserID mocks base method
 func (m *MockStore) LoadMattermostUserID(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +281,21 @@ func (mr *MockStoreMockRecorder) LoadMattermostUserID(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMattermostUserID", reflect.TypeOf((*MockStore)(nil).LoadMattermostUserID), arg0)
 }
 
+// LoadMeetingThreads mocks base method
+func (m *MockStore) LoadMeetingThreads(arg0 string) ([]*store.MeetingThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadMeetingThreads", arg0)
+	ret0, _ := ret[0].([]*store.MeetingThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadMeetingThreads indicates an expected call of LoadMeetingThreads
+func (mr *MockStoreMockRecorder) LoadMeetingThreads(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMeetingThreads", reflect.TypeOf((*MockStore)(nil).LoadMeetingThreads), arg0)
+}
+
 // LoadSubscription mocks base method
 func (m *MockStore) LoadSubscription(arg0 string) (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -252,6 +341,21 @@ func (mr *MockStoreMockRecorder) LoadUserEvent(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserEvent", reflect.TypeOf((*MockStore)(nil).LoadUserEvent), arg0, arg1)
 }
 
+// LoadUserEventByRemoteID mocks base method
+func (m *MockStore) LoadUserEventByRemoteID(arg0, arg1 string) (*store.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserEventByRemoteID", arg0, arg1)
+	ret0, _ := ret[0].(*store.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserEventByRemoteID indicates an expected call of LoadUserEventByRemoteID
+func (mr *MockStoreMockRecorder) LoadUserEventByRemoteID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserEventByRemoteID", reflect.TypeOf((*MockStore)(nil).LoadUserEventByRemoteID), arg0, arg1)
+}
+
 // LoadUserFromIndex mocks base method
 func (m *MockStore) LoadUserFromIndex(arg0 string) (*store.UserShort, error) {
 	m.ctrl.T.Helper()
@@ -267,6 +371,21 @@ func (mr *MockStoreMockRecorder) LoadUserFromIndex(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserFromIndex", reflect.TypeOf((*MockStore)(nil).LoadUserFromIndex), arg0)
 }
 
+// LoadUserImportedEventID mocks base method
+func (m *MockStore) LoadUserImportedEventID(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserImportedEventID", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserImportedEventID indicates an expected call of LoadUserImportedEventID
+func (mr *MockStoreMockRecorder) LoadUserImportedEventID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserImportedEventID", reflect.TypeOf((*MockStore)(nil).LoadUserImportedEventID), arg0, arg1)
+}
+
 // LoadUserIndex mocks base method
 func (m *MockStore) LoadUserIndex() (store.UserIndex, error) {
 	m.ctrl.T.Helper()
@@ -282,6 +401,21 @@ func (mr *MockStoreMockRecorder) LoadUserIndex() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserIndex", reflect.TypeOf((*MockStore)(nil).LoadUserIndex))
 }
 
+// LoadUserSnoozedReminders mocks base method
+func (m *MockStore) LoadUserSnoozedReminders(arg0 string) ([]*store.SnoozedReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserSnoozedReminders", arg0)
+	ret0, _ := ret[0].([]*store.SnoozedReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserSnoozedReminders indicates an expected call of LoadUserSnoozedReminders
+func (mr *MockStoreMockRecorder) LoadUserSnoozedReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserSnoozedReminders", reflect.TypeOf((*MockStore)(nil).LoadUserSnoozedReminders), arg0)
+}
+
 // LoadUserWelcomePost mocks base method
 func (m *MockStore) LoadUserWelcomePost(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -297,6 +431,34 @@ func (mr *MockStoreMockRecorder) LoadUserWelcomePost(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserWelcomePost", reflect.TypeOf((*MockStore)(nil).LoadUserWelcomePost), arg0)
 }
 
+// ModifyDigest mocks base method
+func (m *MockStore) ModifyDigest(arg0 string, arg1 func(*store.Digest)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyDigest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyDigest indicates an expected call of ModifyDigest
+func (mr *MockStoreMockRecorder) ModifyDigest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyDigest", reflect.TypeOf((*MockStore)(nil).ModifyDigest), arg0, arg1)
+}
+
+// ModifyFocusTime mocks base method
+func (m *MockStore) ModifyFocusTime(arg0 string, arg1 func(*store.FocusTime)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyFocusTime", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyFocusTime indicates an expected call of ModifyFocusTime
+func (mr *MockStoreMockRecorder) ModifyFocusTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyFocusTime", reflect.TypeOf((*MockStore)(nil).ModifyFocusTime), arg0, arg1)
+}
+
 // ModifyUserIndex mocks base method
 func (m *MockStore) ModifyUserIndex(arg0 func(store.UserIndex) (store.UserIndex, error)) error {
 	m.ctrl.T.Helper()
@@ -395,6 +557,34 @@ func (mr *MockStoreMockRecorder) SetSetting(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSetting", reflect.TypeOf((*MockStore)(nil).SetSetting), arg0, arg1, arg2)
 }
 
+// StoreDailySummaryPostTime mocks base method
+func (m *MockStore) StoreDailySummaryPostTime(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreDailySummaryPostTime", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreDailySummaryPostTime indicates an expected call of StoreDailySummaryPostTime
+func (mr *MockStoreMockRecorder) StoreDailySummaryPostTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreDailySummaryPostTime", reflect.TypeOf((*MockStore)(nil).StoreDailySummaryPostTime), arg0, arg1)
+}
+
+// StoreMeetingThreads mocks base method
+func (m *MockStore) StoreMeetingThreads(arg0 string, arg1 []*store.MeetingThread) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMeetingThreads", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMeetingThreads indicates an expected call of StoreMeetingThreads
+func (mr *MockStoreMockRecorder) StoreMeetingThreads(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMeetingThreads", reflect.TypeOf((*MockStore)(nil).StoreMeetingThreads), arg0, arg1)
+}
+
 // StoreOAuth2State mocks base method
 func (m *MockStore) StoreOAuth2State(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -451,6 +641,20 @@ func (mr *MockStoreMockRecorder) StoreUserEvent(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserEvent", reflect.TypeOf((*MockStore)(nil).StoreUserEvent), arg0, arg1)
 }
 
+// StoreUserImportedEventID mocks base method
+func (m *MockStore) StoreUserImportedEventID(arg0, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserImportedEventID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserImportedEventID indicates an expected call of StoreUserImportedEventID
+func (mr *MockStoreMockRecorder) StoreUserImportedEventID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserImportedEventID", reflect.TypeOf((*MockStore)(nil).StoreUserImportedEventID), arg0, arg1, arg2, arg3)
+}
+
 // StoreUserInIndex mocks base method
 func (m *MockStore) StoreUserInIndex(arg0 *store.User) error {
 	m.ctrl.T.Helper()
@@ -465,6 +669,34 @@ func (mr *MockStoreMockRecorder) StoreUserInIndex(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserInIndex", reflect.TypeOf((*MockStore)(nil).StoreUserInIndex), arg0)
 }
 
+// StoreUserReminderSent mocks base method
+func (m *MockStore) StoreUserReminderSent(arg0, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserReminderSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserReminderSent indicates an expected call of StoreUserReminderSent
+func (mr *MockStoreMockRecorder) StoreUserReminderSent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserReminderSent", reflect.TypeOf((*MockStore)(nil).StoreUserReminderSent), arg0, arg1, arg2)
+}
+
+// StoreUserSnoozedReminder mocks base method
+func (m *MockStore) StoreUserSnoozedReminder(arg0 string, arg1 *store.SnoozedReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserSnoozedReminder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserSnoozedReminder indicates an expected call of StoreUserSnoozedReminder
+func (mr *MockStoreMockRecorder) StoreUserSnoozedReminder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserSnoozedReminder", reflect.TypeOf((*MockStore)(nil).StoreUserSnoozedReminder), arg0, arg1)
+}
+
 // StoreUserSubscription mocks base method
 func (m *MockStore) StoreUserSubscription(arg0 *store.User, arg1 *store.Subscription) error {
 	m.ctrl.T.Helper()
@@ -493,6 +725,35 @@ func (mr *MockStoreMockRecorder) StoreUserWelcomePost(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserWelcomePost", reflect.TypeOf((*MockStore)(nil).StoreUserWelcomePost), arg0, arg1)
 }
 
+// StoreWeeklySummaryPostTime mocks base method
+func (m *MockStore) StoreWeeklySummaryPostTime(arg0 string, arg1 bool, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreWeeklySummaryPostTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreWeeklySummaryPostTime indicates an expected call of StoreWeeklySummaryPostTime
+func (mr *MockStoreMockRecorder) StoreWeeklySummaryPostTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreWeeklySummaryPostTime", reflect.TypeOf((*MockStore)(nil).StoreWeeklySummaryPostTime), arg0, arg1, arg2)
+}
+
+// TakeDigest mocks base method
+func (m *MockStore) TakeDigest(arg0 string, arg1 func(*store.Digest) bool) (*store.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDigest", arg0, arg1)
+	ret0, _ := ret[0].(*store.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeDigest indicates an expected call of TakeDigest
+func (mr *MockStoreMockRecorder) TakeDigest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDigest", reflect.TypeOf((*MockStore)(nil).TakeDigest), arg0, arg1)
+}
+
 // VerifyOAuth2State mocks base method
 func (m *MockStore) VerifyOAuth2State(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,125 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/kvstore"
+)
+
+// Sent reminder records are kept until shortly after the event starts, so the
+// overlapping status sync windows never deliver the same reminder twice.
+const ttlAfterReminderEventStart = time.Hour
+
+type ReminderStore interface {
+	IsUserReminderSent(mattermostUserID, reminderID string) (bool, error)
+	StoreUserReminderSent(mattermostUserID, reminderID string, eventStart time.Time) error
+	LoadUserSnoozedReminders(mattermostUserID string) ([]*SnoozedReminder, error)
+	StoreUserSnoozedReminder(mattermostUserID string, reminder *SnoozedReminder) error
+	DeleteUserSnoozedReminder(mattermostUserID, eventID string) error
+	DeleteUserSnoozedReminders(mattermostUserID string) error
+}
+
+// SnoozedReminder is a reminder the user asked to be delivered again later.
+type SnoozedReminder struct {
+	Event    *remote.Event
+	RemindAt time.Time
+}
+
+func reminderKey(mattermostUserID, reminderID string) string {
+	return mattermostUserID + "_" + reminderID
+}
+
+func (s *pluginStore) IsUserReminderSent(mattermostUserID, reminderID string) (bool, error) {
+	_, err := s.reminderKV.Load(reminderKey(mattermostUserID, reminderID))
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *pluginStore) StoreUserReminderSent(mattermostUserID, reminderID string, eventStart time.Time) error {
+	now := time.Now()
+	end := eventStart.Add(ttlAfterReminderEventStart)
+	if end.Before(now) {
+		// no point storing expired keys
+		return nil
+	}
+
+	ttl := int64(end.Sub(now).Seconds())
+	err := s.reminderKV.StoreTTL(reminderKey(mattermostUserID, reminderID), []byte(now.Format(time.RFC3339)), ttl)
+	if err != nil {
+		return err
+	}
+
+	s.Logger.With(bot.LogContext{
+		"mattermostUserID": mattermostUserID,
+		"reminderID":       reminderID,
+		"expires":          end.String(),
+	}).Debugf("store: stored sent reminder.")
+
+	return nil
+}
+
+func (s *pluginStore) LoadUserSnoozedReminders(mattermostUserID string) ([]*SnoozedReminder, error) {
+	reminders := []*SnoozedReminder{}
+	err := kvstore.LoadJSON(s.snoozedReminderKV, mattermostUserID, &reminders)
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (s *pluginStore) StoreUserSnoozedReminder(mattermostUserID string, reminder *SnoozedReminder) error {
+	return s.modifyUserSnoozedReminders(mattermostUserID, func(reminders []*SnoozedReminder) []*SnoozedReminder {
+		result := []*SnoozedReminder{reminder}
+		for _, r := range reminders {
+			if r.Event.ID != reminder.Event.ID {
+				result = append(result, r)
+			}
+		}
+		return result
+	})
+}
+
+func (s *pluginStore) DeleteUserSnoozedReminder(mattermostUserID, eventID string) error {
+	return s.modifyUserSnoozedReminders(mattermostUserID, func(reminders []*SnoozedReminder) []*SnoozedReminder {
+		result := []*SnoozedReminder{}
+		for _, r := range reminders {
+			if r.Event.ID != eventID {
+				result = append(result, r)
+			}
+		}
+		return result
+	})
+}
+
+func (s *pluginStore) DeleteUserSnoozedReminders(mattermostUserID string) error {
+	return s.snoozedReminderKV.Delete(mattermostUserID)
+}
+
+func (s *pluginStore) modifyUserSnoozedReminders(mattermostUserID string, modify func([]*SnoozedReminder) []*SnoozedReminder) error {
+	return kvstore.AtomicModify(s.snoozedReminderKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var stored []*SnoozedReminder
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &stored)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return json.Marshal(modify(stored))
+	})
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -10,11 +11,99 @@ const (
 	GetConfirmationSettingID            = "get_confirmation"
 	ReceiveNotificationsDuringMeetingID = "receive_notification"
 	ReceiveRemindersSettingID           = "get_reminders"
+	ReminderLeadTimeSettingID           = "reminder_lead_time"
 	DailySummarySettingID               = "summary_setting"
+	WeeklySummarySettingID              = "weekly_summary"
+	NextWeekPreviewSettingID            = "next_week_preview"
 	AutoRespondSettingID                = "auto_respond"
 	AutoRespondMessageSettingID         = "auto_respond_message"
+	CalendarsSettingID                  = "calendars"
+	NotificationsSettingID              = "new_or_updated_event_setting"
+	HighImportanceOnlySettingID         = "high_importance_only"
+	MoreThanAttendeesSettingID          = "more_than_attendees"
+	ImportantChangesSettingID           = "important_changes"
+	MutedNotificationsSettingID         = "muted_notifications"
+	NotificationDigestSettingID         = "notification_digest"
+	NotificationThreadSettingID         = "notification_thread"
+	DailyFocusHoursSettingID            = "daily_focus_hours"
+	OfferICSImportSettingID             = "offer_ics_import"
 )
 
+const (
+	NotificationDigestOff          = "Send immediately"
+	NotificationDigestEveryHour    = "Every hour"
+	NotificationDigestEvery2Hours  = "Every 2 hours"
+	NotificationDigestEvery4Hours  = "Every 4 hours"
+	NotificationDigestMorningNoon  = "At 9:00AM and 1:00PM"
+	NotificationDigestTwiceADay    = "At 9:00AM and 5:00PM"
+	NotificationDigestEveryMorning = "At 9:00AM"
+)
+
+var NotificationDigestOptions = []string{
+	NotificationDigestOff,
+	NotificationDigestEveryHour,
+	NotificationDigestEvery2Hours,
+	NotificationDigestEvery4Hours,
+	NotificationDigestMorningNoon,
+	NotificationDigestTwiceADay,
+	NotificationDigestEveryMorning,
+}
+
+const (
+	ImportantChangeLocation  = "Location"
+	ImportantChangeAttendees = "Attendees"
+	ImportantChangeBody      = "Body"
+)
+
+var ImportantChangeOptions = []string{
+	ImportantChangeLocation,
+	ImportantChangeAttendees,
+	ImportantChangeBody,
+}
+
+const MoreThanAttendeesAny = "Any number"
+
+var MoreThanAttendeesOptions = []string{
+	MoreThanAttendeesAny,
+	"More than 2",
+	"More than 5",
+	"More than 10",
+	"More than 20",
+}
+
+const DailyFocusHoursOff = "Off"
+
+var DailyFocusHoursOptions = []string{
+	DailyFocusHoursOff,
+	"1 hour",
+	"2 hours",
+	"3 hours",
+	"4 hours",
+}
+
+// DefaultCalendarsOption resets the calendars setting to the default calendar.
+const DefaultCalendarsOption = "default"
+
+const (
+	ReminderLeadTime1Minute   = "1 minute"
+	ReminderLeadTime5Minutes  = "5 minutes"
+	ReminderLeadTime10Minutes = "10 minutes"
+	ReminderLeadTime15Minutes = "15 minutes"
+	ReminderLeadTime15And5    = "15 and 5 minutes"
+	ReminderLeadTimeOutlook   = "Use Outlook's reminder"
+
+	DefaultReminderLeadTime = ReminderLeadTime10Minutes
+)
+
+var ReminderLeadTimeOptions = []string{
+	ReminderLeadTime1Minute,
+	ReminderLeadTime5Minutes,
+	ReminderLeadTime10Minutes,
+	ReminderLeadTime15Minutes,
+	ReminderLeadTime15And5,
+	ReminderLeadTimeOutlook,
+}
+
 func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) error {
 	user, err := s.LoadUser(userID)
 	if err != nil {
@@ -47,6 +136,22 @@ func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) er
 			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
 		}
 		user.Settings.ReceiveReminders = storableValue
+		if !storableValue {
+			// Snoozed reminders would keep coming otherwise
+			err = s.DeleteUserSnoozedReminders(userID)
+			if err != nil {
+				return err
+			}
+		}
+	case ReminderLeadTimeSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		if !isReminderLeadTimeOption(storableValue) {
+			return fmt.Errorf("invalid value %s for setting %s", storableValue, settingID)
+		}
+		user.Settings.ReminderLeadTime = storableValue
 	case AutoRespondSettingID:
 		storableValue, ok := value.(bool)
 		if !ok {
@@ -59,8 +164,92 @@ func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) er
 			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
 		}
 		user.Settings.AutoRespondMessage = storableValue
+	case CalendarsSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		user.Settings.CalendarIDs = toggleCalendarID(user.Settings.CalendarIDs, storableValue)
+	case HighImportanceOnlySettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		user.Settings.notificationFilters().HighImportanceOnly = storableValue
+	case MoreThanAttendeesSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		n, ok := parseMoreThanAttendees(storableValue)
+		if !ok {
+			return fmt.Errorf("invalid value %s for setting %s", storableValue, settingID)
+		}
+		user.Settings.notificationFilters().MoreThanAttendees = n
+	case ImportantChangesSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		if !isImportantChangeOption(storableValue) {
+			return fmt.Errorf("invalid value %s for setting %s", storableValue, settingID)
+		}
+		filters := user.Settings.notificationFilters()
+		filters.ImportantChanges = toggleValue(filters.ImportantChanges, storableValue)
+	case NotificationDigestSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		if !isNotificationDigestOption(storableValue) {
+			return fmt.Errorf("invalid value %s for setting %s", storableValue, settingID)
+		}
+		if storableValue == NotificationDigestOff {
+			storableValue = ""
+		}
+		user.Settings.NotificationDigest = storableValue
+	case NotificationThreadSettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		user.Settings.ReplyInNotificationThread = storableValue
+	case OfferICSImportSettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		user.Settings.OfferICSImport = storableValue
+	case DailyFocusHoursSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting string)", value, settingID)
+		}
+		n, ok := parseDailyFocusHours(storableValue)
+		if !ok {
+			return fmt.Errorf("invalid value %s for setting %s", storableValue, settingID)
+		}
+		user.Settings.DailyFocusHours = n
 	case DailySummarySettingID:
 		s.updateDailySummarySettingForUser(user, value)
+	case WeeklySummarySettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		if user.Settings.DailySummary == nil {
+			user.Settings.DailySummary = DefaultDailySummaryUserSettings()
+		}
+		user.Settings.DailySummary.WeeklyEnable = storableValue
+	case NextWeekPreviewSettingID:
+		storableValue, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot read value %v for setting %s (expecting bool)", value, settingID)
+		}
+		if user.Settings.DailySummary == nil {
+			user.Settings.DailySummary = DefaultDailySummaryUserSettings()
+		}
+		user.Settings.DailySummary.NextWeekPreviewEnable = storableValue
 	default:
 		return fmt.Errorf("setting %s not found", settingID)
 	}
@@ -88,18 +277,175 @@ func (s *pluginStore) GetSetting(userID, settingID string) (interface{}, error)
 		return user.Settings.ReceiveNotificationsDuringMeeting, nil
 	case ReceiveRemindersSettingID:
 		return user.Settings.ReceiveReminders, nil
+	case ReminderLeadTimeSettingID:
+		if user.Settings.ReminderLeadTime == "" {
+			return DefaultReminderLeadTime, nil
+		}
+		return user.Settings.ReminderLeadTime, nil
 	case AutoRespondSettingID:
 		return user.Settings.AutoRespond, nil
 	case AutoRespondMessageSettingID:
 		return user.Settings.AutoRespondMessage, nil
+	case CalendarsSettingID:
+		return user.Settings.CalendarIDs, nil
+	case HighImportanceOnlySettingID:
+		filters := user.Settings.NotificationFilters
+		return filters != nil && filters.HighImportanceOnly, nil
+	case MoreThanAttendeesSettingID:
+		filters := user.Settings.NotificationFilters
+		if filters == nil || filters.MoreThanAttendees == 0 {
+			return MoreThanAttendeesAny, nil
+		}
+		return fmt.Sprintf("More than %d", filters.MoreThanAttendees), nil
+	case ImportantChangesSettingID:
+		filters := user.Settings.NotificationFilters
+		if filters == nil {
+			return []string(nil), nil
+		}
+		return filters.ImportantChanges, nil
+	case MutedNotificationsSettingID:
+		return user.Settings.NotificationFilters.MutedString(), nil
+	case NotificationDigestSettingID:
+		if user.Settings.NotificationDigest == "" {
+			return NotificationDigestOff, nil
+		}
+		return user.Settings.NotificationDigest, nil
+	case NotificationThreadSettingID:
+		return user.Settings.ReplyInNotificationThread, nil
+	case OfferICSImportSettingID:
+		return user.Settings.OfferICSImport, nil
+	case DailyFocusHoursSettingID:
+		switch user.Settings.DailyFocusHours {
+		case 0:
+			return DailyFocusHoursOff, nil
+		case 1:
+			return "1 hour", nil
+		default:
+			return fmt.Sprintf("%d hours", user.Settings.DailyFocusHours), nil
+		}
 	case DailySummarySettingID:
 		dsum := user.Settings.DailySummary
 		return dsum, nil
+	case WeeklySummarySettingID:
+		dsum := user.Settings.DailySummary
+		return dsum != nil && dsum.WeeklyEnable, nil
+	case NextWeekPreviewSettingID:
+		dsum := user.Settings.DailySummary
+		return dsum != nil && dsum.NextWeekPreviewEnable, nil
 	default:
 		return nil, fmt.Errorf("setting %s not found", settingID)
 	}
 }
 
+// toggleCalendarID adds the calendar to the selection, or removes it when
+// already selected. DefaultCalendarsOption clears the selection.
+func toggleCalendarID(calendarIDs []string, calendarID string) []string {
+	if calendarID == DefaultCalendarsOption {
+		return nil
+	}
+	return toggleValue(calendarIDs, calendarID)
+}
+
+// toggleValue adds the value to the selection, or removes it when already
+// selected.
+func toggleValue(values []string, value string) []string {
+	selected := []string{}
+	found := false
+	for _, v := range values {
+		if v == value {
+			found = true
+			continue
+		}
+		selected = append(selected, v)
+	}
+	if !found {
+		selected = append(selected, value)
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
+
+// notificationFilters returns the notification filters, creating them when
+// none were set.
+func (settings *Settings) notificationFilters() *NotificationFilters {
+	if settings.NotificationFilters == nil {
+		settings.NotificationFilters = &NotificationFilters{}
+	}
+	return settings.NotificationFilters
+}
+
+// MutedString lists the muted organizers and keywords.
+func (filters *NotificationFilters) MutedString() string {
+	if filters == nil || len(filters.MutedOrganizers)+len(filters.MutedKeywords) == 0 {
+		return "Nothing muted."
+	}
+	muted := []string{}
+	if len(filters.MutedOrganizers) > 0 {
+		muted = append(muted, "organizers: "+strings.Join(filters.MutedOrganizers, ", "))
+	}
+	if len(filters.MutedKeywords) > 0 {
+		muted = append(muted, "keywords: "+strings.Join(filters.MutedKeywords, ", "))
+	}
+	return "Muted " + strings.Join(muted, "; ")
+}
+
+func parseMoreThanAttendees(value string) (int, bool) {
+	if value == MoreThanAttendeesAny {
+		return 0, true
+	}
+	for _, o := range MoreThanAttendeesOptions {
+		if o != value {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(value, "More than "))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func parseDailyFocusHours(value string) (int, bool) {
+	if value == DailyFocusHoursOff {
+		return 0, true
+	}
+	for _, o := range DailyFocusHoursOptions {
+		if o != value {
+			continue
+		}
+		n, err := strconv.Atoi(strings.Fields(value)[0])
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func isImportantChangeOption(value string) bool {
+	for _, o := range ImportantChangeOptions {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotificationDigestOption(value string) bool {
+	for _, o := range NotificationDigestOptions {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isReminderLeadTimeOption(value string) bool {
+	for _, o := range ReminderLeadTimeOptions {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
+
 func DefaultDailySummaryUserSettings() *DailySummaryUserSettings {
 	return &DailySummaryUserSettings{
 		PostTime: "8:00AM",
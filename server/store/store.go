@@ -22,8 +22,15 @@ const (
 	OAuth2KeyPrefix           = "oauth2_"
 	SubscriptionKeyPrefix     = "sub_"
 	EventKeyPrefix            = "ev_"
+	EventRemoteIDKeyPrefix    = "evid_"
 	WelcomeKeyPrefix          = "welcome_"
 	SettingsPanelPrefix       = "settings_panel_"
+	ReminderKeyPrefix         = "reminder_"
+	SnoozedReminderKeyPrefix  = "snoozed_"
+	ICSImportKeyPrefix        = "icsimport_"
+	DigestKeyPrefix           = "digest_"
+	MeetingThreadKeyPrefix    = "meetingthread_"
+	FocusKeyPrefix            = "focus_"
 )
 
 const OAuth2KeyExpiration = 15 * time.Minute
@@ -36,6 +43,11 @@ type Store interface {
 	SubscriptionStore
 	EventStore
 	WelcomeStore
+	ReminderStore
+	ICSImportStore
+	DigestStore
+	MeetingThreadStore
+	FocusStore
 	flow.Store
 	settingspanel.SettingStore
 	settingspanel.PanelStore
@@ -49,8 +61,15 @@ type pluginStore struct {
 	userIndexKV        kvstore.KVStore
 	subscriptionKV     kvstore.KVStore
 	eventKV            kvstore.KVStore
+	eventRemoteIDKV    kvstore.KVStore
 	welcomeIndexKV     kvstore.KVStore
 	settingsPanelKV    kvstore.KVStore
+	reminderKV         kvstore.KVStore
+	snoozedReminderKV  kvstore.KVStore
+	icsImportKV        kvstore.KVStore
+	digestKV           kvstore.KVStore
+	meetingThreadKV    kvstore.KVStore
+	focusKV            kvstore.KVStore
 	Logger             bot.Logger
 	Tracker            tracker.Tracker
 }
@@ -64,9 +83,16 @@ func NewPluginStore(api plugin.API, logger bot.Logger, tracker tracker.Tracker)
 		mattermostUserIDKV: kvstore.NewHashedKeyStore(basicKV, MattermostUserIDKeyPrefix),
 		subscriptionKV:     kvstore.NewHashedKeyStore(basicKV, SubscriptionKeyPrefix),
 		eventKV:            kvstore.NewHashedKeyStore(basicKV, EventKeyPrefix),
+		eventRemoteIDKV:    kvstore.NewHashedKeyStore(basicKV, EventRemoteIDKeyPrefix),
 		oauth2KV:           kvstore.NewHashedKeyStore(kvstore.NewOneTimePluginStore(api, OAuth2KeyExpiration), OAuth2KeyPrefix),
 		welcomeIndexKV:     kvstore.NewHashedKeyStore(basicKV, WelcomeKeyPrefix),
 		settingsPanelKV:    kvstore.NewHashedKeyStore(basicKV, SettingsPanelPrefix),
+		reminderKV:         kvstore.NewHashedKeyStore(basicKV, ReminderKeyPrefix),
+		snoozedReminderKV:  kvstore.NewHashedKeyStore(basicKV, SnoozedReminderKeyPrefix),
+		icsImportKV:        kvstore.NewHashedKeyStore(basicKV, ICSImportKeyPrefix),
+		digestKV:           kvstore.NewHashedKeyStore(basicKV, DigestKeyPrefix),
+		meetingThreadKV:    kvstore.NewHashedKeyStore(basicKV, MeetingThreadKeyPrefix),
+		focusKV:            kvstore.NewHashedKeyStore(basicKV, FocusKeyPrefix),
 		Logger:             logger,
 		Tracker:            tracker,
 	}
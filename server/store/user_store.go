@@ -6,6 +6,7 @@ package store
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"golang.org/x/oauth2"
 
@@ -24,6 +25,8 @@ type UserStore interface {
 	StoreUserInIndex(user *User) error
 	DeleteUserFromIndex(mattermostUserID string) error
 	StoreUserActiveEvents(mattermostUserID string, events []string) error
+	StoreDailySummaryPostTime(mattermostUserID string, postTime time.Time) error
+	StoreWeeklySummaryPostTime(mattermostUserID string, nextWeek bool, postTime time.Time) error
 }
 
 type UserIndex []*UserShort
@@ -43,6 +46,15 @@ type User struct {
 	ActiveEvents      []string `json:"events"`
 	LastStatus        string
 	WelcomeFlowStatus WelcomeFlowStatus `json:"mattermostFlags,omitempty"`
+
+	// Delegates are the users allowed to manage this user's calendar, and
+	// Delegators the users whose calendars this user manages.
+	Delegates  []string `json:"delegates,omitempty"`
+	Delegators []string `json:"delegators,omitempty"`
+
+	// ChannelBindings are the events the user organizes that have a thread
+	// posted in a channel before each occurrence.
+	ChannelBindings []*ChannelBinding `json:"channel_bindings,omitempty"`
 }
 
 type Settings struct {
@@ -50,10 +62,50 @@ type Settings struct {
 	UpdateStatus                      bool
 	GetConfirmation                   bool
 	ReceiveReminders                  bool
+	ReminderLeadTime                  string
 	AutoRespond                       bool
 	AutoRespondMessage                string
 	ReceiveNotificationsDuringMeeting bool
 	DailySummary                      *DailySummaryUserSettings
+
+	// CalendarIDs are the calendars that count for the status, reminders and
+	// summaries. The default calendar is used when empty.
+	CalendarIDs []string
+
+	NotificationFilters *NotificationFilters `json:",omitempty"`
+
+	// NotificationDigest is when the notifications of events are posted
+	// together, as a NotificationDigest option. They are posted immediately
+	// when empty.
+	NotificationDigest string `json:",omitempty"`
+
+	// ReplyInNotificationThread replies to the notification of an event when
+	// it is updated in place, for the change to show as unread.
+	ReplyInNotificationThread bool `json:",omitempty"`
+
+	// DailyFocusHours is the number of hours of focus time booked every
+	// working day in the free time of the calendar. None is booked when 0.
+	DailyFocusHours int `json:",omitempty"`
+
+	// OfferICSImport offers to import the calendar files the user posts in
+	// channels, in a DM. Files sent to the bot are always offered.
+	OfferICSImport bool `json:",omitempty"`
+}
+
+// NotificationFilters select the events the user is notified about when they
+// are created or updated.
+type NotificationFilters struct {
+	// Organizers are email addresses, and keywords are matched in the subject
+	// regardless of case.
+	MutedOrganizers []string `json:"muted_organizers,omitempty"`
+	MutedKeywords   []string `json:"muted_keywords,omitempty"`
+
+	HighImportanceOnly bool `json:"high_importance_only,omitempty"`
+	MoreThanAttendees  int  `json:"more_than_attendees,omitempty"`
+
+	// ImportantChanges are the changes that are notified on top of the
+	// subject and time, as ImportantChange values.
+	ImportantChanges []string `json:"important_changes,omitempty"`
 }
 
 type DailySummaryUserSettings struct {
@@ -61,6 +113,13 @@ type DailySummaryUserSettings struct {
 	PostTime     string `json:"post_time"` // Kitchen format, i.e. 8:30AM
 	Timezone     string `json:"tz"`        // Timezone in MSCal when PostTime is set/updated
 	LastPostTime string `json:"last_post_time"`
+
+	// The weekly summary is posted on Mondays and the next week preview on
+	// Fridays, both at PostTime.
+	WeeklyEnable                bool   `json:"weekly_enable"`
+	NextWeekPreviewEnable       bool   `json:"next_week_preview_enable"`
+	LastWeeklyPostTime          string `json:"last_weekly_post_time"`
+	LastNextWeekPreviewPostTime string `json:"last_next_week_preview_post_time"`
 }
 
 type WelcomeFlowStatus struct {
@@ -238,6 +297,47 @@ func (s *pluginStore) StoreUserActiveEvents(mattermostUserID string, events []st
 	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
 }
 
+// StoreDailySummaryPostTime records when the daily summary was posted,
+// leaving the rest of the user as stored, which the summary jobs running
+// alongside change too.
+func (s *pluginStore) StoreDailySummaryPostTime(mattermostUserID string, postTime time.Time) error {
+	return s.modifyDailySummarySettings(mattermostUserID, func(dsum *DailySummaryUserSettings) {
+		dsum.LastPostTime = postTime.Format(time.RFC3339)
+	})
+}
+
+// StoreWeeklySummaryPostTime records when the weekly summary, or the next
+// week preview, was posted.
+func (s *pluginStore) StoreWeeklySummaryPostTime(mattermostUserID string, nextWeek bool, postTime time.Time) error {
+	return s.modifyDailySummarySettings(mattermostUserID, func(dsum *DailySummaryUserSettings) {
+		if nextWeek {
+			dsum.LastNextWeekPreviewPostTime = postTime.Format(time.RFC3339)
+		} else {
+			dsum.LastWeeklyPostTime = postTime.Format(time.RFC3339)
+		}
+	})
+}
+
+func (s *pluginStore) modifyDailySummarySettings(mattermostUserID string, modify func(dsum *DailySummaryUserSettings)) error {
+	return kvstore.AtomicModify(s.userKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil {
+			return initial, storeErr
+		}
+
+		user := &User{}
+		err := json.Unmarshal(initial, user)
+		if err != nil {
+			return nil, err
+		}
+		if user.Settings.DailySummary == nil {
+			return initial, nil
+		}
+
+		modify(user.Settings.DailySummary)
+		return json.Marshal(user)
+	})
+}
+
 func (index UserIndex) ByMattermostID() map[string]*UserShort {
 	result := map[string]*UserShort{}
 
@@ -0,0 +1,36 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/kvstore"
+)
+
+func TestSummaryPostTimeInterleaving(t *testing.T) {
+	kv := &interleavingKV{data: map[string][]byte{}}
+	s := &pluginStore{userKV: kv}
+	err := kvstore.StoreJSON(kv, "user_mm_id", &User{
+		MattermostUserID: "user_mm_id",
+		Settings:         Settings{DailySummary: DefaultDailySummaryUserSettings()},
+	})
+	require.NoError(t, err)
+
+	dailyTime := time.Date(2020, 2, 10, 9, 0, 0, 0, time.UTC)
+	weeklyTime := dailyTime.Add(time.Second)
+	kv.beforeStore = func() {
+		require.NoError(t, s.StoreWeeklySummaryPostTime("user_mm_id", false, weeklyTime))
+	}
+	require.NoError(t, s.StoreDailySummaryPostTime("user_mm_id", dailyTime))
+
+	user, err := s.LoadUser("user_mm_id")
+	require.NoError(t, err)
+	require.Equal(t, dailyTime.Format(time.RFC3339), user.Settings.DailySummary.LastPostTime)
+	require.Equal(t, weeklyTime.Format(time.RFC3339), user.Settings.DailySummary.LastWeeklyPostTime)
+	require.Empty(t, user.Settings.DailySummary.LastNextWeekPreviewPostTime)
+}
@@ -92,6 +92,26 @@ func (mr *MockPosterMockRecorder) DMWithAttachments(arg0 interface{}, arg1 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DMWithAttachments", reflect.TypeOf((*MockPoster)(nil).DMWithAttachments), varargs...)
 }
 
+// DMWithFile mocks base method
+func (m *MockPoster) DMWithFile(arg0, arg1 string, arg2 []byte, arg3 string, arg4 ...interface{}) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DMWithFile", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DMWithFile indicates an expected call of DMWithFile
+func (mr *MockPosterMockRecorder) DMWithFile(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DMWithFile", reflect.TypeOf((*MockPoster)(nil).DMWithFile), varargs...)
+}
+
 // DeletePost mocks base method
 func (m *MockPoster) DeletePost(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -123,6 +143,46 @@ func (mr *MockPosterMockRecorder) Ephemeral(arg0, arg1, arg2 interface{}, arg3 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ephemeral", reflect.TypeOf((*MockPoster)(nil).Ephemeral), varargs...)
 }
 
+// PostInChannel mocks base method
+func (m *MockPoster) PostInChannel(arg0, arg1, arg2 string, arg3 ...interface{}) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostInChannel", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInChannel indicates an expected call of PostInChannel
+func (mr *MockPosterMockRecorder) PostInChannel(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInChannel", reflect.TypeOf((*MockPoster)(nil).PostInChannel), varargs...)
+}
+
+// PostInChannelWithAttachments mocks base method
+func (m *MockPoster) PostInChannelWithAttachments(arg0, arg1 string, arg2 ...*model.SlackAttachment) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostInChannelWithAttachments", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInChannelWithAttachments indicates an expected call of PostInChannelWithAttachments
+func (mr *MockPosterMockRecorder) PostInChannelWithAttachments(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInChannelWithAttachments", reflect.TypeOf((*MockPoster)(nil).PostInChannelWithAttachments), varargs...)
+}
+
 // UpdatePost mocks base method
 func (m *MockPoster) UpdatePost(arg0 *model.Post) error {
 	m.ctrl.T.Helper()
@@ -18,6 +18,17 @@ type Poster interface {
 	// Often used to include post actions.
 	DMWithAttachments(mattermostUserID string, attachments ...*model.SlackAttachment) (string, error)
 
+	// DMWithFile posts a Direct Message with a file attached
+	DMWithFile(mattermostUserID, fileName string, data []byte, format string, args ...interface{}) (string, error)
+
+	// PostInChannel posts a message to a channel, as a reply to rootID when it
+	// is set
+	PostInChannel(channelID, rootID, format string, args ...interface{}) (string, error)
+
+	// PostInChannelWithAttachments posts Slack attachments to a channel, as a
+	// reply to rootID when it is set
+	PostInChannelWithAttachments(channelID, rootID string, attachments ...*model.SlackAttachment) (string, error)
+
 	// Ephemeral sends an ephemeral message to a user
 	Ephemeral(mattermostUserID, channelID, format string, args ...interface{})
 
@@ -50,6 +61,23 @@ func (bot *bot) DMWithAttachments(mattermostUserID string, attachments ...*model
 	return bot.dm(mattermostUserID, &post)
 }
 
+// DMWithFile posts a Direct Message with a file attached
+func (bot *bot) DMWithFile(mattermostUserID, fileName string, data []byte, format string, args ...interface{}) (string, error) {
+	channel, err := bot.pluginAPI.GetDirectChannel(mattermostUserID, bot.mattermostUserID)
+	if err != nil {
+		bot.pluginAPI.LogInfo("Couldn't get bot's DM channel", "user_id", mattermostUserID)
+		return "", err
+	}
+	fileInfo, err := bot.pluginAPI.UploadFile(data, channel.Id, fileName)
+	if err != nil {
+		return "", err
+	}
+	return bot.dm(mattermostUserID, &model.Post{
+		Message: fmt.Sprintf(format, args...),
+		FileIds: []string{fileInfo.Id},
+	})
+}
+
 func (bot *bot) dm(mattermostUserID string, post *model.Post) (string, error) {
 	channel, err := bot.pluginAPI.GetDirectChannel(mattermostUserID, bot.mattermostUserID)
 	if err != nil {
@@ -78,6 +106,36 @@ func (bot *bot) dmAdmins(format string, args ...interface{}) error {
 	return nil
 }
 
+// PostInChannel posts a message to a channel, as a reply to rootID when it is set
+func (bot *bot) PostInChannel(channelID, rootID, format string, args ...interface{}) (string, error) {
+	sentPost, err := bot.pluginAPI.CreatePost(&model.Post{
+		UserId:    bot.mattermostUserID,
+		ChannelId: channelID,
+		RootId:    rootID,
+		Message:   fmt.Sprintf(format, args...),
+	})
+	if err != nil {
+		return "", err
+	}
+	return sentPost.Id, nil
+}
+
+// PostInChannelWithAttachments posts Slack attachments to a channel, as a reply
+// to rootID when it is set
+func (bot *bot) PostInChannelWithAttachments(channelID, rootID string, attachments ...*model.SlackAttachment) (string, error) {
+	post := &model.Post{
+		UserId:    bot.mattermostUserID,
+		ChannelId: channelID,
+		RootId:    rootID,
+	}
+	model.ParseSlackAttachment(post, attachments)
+	sentPost, err := bot.pluginAPI.CreatePost(post)
+	if err != nil {
+		return "", err
+	}
+	return sentPost.Id, nil
+}
+
 // Ephemeral sends an ephemeral message to a user
 func (bot *bot) Ephemeral(userID, channelID, format string, args ...interface{}) {
 	post := &model.Post{
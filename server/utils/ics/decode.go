@@ -0,0 +1,373 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package ics
+
+import (
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/tz"
+)
+
+// Decode reads the first calendar of an iCalendar file. Times without a time
+// zone are read as UTC. TZIDs that are IANA or Windows names are read as such,
+// others with the VTIMEZONE of the file, at a fixed offset.
+func Decode(r io.Reader) (*Calendar, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseComponents(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var vcalendar *component
+	for _, c := range root.children {
+		if c.name == "VCALENDAR" {
+			vcalendar = c
+			break
+		}
+	}
+	if vcalendar == nil {
+		return nil, errors.New("not an iCalendar file")
+	}
+
+	cal := &Calendar{
+		ProdID: vcalendar.value("PRODID"),
+		Method: strings.ToUpper(vcalendar.value("METHOD")),
+	}
+	d := &decoder{zones: map[string]*vtimezone{}}
+	for _, c := range vcalendar.children {
+		if c.name != "VTIMEZONE" {
+			continue
+		}
+		// Time zones that cannot be read only matter if no known name matches
+		zone, err := decodeTimeZone(c)
+		if err != nil {
+			continue
+		}
+		d.zones[c.value("TZID")] = zone
+	}
+	for _, c := range vcalendar.children {
+		if c.name != "VEVENT" {
+			continue
+		}
+		event, err := d.event(c)
+		if err != nil {
+			return nil, err
+		}
+		cal.Events = append(cal.Events, event)
+	}
+	return cal, nil
+}
+
+type decoder struct {
+	zones map[string]*vtimezone
+	// named are the named locations matching the zones, by TZID and year
+	named map[string]*time.Location
+}
+
+func (d *decoder) event(c *component) (*Event, error) {
+	event := &Event{
+		UID:         c.value("UID"),
+		Summary:     unescapeText(c.value("SUMMARY")),
+		Description: unescapeText(c.value("DESCRIPTION")),
+		Location:    unescapeText(c.value("LOCATION")),
+		URL:         c.value("URL"),
+		Status:      strings.ToUpper(c.value("STATUS")),
+		Transparent: strings.EqualFold(c.value("TRANSP"), "TRANSPARENT"),
+	}
+
+	dtstart := c.property("DTSTART")
+	if dtstart == nil {
+		return nil, errors.Errorf("event %q has no start", event.UID)
+	}
+	var err error
+	event.Start, event.AllDay, err = d.dateTime(dtstart)
+	if err != nil {
+		return nil, err
+	}
+
+	if stamp := c.property("DTSTAMP"); stamp != nil {
+		event.Stamp, _, err = d.dateTime(stamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case c.property("DTEND") != nil:
+		event.End, _, err = d.dateTime(c.property("DTEND"))
+		if err != nil {
+			return nil, err
+		}
+	case c.property("DURATION") != nil:
+		duration, err := parseDuration(c.value("DURATION"))
+		if err != nil {
+			return nil, err
+		}
+		event.End = event.Start.Add(duration)
+	case event.AllDay:
+		event.End = event.Start.AddDate(0, 0, 1)
+	default:
+		event.End = event.Start
+	}
+
+	if rrule := c.value("RRULE"); rrule != "" {
+		event.RRule, err = ParseRecurrenceRule(rrule, event.Start.Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range c.properties {
+		if p.name != "EXDATE" {
+			continue
+		}
+		for _, v := range strings.Split(p.value, ",") {
+			exdate, _, err := d.dateTime(&contentLine{name: p.name, params: p.params, value: v})
+			if err != nil {
+				return nil, err
+			}
+			event.ExDates = append(event.ExDates, exdate)
+		}
+	}
+	if recurrenceID := c.property("RECURRENCE-ID"); recurrenceID != nil {
+		event.RecurrenceID, _, err = d.dateTime(recurrenceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if organizer := c.property("ORGANIZER"); organizer != nil {
+		event.Organizer = decodeAttendee(organizer)
+	}
+	for _, p := range c.properties {
+		if p.name == "ATTENDEE" {
+			event.Attendees = append(event.Attendees, decodeAttendee(p))
+		}
+	}
+	return event, nil
+}
+
+func decodeAttendee(p *contentLine) *Attendee {
+	email := p.value
+	if len(email) > len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+		email = email[len("mailto:"):]
+	}
+	return &Attendee{
+		Name:     p.params["CN"],
+		Email:    email,
+		Role:     strings.ToUpper(p.params["ROLE"]),
+		PartStat: strings.ToUpper(p.params["PARTSTAT"]),
+	}
+}
+
+// dateTime reads a DATE or DATE-TIME value, in UTC, in its TZID or floating.
+func (d *decoder) dateTime(p *contentLine) (t time.Time, isDate bool, err error) {
+	value := p.value
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(value) == len(dateFormat) {
+		t, err = time.ParseInLocation(dateFormat, value, time.UTC)
+		return t, true, errors.Wrapf(err, "invalid date %q", value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.ParseInLocation(utcFormat, value, time.UTC)
+		return t, false, errors.Wrapf(err, "invalid time %q", value)
+	}
+
+	tzid := p.params["TZID"]
+	if tzid == "" {
+		t, err = time.ParseInLocation(dateTimeFormat, value, time.UTC)
+		return t, false, errors.Wrapf(err, "invalid time %q", value)
+	}
+	loc, err := loadLocation(tzid)
+	if err == nil {
+		t, err = time.ParseInLocation(dateTimeFormat, value, loc)
+		return t, false, errors.Wrapf(err, "invalid time %q", value)
+	}
+	zone := d.zones[tzid]
+	if zone == nil {
+		return time.Time{}, false, err
+	}
+	wall, err := time.ParseInLocation(dateTimeFormat, value, time.UTC)
+	if err != nil {
+		return time.Time{}, false, errors.Wrapf(err, "invalid time %q", value)
+	}
+	loc = d.namedLocation(tzid, zone, wall.Year())
+	if loc == nil {
+		loc = zone.location(wall)
+	}
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc), false, nil
+}
+
+// namedLocation finds the named location with the same offsets as the zone
+// during the year, if any.
+func (d *decoder) namedLocation(tzid string, zone *vtimezone, year int) *time.Location {
+	key := tzid + "/" + strconv.Itoa(year)
+	if d.named == nil {
+		d.named = map[string]*time.Location{}
+	}
+	loc, ok := d.named[key]
+	if !ok {
+		loc = zone.namedLocation(year)
+		d.named[key] = loc
+	}
+	return loc
+}
+
+// loadLocation finds a location by its IANA or Windows name.
+func loadLocation(tzid string) (*time.Location, error) {
+	name := tz.Go(strings.TrimPrefix(tzid, "/"))
+	if name == "" {
+		return nil, errors.Errorf("unknown time zone %q", tzid)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.Errorf("unknown time zone %q", tzid)
+	}
+	return loc, nil
+}
+
+var durationRegexp = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration reads a DURATION value, like P1D or PT1H30M.
+func parseDuration(value string) (time.Duration, error) {
+	m := durationRegexp.FindStringSubmatch(strings.ToUpper(value))
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, errors.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	d := time.Duration(0)
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var textUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
+
+type contentLine struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+type component struct {
+	name       string
+	properties []*contentLine
+	children   []*component
+}
+
+func (c *component) property(name string) *contentLine {
+	for _, p := range c.properties {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *component) value(name string) string {
+	p := c.property(name)
+	if p == nil {
+		return ""
+	}
+	return p.value
+}
+
+// parseComponents unfolds the content lines and nests the components they
+// describe under a root component.
+func parseComponents(data string) (*component, error) {
+	data = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(data)
+
+	root := &component{}
+	stack := []*component{root}
+	for _, raw := range strings.Split(data, "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		line, err := parseContentLine(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		current := stack[len(stack)-1]
+		switch line.name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(line.value)}
+			current.children = append(current.children, c)
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 1 || current.name != strings.ToUpper(line.value) {
+				return nil, errors.Errorf("unexpected END:%s", line.value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			current.properties = append(current.properties, line)
+		}
+	}
+	if len(stack) != 1 {
+		return nil, errors.Errorf("missing END:%s", stack[len(stack)-1].name)
+	}
+	return root, nil
+}
+
+// parseContentLine splits a line like NAME;PARAM="a:b";OTHER=c:value. Colons
+// and semicolons within quoted parameter values are not separators.
+func parseContentLine(raw string) (*contentLine, error) {
+	line := &contentLine{params: map[string]string{}}
+
+	inQuotes := false
+	start := 0
+	paramName := ""
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		switch {
+		case ch == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case ch == '=' && line.name != "" && paramName == "":
+			paramName = strings.ToUpper(raw[start:i])
+			start = i + 1
+		case ch == ';' || ch == ':':
+			part := raw[start:i]
+			if line.name == "" {
+				line.name = strings.ToUpper(part)
+			} else if paramName != "" {
+				line.params[paramName] = strings.Trim(part, `"`)
+				paramName = ""
+			}
+			start = i + 1
+			if ch == ':' {
+				line.value = raw[start:]
+				return line, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("invalid content line %q", raw)
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+	utcFormat      = "20060102T150405Z"
+)
+
+// maxLineLength is the length in octets after which content lines are folded.
+const maxLineLength = 75
+
+// Encode writes the calendar. Every named location used by the events is
+// described by a VTIMEZONE covering the years of the events.
+func Encode(w io.Writer, cal *Calendar) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+
+	e.line("BEGIN", nil, "VCALENDAR")
+	e.line("VERSION", nil, "2.0")
+	prodID := cal.ProdID
+	if prodID == "" {
+		prodID = "-//Mattermost//Microsoft Calendar Plugin//EN"
+	}
+	e.line("PRODID", nil, prodID)
+	e.line("CALSCALE", nil, "GREGORIAN")
+	if cal.Method != "" {
+		e.line("METHOD", nil, cal.Method)
+	}
+
+	for _, tz := range collectTimeZones(cal.Events) {
+		e.timeZone(tz)
+	}
+	for _, event := range cal.Events {
+		e.event(event)
+	}
+
+	e.line("END", nil, "VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+type param struct {
+	name  string
+	value string
+}
+
+func (e *encoder) event(event *Event) {
+	e.line("BEGIN", nil, "VEVENT")
+	e.line("UID", nil, event.UID)
+	stamp := event.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	e.line("DTSTAMP", nil, stamp.UTC().Format(utcFormat))
+	e.dateTime("DTSTART", event.Start, event.AllDay)
+	if !event.End.IsZero() {
+		e.dateTime("DTEND", event.End, event.AllDay)
+	}
+	if event.RRule != nil {
+		e.line("RRULE", nil, event.RRule.String())
+	}
+	if !event.RecurrenceID.IsZero() {
+		e.dateTime("RECURRENCE-ID", event.RecurrenceID, event.AllDay)
+	}
+	if event.Summary != "" {
+		e.line("SUMMARY", nil, escapeText(event.Summary))
+	}
+	if event.Description != "" {
+		e.line("DESCRIPTION", nil, escapeText(event.Description))
+	}
+	if event.Location != "" {
+		e.line("LOCATION", nil, escapeText(event.Location))
+	}
+	if event.URL != "" {
+		e.line("URL", nil, event.URL)
+	}
+	if event.Status != "" {
+		e.line("STATUS", nil, event.Status)
+	}
+	if event.Transparent {
+		e.line("TRANSP", nil, "TRANSPARENT")
+	}
+	if event.Organizer != nil {
+		e.line("ORGANIZER", attendeeParams(event.Organizer), "mailto:"+event.Organizer.Email)
+	}
+	for _, a := range event.Attendees {
+		e.line("ATTENDEE", attendeeParams(a), "mailto:"+a.Email)
+	}
+	e.line("END", nil, "VEVENT")
+}
+
+func attendeeParams(a *Attendee) []param {
+	params := []param{}
+	if a.Name != "" {
+		params = append(params, param{"CN", a.Name})
+	}
+	if a.Role != "" {
+		params = append(params, param{"ROLE", a.Role})
+	}
+	if a.PartStat != "" {
+		params = append(params, param{"PARTSTAT", a.PartStat})
+	}
+	return params
+}
+
+func (e *encoder) dateTime(name string, t time.Time, allDay bool) {
+	switch {
+	case allDay:
+		e.line(name, []param{{"VALUE", "DATE"}}, t.Format(dateFormat))
+	case t.Location() == time.UTC:
+		e.line(name, nil, t.Format(utcFormat))
+	default:
+		e.line(name, []param{{"TZID", t.Location().String()}}, t.Format(dateTimeFormat))
+	}
+}
+
+// timeZoneRange is a named location and the years during which it is used.
+type timeZoneRange struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+func collectTimeZones(events []*Event) []*timeZoneRange {
+	byName := map[string]*timeZoneRange{}
+	for _, event := range events {
+		if event.AllDay {
+			continue
+		}
+		for _, t := range []time.Time{event.Start, event.End, event.RecurrenceID} {
+			if t.IsZero() || t.Location() == time.UTC {
+				continue
+			}
+			from := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+			to := from.AddDate(1, 0, 0)
+			tz := byName[t.Location().String()]
+			if tz == nil {
+				byName[t.Location().String()] = &timeZoneRange{loc: t.Location(), from: from, to: to}
+				continue
+			}
+			if from.Before(tz.from) {
+				tz.from = from
+			}
+			if to.After(tz.to) {
+				tz.to = to
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	zones := []*timeZoneRange{}
+	for _, name := range names {
+		zones = append(zones, byName[name])
+	}
+	return zones
+}
+
+// timeZone describes the offsets of the location with one observance for the
+// start of the range and one for each transition within it.
+func (e *encoder) timeZone(tz *timeZoneRange) {
+	e.line("BEGIN", nil, "VTIMEZONE")
+	e.line("TZID", nil, tz.loc.String())
+
+	name, offset := tz.from.In(tz.loc).Zone()
+	e.observance(tz, tz.from, name, offset, offset)
+	for _, t := range transitions(tz.loc, tz.from, tz.to) {
+		_, offsetFrom := t.Add(-time.Second).In(tz.loc).Zone()
+		name, offsetTo := t.In(tz.loc).Zone()
+		e.observance(tz, t, name, offsetFrom, offsetTo)
+	}
+
+	e.line("END", nil, "VTIMEZONE")
+}
+
+func (e *encoder) observance(tz *timeZoneRange, t time.Time, name string, offsetFrom, offsetTo int) {
+	kind := "STANDARD"
+	if offsetTo > standardOffset(tz.loc, t.Year()) {
+		kind = "DAYLIGHT"
+	}
+	e.line("BEGIN", nil, kind)
+	e.line("DTSTART", nil, t.In(time.FixedZone("", offsetFrom)).Format(dateTimeFormat))
+	e.line("TZOFFSETFROM", nil, formatOffset(offsetFrom))
+	e.line("TZOFFSETTO", nil, formatOffset(offsetTo))
+	if name != "" {
+		e.line("TZNAME", nil, escapeText(name))
+	}
+	e.line("END", nil, kind)
+}
+
+// standardOffset is the smallest of the offsets in the middle of winter and of
+// summer, in either hemisphere.
+func standardOffset(loc *time.Location, year int) int {
+	_, january := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, july := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	if january < july {
+		return january
+	}
+	return july
+}
+
+// transitions finds the instants in [from, to) at which the offset of the
+// location changes. Offsets are assumed to last at least a day.
+func transitions(loc *time.Location, from, to time.Time) []time.Time {
+	found := []time.Time{}
+	_, offset := from.In(loc).Zone()
+	for t := from; t.Before(to); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset == offset {
+			continue
+		}
+
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if _, o := mid.In(loc).Zone(); o == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		found = append(found, hi)
+		offset = nextOffset
+	}
+	return found
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// line writes a content line, folded to lines of at most 75 octets.
+func (e *encoder) line(name string, params []param, value string) {
+	if e.err != nil {
+		return
+	}
+
+	s := name
+	for _, p := range params {
+		s += ";" + p.name + "=" + quoteParam(p.value)
+	}
+	s += ":" + value
+
+	// Continuation lines start with a space, which counts toward their length
+	prefix, limit := "", maxLineLength
+	for len(s) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		_, e.err = e.w.WriteString(prefix + s[:n] + "\r\n")
+		if e.err != nil {
+			return
+		}
+		s = s[n:]
+		prefix, limit = " ", maxLineLength-1
+	}
+	_, e.err = e.w.WriteString(prefix + s + "\r\n")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// quoteParam quotes parameter values that contain separators. Double quotes
+// cannot be escaped, so they are dropped.
+func quoteParam(s string) string {
+	s = strings.Replace(s, `"`, "", -1)
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + s + `"`
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+// Package ics reads and writes iCalendar (RFC 5545) files. Only the events of
+// a calendar are supported.
+package ics
+
+import (
+	"time"
+)
+
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+)
+
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	RoleRequired       = "REQ-PARTICIPANT"
+	RoleOptional       = "OPT-PARTICIPANT"
+	RoleNonParticipant = "NON-PARTICIPANT"
+	RoleChair          = "CHAIR"
+)
+
+const (
+	PartStatNeedsAction = "NEEDS-ACTION"
+	PartStatAccepted    = "ACCEPTED"
+	PartStatDeclined    = "DECLINED"
+	PartStatTentative   = "TENTATIVE"
+)
+
+// MediaType is the MIME type of iCalendar files.
+const MediaType = "text/calendar"
+
+type Calendar struct {
+	ProdID string
+	Method string
+	Events []*Event
+}
+
+// Event is a VEVENT. Start and End are in the time zone the event is written
+// in, UTC or a named location. All-day events end on the day after their last
+// day, and only the dates of Start and End are used. ExDates are the starts of
+// the occurrences removed from RRule. The occurrences of a recurring event
+// share its UID, and are told apart by RecurrenceID, their start in the
+// series.
+type Event struct {
+	UID          string
+	Stamp        time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Status       string
+	Transparent  bool
+	Organizer    *Attendee
+	Attendees    []*Attendee
+	RRule        *RecurrenceRule
+	ExDates      []time.Time
+	RecurrenceID time.Time
+}
+
+type Attendee struct {
+	Name     string
+	Email    string
+	Role     string
+	PartStat string
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package ics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	cal := &Calendar{
+		ProdID: "-//Test//EN",
+		Method: MethodPublish,
+		Events: []*Event{
+			{
+				UID:         "event-1",
+				Stamp:       time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+				Summary:     "Planning; budget, and \\ roadmap",
+				Description: "Line one\nLine two with a long text that needs to be folded because it is longer than seventy-five octets: é",
+				Location:    "Room 1",
+				Start:       time.Date(2020, 3, 11, 9, 0, 0, 0, loc),
+				End:         time.Date(2020, 3, 11, 10, 0, 0, 0, loc),
+				Status:      StatusConfirmed,
+				Organizer:   &Attendee{Name: "Doe, John", Email: "john@example.com"},
+				Attendees: []*Attendee{
+					{Name: "Alice", Email: "alice@example.com", Role: RoleRequired, PartStat: PartStatAccepted},
+					{Email: "bob@example.com", Role: RoleOptional, PartStat: PartStatNeedsAction},
+				},
+			},
+			{
+				UID:         "event-2",
+				Stamp:       time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+				Summary:     "Holiday",
+				Start:       time.Date(2020, 3, 12, 0, 0, 0, 0, time.UTC),
+				End:         time.Date(2020, 3, 14, 0, 0, 0, 0, time.UTC),
+				AllDay:      true,
+				Transparent: true,
+			},
+			{
+				UID:          "event-3",
+				Stamp:        time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+				Start:        time.Date(2020, 3, 12, 15, 30, 0, 0, time.UTC),
+				End:          time.Date(2020, 3, 12, 16, 0, 0, 0, time.UTC),
+				RecurrenceID: time.Date(2020, 3, 12, 14, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, Encode(buf, cal))
+	out := buf.String()
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		require.True(t, len(line) <= maxLineLength, line)
+	}
+	require.Contains(t, out, "DTSTART;TZID=America/New_York:20200311T090000\r\n")
+	require.Contains(t, out, "DTSTART;VALUE=DATE:20200312\r\n")
+	require.Contains(t, out, "DTSTART:20200312T153000Z\r\n")
+	require.Contains(t, out, "RECURRENCE-ID:20200312T140000Z\r\n")
+	require.Contains(t, out, "SUMMARY:Planning\\; budget\\, and \\\\ roadmap\r\n")
+	require.Contains(t, out, "ORGANIZER;CN=\"Doe, John\":mailto:john@example.com\r\n")
+	require.Contains(t, out, "BEGIN:DAYLIGHT\r\nDTSTART:20200308T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\nEND:DAYLIGHT\r\n")
+	require.Contains(t, out, "BEGIN:STANDARD\r\nDTSTART:20201101T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\nEND:STANDARD\r\n")
+
+	decoded, err := Decode(strings.NewReader(out))
+	require.NoError(t, err)
+	require.Equal(t, cal.ProdID, decoded.ProdID)
+	require.Equal(t, cal.Method, decoded.Method)
+	require.Len(t, decoded.Events, len(cal.Events))
+	for i, expected := range cal.Events {
+		actual := decoded.Events[i]
+		require.True(t, expected.Start.Equal(actual.Start), expected.UID)
+		require.True(t, expected.End.Equal(actual.End), expected.UID)
+		require.True(t, expected.Stamp.Equal(actual.Stamp), expected.UID)
+		require.Equal(t, expected.Start.Location().String(), actual.Start.Location().String())
+		expected.Start, expected.End, expected.Stamp = actual.Start, actual.End, actual.Stamp
+		require.Equal(t, expected, actual)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		data             string
+		expected         *Event
+		expectedLocation string
+		expectedError    string
+	}{
+		{
+			name: "Windows time zone and duration",
+			data: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\nDTSTART;TZID=\"Pacific Standard Time\":20200311T090000\nDURATION:PT1H30M\nSUMMARY:Sync\nEND:VEVENT\nEND:VCALENDAR\n",
+			expected: &Event{
+				UID:     "1",
+				Summary: "Sync",
+				Start:   time.Date(2020, 3, 11, 16, 0, 0, 0, time.UTC),
+				End:     time.Date(2020, 3, 11, 17, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Folded lines and all-day event without end",
+			data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:2\r\nDTSTART;VALUE=DATE:20200311\r\nSUMMARY:Long\r\n  summary\r\nATTENDEE;CN=Alice;PARTSTAT=declined:MAILTO:alice@example.com\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			expected: &Event{
+				UID:       "2",
+				Summary:   "Long summary",
+				Start:     time.Date(2020, 3, 11, 0, 0, 0, 0, time.UTC),
+				End:       time.Date(2020, 3, 12, 0, 0, 0, 0, time.UTC),
+				AllDay:    true,
+				Attendees: []*Attendee{{Name: "Alice", Email: "alice@example.com", PartStat: PartStatDeclined}},
+			},
+		},
+		{
+			name: "Time zone defined by the file",
+			data: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"BEGIN:VTIMEZONE",
+				"TZID:Custom Eastern",
+				"BEGIN:STANDARD",
+				"DTSTART:19671029T020000",
+				"RRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11",
+				"TZOFFSETFROM:-0400",
+				"TZOFFSETTO:-0500",
+				"TZNAME:EST",
+				"END:STANDARD",
+				"BEGIN:DAYLIGHT",
+				"DTSTART:19870405T020000",
+				"RRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3",
+				"TZOFFSETFROM:-0500",
+				"TZOFFSETTO:-0400",
+				"TZNAME:EDT",
+				"END:DAYLIGHT",
+				"END:VTIMEZONE",
+				"BEGIN:VEVENT",
+				"UID:4",
+				"DTSTART;TZID=Custom Eastern:20200311T090000",
+				"DTEND;TZID=Custom Eastern:20200311T100000",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20200401T000000Z",
+				"EXDATE;TZID=Custom Eastern:20200316T090000,20200318T090000",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			}, "\n"),
+			expectedLocation: "America/New_York",
+			expected: &Event{
+				UID:     "4",
+				Start:   time.Date(2020, 3, 11, 13, 0, 0, 0, time.UTC),
+				End:     time.Date(2020, 3, 11, 14, 0, 0, 0, time.UTC),
+				ExDates: []time.Time{time.Date(2020, 3, 16, 13, 0, 0, 0, time.UTC), time.Date(2020, 3, 18, 13, 0, 0, 0, time.UTC)},
+				RRule: &RecurrenceRule{
+					Freq:      FreqWeekly,
+					Interval:  1,
+					Until:     time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+					ByDay:     []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Wednesday}},
+					WeekStart: time.Monday,
+				},
+			},
+		},
+		{
+			name:          "Not a calendar",
+			data:          "BEGIN:VCARD\nFN:Alice\nEND:VCARD\n",
+			expectedError: "not an iCalendar file",
+		},
+		{
+			name:          "Unbalanced components",
+			data:          "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+			expectedError: "unexpected END:VCALENDAR",
+		},
+		{
+			name:          "Unknown time zone",
+			data:          "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:3\nDTSTART;TZID=Nowhere:20200311T090000\nEND:VEVENT\nEND:VCALENDAR\n",
+			expectedError: `unknown time zone "Nowhere"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cal, err := Decode(strings.NewReader(tc.data))
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, cal.Events, 1)
+			actual := cal.Events[0]
+			require.True(t, tc.expected.Start.Equal(actual.Start))
+			require.True(t, tc.expected.End.Equal(actual.End))
+			if tc.expectedLocation != "" {
+				require.Equal(t, tc.expectedLocation, actual.Start.Location().String())
+			}
+			require.Len(t, actual.ExDates, len(tc.expected.ExDates))
+			for i, exdate := range tc.expected.ExDates {
+				require.True(t, exdate.Equal(actual.ExDates[i]))
+			}
+			tc.expected.Start, tc.expected.End, tc.expected.ExDates = actual.Start, actual.End, actual.ExDates
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRecurrenceRule(t *testing.T) {
+	for _, tc := range []struct {
+		value         string
+		expected      *RecurrenceRule
+		expectedError string
+	}{
+		{
+			value:    "FREQ=DAILY;COUNT=5",
+			expected: &RecurrenceRule{Freq: FreqDaily, Interval: 1, Count: 5, WeekStart: time.Monday},
+		},
+		{
+			value: "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;WKST=SU",
+			expected: &RecurrenceRule{
+				Freq:      FreqMonthly,
+				Interval:  2,
+				ByDay:     []WeekdayNum{{Ordinal: -1, Weekday: time.Friday}},
+				WeekStart: time.Sunday,
+			},
+		},
+		{
+			value:    "FREQ=YEARLY;BYMONTHDAY=15;BYMONTH=6",
+			expected: &RecurrenceRule{Freq: FreqYearly, Interval: 1, ByMonthDay: []int{15}, ByMonth: []int{6}, WeekStart: time.Monday},
+		},
+		{value: "FREQ=HOURLY", expectedError: `unsupported recurrence frequency "HOURLY"`},
+		{value: "FREQ=DAILY;BYDAY=XX", expectedError: `invalid day "XX"`},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tc.value, time.UTC)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rule)
+			require.Equal(t, tc.value, rule.String())
+		})
+	}
+}
+
+func TestNthWeekday(t *testing.T) {
+	require.Equal(t, time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC), nthWeekday(2020, time.March, time.Sunday, 2, time.UTC))
+	require.Equal(t, time.Date(2020, 3, 27, 0, 0, 0, 0, time.UTC), nthWeekday(2020, time.March, time.Friday, -1, time.UTC))
+	require.Equal(t, time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC), nthWeekday(2020, time.March, time.Tuesday, -1, time.UTC))
+}
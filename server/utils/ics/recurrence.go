@@ -0,0 +1,205 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package ics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// RecurrenceRule is an RRULE. Only the parts that calendars commonly use are
+// kept.
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// WeekdayNum is a day of the week, like MO, or its occurrence within the
+// month or year, like 2MO or -1FR. Ordinal is 0 for every occurrence.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// ParseRecurrenceRule reads an RRULE value. UNTIL times without a time zone
+// are read in loc.
+func ParseRecurrenceRule(value string, loc *time.Location) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(value, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid recurrence rule %q", value)
+		}
+		name, v := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = v
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(v)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(v)
+		case "UNTIL":
+			rule.Until, err = parseUntil(v, loc)
+		case "BYDAY":
+			for _, day := range strings.Split(v, ",") {
+				wn, dayErr := parseWeekdayNum(day)
+				if dayErr != nil {
+					return nil, dayErr
+				}
+				rule.ByDay = append(rule.ByDay, wn)
+			}
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(v)
+		case "BYMONTH":
+			rule.ByMonth, err = parseInts(v)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseInts(v)
+		case "WKST":
+			rule.WeekStart, err = parseWeekday(v)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid recurrence rule %q", value)
+		}
+	}
+
+	switch rule.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	default:
+		return nil, errors.Errorf("unsupported recurrence frequency %q", rule.Freq)
+	}
+	if rule.Interval < 1 {
+		return nil, errors.Errorf("invalid recurrence interval %d", rule.Interval)
+	}
+	return rule, nil
+}
+
+// String writes the rule as an RRULE value.
+func (rule *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + rule.Freq}
+	if rule.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(rule.Interval))
+	}
+	if rule.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(rule.Count))
+	}
+	if !rule.Until.IsZero() {
+		parts = append(parts, "UNTIL="+rule.Until.UTC().Format(utcFormat))
+	}
+	if len(rule.ByDay) > 0 {
+		days := []string{}
+		for _, wn := range rule.ByDay {
+			day := weekdayCodes[wn.Weekday]
+			if wn.Ordinal != 0 {
+				day = strconv.Itoa(wn.Ordinal) + day
+			}
+			days = append(days, day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(rule.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+formatInts(rule.ByMonthDay))
+	}
+	if len(rule.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+formatInts(rule.ByMonth))
+	}
+	if len(rule.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+formatInts(rule.BySetPos))
+	}
+	if rule.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCodes[rule.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func parseUntil(v string, loc *time.Location) (time.Time, error) {
+	switch {
+	case len(v) == len(dateFormat):
+		return time.ParseInLocation(dateFormat, v, loc)
+	case strings.HasSuffix(v, "Z"):
+		return time.ParseInLocation(utcFormat, v, time.UTC)
+	default:
+		return time.ParseInLocation(dateTimeFormat, v, loc)
+	}
+}
+
+func parseWeekdayNum(v string) (WeekdayNum, error) {
+	if len(v) < 2 {
+		return WeekdayNum{}, errors.Errorf("invalid day %q", v)
+	}
+	weekday, err := parseWeekday(v[len(v)-2:])
+	if err != nil {
+		return WeekdayNum{}, err
+	}
+	wn := WeekdayNum{Weekday: weekday}
+	if ordinal := strings.TrimPrefix(v[:len(v)-2], "+"); ordinal != "" {
+		wn.Ordinal, err = strconv.Atoi(ordinal)
+		if err != nil {
+			return WeekdayNum{}, errors.Errorf("invalid day %q", v)
+		}
+	}
+	return wn, nil
+}
+
+func parseWeekday(v string) (time.Weekday, error) {
+	for i, code := range weekdayCodes {
+		if v == code {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, errors.Errorf("invalid day %q", v)
+}
+
+func parseInts(v string) ([]int, error) {
+	ints := []int{}
+	for _, s := range strings.Split(v, ",") {
+		n, err := strconv.Atoi(strings.TrimPrefix(s, "+"))
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+func formatInts(ints []int) string {
+	s := []string{}
+	for _, n := range ints {
+		s = append(s, strconv.Itoa(n))
+	}
+	return strings.Join(s, ",")
+}
+
+// nthWeekday finds the nth weekday of the month, counting from its end when n
+// is negative.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int, loc *time.Location) time.Time {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc)
+		back := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -back+7*(n+1))
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	forward := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, forward+7*(n-1))
+}
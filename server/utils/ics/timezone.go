@@ -0,0 +1,221 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+package ics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	tzdata "github.com/mattermost/mattermost-plugin-mscalendar/server/utils/tz"
+)
+
+// vtimezone is a time zone defined by the file, for TZIDs that are neither
+// IANA nor Windows names. Its observances start at wall-clock times, which are
+// kept as UTC times.
+type vtimezone struct {
+	observances []*observance
+}
+
+type observance struct {
+	start      time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	rule       *RecurrenceRule
+	rdates     []time.Time
+}
+
+func decodeTimeZone(c *component) (*vtimezone, error) {
+	tz := &vtimezone{}
+	for _, child := range c.children {
+		if child.name != "STANDARD" && child.name != "DAYLIGHT" {
+			continue
+		}
+
+		o := &observance{name: unescapeText(child.value("TZNAME"))}
+		var err error
+		o.start, err = time.ParseInLocation(dateTimeFormat, child.value("DTSTART"), time.UTC)
+		if err != nil {
+			return nil, errors.Errorf("invalid time zone start %q", child.value("DTSTART"))
+		}
+		o.offsetFrom, err = parseOffset(child.value("TZOFFSETFROM"))
+		if err != nil {
+			return nil, err
+		}
+		o.offsetTo, err = parseOffset(child.value("TZOFFSETTO"))
+		if err != nil {
+			return nil, err
+		}
+		if rrule := child.value("RRULE"); rrule != "" {
+			o.rule, err = ParseRecurrenceRule(rrule, time.UTC)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, p := range child.properties {
+			if p.name != "RDATE" {
+				continue
+			}
+			for _, v := range strings.Split(p.value, ",") {
+				rdate, err := time.ParseInLocation(dateTimeFormat, v, time.UTC)
+				if err == nil {
+					o.rdates = append(o.rdates, rdate)
+				}
+			}
+		}
+		tz.observances = append(tz.observances, o)
+	}
+	if len(tz.observances) == 0 {
+		return nil, errors.Errorf("time zone %q has no observances", c.value("TZID"))
+	}
+	return tz, nil
+}
+
+// location finds the observance in effect at the wall-clock time, and
+// returns a location with its fixed offset.
+func (tz *vtimezone) location(wall time.Time) *time.Location {
+	var current *observance
+	var currentOnset time.Time
+	for _, o := range tz.observances {
+		onset, ok := o.lastOnset(wall)
+		if ok && (current == nil || onset.After(currentOnset)) {
+			current, currentOnset = o, onset
+		}
+	}
+	if current == nil {
+		// Before the first onset, the offset the first observance moved from
+		first := tz.observances[0]
+		for _, o := range tz.observances {
+			if o.start.Before(first.start) {
+				first = o
+			}
+		}
+		return time.FixedZone("", first.offsetFrom)
+	}
+	return time.FixedZone(current.name, current.offsetTo)
+}
+
+// namedLocation finds a location of a Windows time zone with the same offsets
+// as the zone every day of the year, so that the events in the zone can be
+// sent with a time zone that follows its daylight saving time. Zones with the
+// same names for their offsets are preferred, as several zones can have the
+// same offsets in a year.
+func (tz *vtimezone) namedLocation(year int) *time.Location {
+	type zoneAt struct {
+		name   string
+		offset int
+	}
+	days := []zoneAt{}
+	for day := time.Date(year, time.January, 1, 12, 0, 0, 0, time.UTC); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		name, offset := day.In(tz.location(day)).Zone()
+		days = append(days, zoneAt{name, offset})
+	}
+
+	var found *time.Location
+	for _, windowsName := range tzdata.WindowsNames() {
+		loc, err := time.LoadLocation(tzdata.Go(windowsName))
+		if err != nil {
+			continue
+		}
+		sameOffsets, sameNames := true, true
+		for i, day := range days {
+			name, offset := time.Date(year, time.January, 1+i, 12, 0, 0, 0, loc).Zone()
+			if offset != day.offset {
+				sameOffsets = false
+				break
+			}
+			sameNames = sameNames && name == day.name
+		}
+		switch {
+		case sameOffsets && sameNames:
+			return loc
+		case sameOffsets && found == nil:
+			found = loc
+		}
+	}
+	return found
+}
+
+// lastOnset finds the latest onset of the observance at or before the
+// wall-clock time. Only yearly rules are followed, as time zones use.
+func (o *observance) lastOnset(wall time.Time) (time.Time, bool) {
+	var last time.Time
+	found := false
+	consider := func(onset time.Time) {
+		if !onset.After(wall) && (!found || onset.After(last)) {
+			last, found = onset, true
+		}
+	}
+
+	consider(o.start)
+	for _, rdate := range o.rdates {
+		consider(rdate)
+	}
+	if o.rule == nil || o.rule.Freq != FreqYearly {
+		return last, found
+	}
+	for year := wall.Year() - 1; year <= wall.Year(); year++ {
+		if year < o.start.Year() {
+			continue
+		}
+		onset := o.onsetIn(year)
+		if !o.rule.Until.IsZero() && onset.After(o.rule.Until) {
+			continue
+		}
+		consider(onset)
+	}
+	return last, found
+}
+
+func (o *observance) onsetIn(year int) time.Time {
+	month := o.start.Month()
+	if len(o.rule.ByMonth) > 0 {
+		month = time.Month(o.rule.ByMonth[0])
+	}
+	day := time.Date(year, month, o.start.Day(), 0, 0, 0, 0, time.UTC)
+	switch {
+	case len(o.rule.ByDay) > 0:
+		wn := o.rule.ByDay[0]
+		n := wn.Ordinal
+		if n == 0 && len(o.rule.BySetPos) > 0 {
+			n = o.rule.BySetPos[0]
+		}
+		if n == 0 {
+			n = 1
+		}
+		day = nthWeekday(year, month, wn.Weekday, n, time.UTC)
+	case len(o.rule.ByMonthDay) > 0:
+		day = time.Date(year, month, o.rule.ByMonthDay[0], 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(year, month, day.Day(), o.start.Hour(), o.start.Minute(), o.start.Second(), 0, time.UTC)
+}
+
+func parseOffset(v string) (int, error) {
+	if len(v) != 5 && len(v) != 7 || (v[0] != '+' && v[0] != '-') {
+		return 0, errors.Errorf("invalid time zone offset %q", v)
+	}
+	hours, err := strconv.Atoi(v[1:3])
+	if err != nil {
+		return 0, errors.Errorf("invalid time zone offset %q", v)
+	}
+	minutes, err := strconv.Atoi(v[3:5])
+	if err != nil {
+		return 0, errors.Errorf("invalid time zone offset %q", v)
+	}
+	seconds := 0
+	if len(v) == 7 {
+		seconds, err = strconv.Atoi(v[5:7])
+		if err != nil {
+			return 0, errors.Errorf("invalid time zone offset %q", v)
+		}
+	}
+	offset := hours*3600 + minutes*60 + seconds
+	if v[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
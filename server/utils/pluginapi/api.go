@@ -38,6 +38,38 @@ func (a *API) GetMattermostChannel(channelID string) (*model.Channel, error) {
 	return c, nil
 }
 
+func (a *API) GetMattermostTeam(teamID string) (*model.Team, error) {
+	t, appErr := a.api.GetTeam(teamID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return t, nil
+}
+
+func (a *API) GetMattermostTeamMember(teamID, mattermostUserID string) (*model.TeamMember, error) {
+	m, appErr := a.api.GetTeamMember(teamID, mattermostUserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return m, nil
+}
+
+func (a *API) GetMattermostFileInfo(fileID string) (*model.FileInfo, error) {
+	info, appErr := a.api.GetFileInfo(fileID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return info, nil
+}
+
+func (a *API) GetMattermostFile(fileID string) ([]byte, error) {
+	data, appErr := a.api.GetFile(fileID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return data, nil
+}
+
 func (a *API) GetMattermostUsersInChannel(channelID string, sortBy string, page int, perPage int) ([]*model.User, error) {
 	u, appErr := a.api.GetUsersInChannel(channelID, sortBy, page, perPage)
 	if appErr != nil {
@@ -129,3 +161,23 @@ func (a *API) GetPost(postID string) (*model.Post, error) {
 	}
 	return p, nil
 }
+
+func (a *API) HasPermissionToChannel(mattermostUserID, channelID string, permission *model.Permission) bool {
+	return a.api.HasPermissionToChannel(mattermostUserID, channelID, permission)
+}
+
+func (a *API) GetPostThread(postID string) (*model.PostList, error) {
+	l, appErr := a.api.GetPostThread(postID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return l, nil
+}
+
+func (a *API) UpdatePost(post *model.Post) (*model.Post, error) {
+	p, appErr := a.api.UpdatePost(post)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return p, nil
+}
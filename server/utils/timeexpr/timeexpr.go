@@ -0,0 +1,564 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License for license information.
+
+// Package timeexpr parses the natural-language times entered in commands and
+// dialogs, like "tomorrow 3pm" or "next tuesday 10:30-11".
+package timeexpr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/server/utils/tz"
+)
+
+const Help = "Times can be given like `tomorrow 3pm`, `next tuesday 10:30-11`, `in 2 hours for 45m`, `fri` or `2020-03-10 9am to 5pm`."
+
+// Expression is a parsed time expression. End is zero when the expression
+// is a single point in time without a duration, like "tomorrow 3pm".
+// Expressions without a time of day, like "fri", cover whole days.
+type Expression struct {
+	Start   time.Time
+	End     time.Time
+	HasTime bool
+}
+
+// timePoint is one side of a time expression: a day, a time of day, or both.
+type timePoint struct {
+	day   *time.Time
+	days  int
+	clock *clockTime
+}
+
+type clockTime struct {
+	text        string
+	hour        int
+	minute      int
+	meridiem    string
+	leadingZero bool
+}
+
+var (
+	clockRegexp    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a|p)?$`)
+	durationRegexp = regexp.MustCompile(`^(?:(\d+(?:\.\d+)?)([dhm]))+$`)
+	durationPart   = regexp.MustCompile(`(\d+(?:\.\d+)?)([dhm])`)
+	isoDateRegexp  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	slashDateRegex = regexp.MustCompile(`^\d{1,2}/\d{1,2}(/\d{2,4})?$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "weds": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// NowIn returns the current time in the time zone of a calendar.
+func NowIn(timezone string) time.Time {
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		loc = time.UTC
+	}
+	return time.Now().In(loc)
+}
+
+// Parse parses expressions like "tomorrow 3pm", "next tuesday
+// 10:30-11", "in 2 hours for 45m" or "fri", relative to now and in its
+// location.
+func Parse(expr string, now time.Time) (*Expression, error) {
+	tokens := Tokenize(expr)
+	if len(tokens) == 0 {
+		return nil, errors.New("please enter a time")
+	}
+
+	var duration time.Duration
+	for i, token := range tokens {
+		if token != "for" {
+			continue
+		}
+		d, err := ParseDuration(tokens[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		duration = d
+		tokens = tokens[:i]
+		break
+	}
+
+	var result *Expression
+	switch {
+	case len(tokens) == 0:
+		return nil, errors.New("please enter a time before the duration")
+	case tokens[0] == "now" && len(tokens) == 1:
+		result = &Expression{Start: now.Truncate(time.Minute), HasTime: true}
+	case tokens[0] == "in":
+		d, err := ParseDuration(tokens[1:])
+		if err != nil {
+			return nil, err
+		}
+		result = &Expression{Start: now.Add(d).Truncate(time.Minute), HasTime: true}
+	default:
+		var err error
+		result, err = parseTimeRange(tokens, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if duration != 0 {
+		if !result.End.IsZero() && result.HasTime {
+			return nil, errors.New("please give either an end time or a duration, not both")
+		}
+		if !result.HasTime {
+			return nil, errors.New("please give a start time with the duration")
+		}
+		result.End = result.Start.Add(duration)
+	}
+	return result, nil
+}
+
+func parseTimeRange(tokens []string, now time.Time) (*Expression, error) {
+	left, right := tokens, []string(nil)
+	for i, token := range tokens {
+		if token == "-" || token == "to" || token == "until" {
+			left, right = tokens[:i], tokens[i+1:]
+			if len(left) == 0 || len(right) == 0 {
+				return nil, errors.Errorf("%q needs a start and an end", strings.Join(tokens, " "))
+			}
+			break
+		}
+	}
+
+	start, err := parseTimePoint(left, now)
+	if err != nil {
+		return nil, err
+	}
+	startDay := start.dayOr(now)
+
+	if right == nil {
+		if start.clock == nil {
+			return &Expression{
+				Start: startDay,
+				End:   startDay.AddDate(0, 0, start.days),
+			}, nil
+		}
+		hour, minute, err := start.clock.resolve()
+		if err != nil {
+			return nil, err
+		}
+		return &Expression{
+			Start:   AtClock(startDay, hour, minute),
+			HasTime: true,
+		}, nil
+	}
+
+	end, err := parseTimePoint(right, now)
+	if err != nil {
+		return nil, err
+	}
+	endDay := startDay
+	if end.day != nil {
+		endDay = *end.day
+	}
+
+	var result *Expression
+	switch {
+	case start.clock == nil && end.clock == nil:
+		result = &Expression{
+			Start: startDay,
+			End:   endDay.AddDate(0, 0, end.days),
+		}
+	case start.clock == nil || end.clock == nil:
+		return nil, errors.Errorf("%q needs a time on both sides, or on neither", strings.Join(tokens, " "))
+	default:
+		startHour, startMinute, endHour, endMinute, err := resolveClockRange(start.clock, end.clock, end.day == nil)
+		if err != nil {
+			return nil, err
+		}
+		result = &Expression{
+			Start:   AtClock(startDay, startHour, startMinute),
+			End:     AtClock(endDay, endHour, endMinute),
+			HasTime: true,
+		}
+	}
+
+	if !result.End.After(result.Start) {
+		return nil, errors.Errorf("%q ends before it starts", strings.Join(tokens, " "))
+	}
+	return result, nil
+}
+
+func parseTimePoint(tokens []string, now time.Time) (*timePoint, error) {
+	p := &timePoint{days: 1}
+	today := AtClock(now, 0, 0)
+	setDay := func(day time.Time, days int) error {
+		if p.day != nil {
+			return errors.Errorf("%q gives more than one day", strings.Join(tokens, " "))
+		}
+		p.day = &day
+		p.days = days
+		return nil
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch token {
+		case "noon":
+			token = "12pm"
+		case "midnight":
+			token = "12am"
+		}
+
+		var err error
+		switch {
+		case token == "today":
+			err = setDay(today, 1)
+		case token == "tomorrow":
+			err = setDay(today.AddDate(0, 0, 1), 1)
+		case token == "yesterday":
+			err = setDay(today.AddDate(0, 0, -1), 1)
+		case clockRegexp.MatchString(token):
+			if p.clock != nil {
+				return nil, errors.Errorf("%q gives more than one time", strings.Join(tokens, " "))
+			}
+			p.clock, err = parseClock(token)
+		case token == "this" || token == "next":
+			if i+1 >= len(tokens) {
+				return nil, errors.Errorf("%q must be followed by a day or \"week\"", token)
+			}
+			i++
+			next := token == "next"
+			monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+			if next {
+				monday = monday.AddDate(0, 0, 7)
+			}
+			if tokens[i] == "week" {
+				err = setDay(monday, 7)
+				break
+			}
+			wd, ok := weekdays[tokens[i]]
+			if !ok {
+				return nil, errors.Errorf("%q must be followed by a day or \"week\"", token)
+			}
+			day := monday.AddDate(0, 0, (int(wd)+6)%7)
+			if !next && day.Before(today) {
+				return nil, errors.Errorf("\"this %s\" has already passed, did you mean \"next %s\"?", tokens[i], tokens[i])
+			}
+			err = setDay(day, 1)
+		case token == "week":
+			monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+			err = setDay(monday, 7)
+		case isoDateRegexp.MatchString(token):
+			day, parseErr := time.ParseInLocation("2006-01-02", token, now.Location())
+			if parseErr != nil {
+				return nil, errors.Errorf("%q is not a valid date", token)
+			}
+			err = setDay(day, 1)
+		case slashDateRegex.MatchString(token):
+			return nil, errors.Errorf("%q is ambiguous, please write dates like 2020-03-10 or \"mar 10\"", token)
+		default:
+			if wd, ok := weekdays[token]; ok {
+				err = setDay(today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), 1)
+				break
+			}
+			if month, ok := months[token]; ok {
+				if i+1 >= len(tokens) {
+					return nil, errors.Errorf("%q must be followed by the day of the month", token)
+				}
+				i++
+				dayOfMonth, convErr := strconv.Atoi(strings.TrimRight(tokens[i], "stndrh"))
+				if convErr != nil || dayOfMonth < 1 || dayOfMonth > 31 {
+					return nil, errors.Errorf("%q is not a valid day of %s", tokens[i], month)
+				}
+				day := time.Date(today.Year(), month, dayOfMonth, 0, 0, 0, 0, now.Location())
+				if day.Month() != month {
+					return nil, errors.Errorf("%s has no day %d", month, dayOfMonth)
+				}
+				if day.Before(today) {
+					day = day.AddDate(1, 0, 0)
+				}
+				err = setDay(day, 1)
+				break
+			}
+			return nil, errors.Errorf("could not understand %q. %s", token, Help)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ParseTimeOfDay parses a time of day like "8am", "8:30 AM" or "17:00".
+func ParseTimeOfDay(expr string) (hour, minute int, err error) {
+	tokens := Tokenize(expr)
+	if len(tokens) != 1 || !clockRegexp.MatchString(tokens[0]) {
+		return 0, 0, errors.Errorf("%q is not a time of day, please enter a time like 8:00AM", expr)
+	}
+	c, err := parseClock(tokens[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	return c.resolve()
+}
+
+func (p *timePoint) dayOr(now time.Time) time.Time {
+	if p.day != nil {
+		return *p.day
+	}
+	return AtClock(now, 0, 0)
+}
+
+func parseClock(token string) (*clockTime, error) {
+	m := clockRegexp.FindStringSubmatch(token)
+	if m == nil {
+		return nil, errors.Errorf("%q is not a valid time", token)
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	c := &clockTime{
+		text:        token,
+		hour:        hour,
+		minute:      minute,
+		leadingZero: len(m[1]) == 2 && m[1][0] == '0',
+	}
+	switch m[3] {
+	case "a", "am":
+		c.meridiem = "am"
+	case "p", "pm":
+		c.meridiem = "pm"
+	}
+
+	if minute > 59 {
+		return nil, errors.Errorf("%q is not a valid time", token)
+	}
+	if c.meridiem != "" && (hour < 1 || hour > 12) {
+		return nil, errors.Errorf("%q is not a valid time", token)
+	}
+	if hour > 23 {
+		return nil, errors.Errorf("%q is not a valid time", token)
+	}
+	return c, nil
+}
+
+// isAmbiguous reports whether the time could be in the morning or the
+// afternoon. Hours from 7 to 12 are taken as is, as they are usually meant
+// during working hours, as are 24-hour clock times like 15:00 or 03:00.
+func (c *clockTime) isAmbiguous() bool {
+	return c.meridiem == "" && !c.leadingZero && c.hour >= 1 && c.hour <= 6
+}
+
+func (c *clockTime) resolve() (hour, minute int, err error) {
+	if c.isAmbiguous() {
+		return 0, 0, errors.Errorf("%q is ambiguous, please use %dam or %dpm", c.text, c.hour, c.hour)
+	}
+	return c.hourWithMeridiem(c.meridiem), c.minute, nil
+}
+
+func (c *clockTime) hourWithMeridiem(meridiem string) int {
+	switch meridiem {
+	case "am":
+		return c.hour % 12
+	case "pm":
+		return c.hour%12 + 12
+	default:
+		return c.hour
+	}
+}
+
+// canTakeMeridiem reports whether the time was written on a 12-hour clock
+// without am or pm.
+func (c *clockTime) canTakeMeridiem() bool {
+	return c.meridiem == "" && !c.leadingZero && c.hour >= 1 && c.hour <= 12
+}
+
+// resolveClockRange resolves the times of a range like "10-11am", "3pm-4" or
+// "9-5", where a side written without am or pm takes it from the other side,
+// or the one that makes the range end after it starts.
+func resolveClockRange(start, end *clockTime, sameDay bool) (startHour, startMinute, endHour, endMinute int, err error) {
+	minutes := func(hour, minute int) int { return hour*60 + minute }
+
+	if sameDay {
+		switch {
+		case (start.meridiem == "" && end.meridiem != "") || (start.isAmbiguous() && !end.isAmbiguous()):
+			endHour, endMinute, _ = end.resolve()
+			startHour = start.hour
+			if start.canTakeMeridiem() {
+				startHour = pickHour(start, end.meridiem, func(hour int) bool {
+					return minutes(hour, start.minute) < minutes(endHour, endMinute)
+				})
+			}
+			return startHour, start.minute, endHour, endMinute, nil
+		case (end.meridiem == "" && start.meridiem != "") || (end.isAmbiguous() && !start.isAmbiguous()):
+			startHour, startMinute, _ = start.resolve()
+			endHour = end.hour
+			if end.canTakeMeridiem() {
+				meridiem := start.meridiem
+				if meridiem == "" {
+					meridiem = "am"
+				}
+				endHour = pickHour(end, meridiem, func(hour int) bool {
+					return minutes(hour, end.minute) > minutes(startHour, startMinute)
+				})
+			}
+			return startHour, startMinute, endHour, end.minute, nil
+		}
+	}
+
+	startHour, startMinute, err = start.resolve()
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	endHour, endMinute, err = end.resolve()
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return startHour, startMinute, endHour, endMinute, nil
+}
+
+// pickHour returns the hour of c in the preferred half of the day, pm when
+// none is given, unless only the other half is ok.
+func pickHour(c *clockTime, preferred string, ok func(hour int) bool) int {
+	other := "am"
+	if preferred == "am" {
+		other = "pm"
+	} else {
+		preferred = "pm"
+	}
+
+	hour := c.hourWithMeridiem(preferred)
+	if !ok(hour) {
+		if alt := c.hourWithMeridiem(other); ok(alt) {
+			return alt
+		}
+	}
+	return hour
+}
+
+// ParseDuration parses a duration like "45m", "1h30m" or "2 hours"
+// from the tokens of an expression.
+func ParseDuration(tokens []string) (time.Duration, error) {
+	if len(tokens) == 0 {
+		return 0, errors.New("please enter a duration, like 45m or 1h30m")
+	}
+
+	normalized := []string{}
+	for _, token := range tokens {
+		switch token {
+		case "a", "an":
+			token = "1"
+		case "and":
+			continue
+		}
+		normalized = append(normalized, token)
+	}
+	s := strings.Join(normalized, "")
+	for _, r := range []struct{ from, to string }{
+		{"minutes", "m"}, {"minute", "m"}, {"mins", "m"}, {"min", "m"},
+		{"hours", "h"}, {"hour", "h"}, {"hrs", "h"}, {"hr", "h"},
+		{"days", "d"}, {"day", "d"},
+	} {
+		s = strings.ReplaceAll(s, r.from, r.to)
+	}
+	if !durationRegexp.MatchString(s) {
+		return 0, errors.Errorf("%q is not a valid duration, please use a duration like 45m or 1h30m", strings.Join(tokens, " "))
+	}
+
+	var d time.Duration
+	for _, m := range durationPart.FindAllStringSubmatch(s, -1) {
+		value, _ := strconv.ParseFloat(m[1], 64)
+		unit := time.Minute
+		switch m[2] {
+		case "h":
+			unit = time.Hour
+		case "d":
+			unit = 24 * time.Hour
+		}
+		d += time.Duration(value * float64(unit))
+	}
+	if d <= 0 {
+		return 0, errors.Errorf("%q is not a valid duration", strings.Join(tokens, " "))
+	}
+	return d.Round(time.Minute), nil
+}
+
+// Tokenize lowercases and splits the expression, separating the
+// ranges written without spaces like "10:30-11" or "2020-03-10..2020-03-12"
+// and joining "3 pm".
+func Tokenize(expr string) []string {
+	expr = strings.ReplaceAll(expr, "..", " - ")
+	fields := strings.Fields(strings.ToLower(strings.ReplaceAll(expr, ",", " ")))
+	tokens := []string{}
+	for _, field := range fields {
+		if isoDateRegexp.MatchString(field) || !strings.Contains(field, "-") {
+			tokens = append(tokens, field)
+			continue
+		}
+		parts := strings.Split(field, "-")
+		for i, part := range parts {
+			if i > 0 {
+				tokens = append(tokens, "-")
+			}
+			if part != "" {
+				tokens = append(tokens, part)
+			}
+		}
+	}
+
+	joined := []string{}
+	for _, token := range tokens {
+		last := len(joined) - 1
+		if (token == "am" || token == "pm") && last >= 0 && clockRegexp.MatchString(joined[last]) {
+			joined[last] += token
+			continue
+		}
+		joined = append(joined, token)
+	}
+	return joined
+}
+
+// AtClock returns the time of day on the day, in its location.
+func AtClock(day time.Time, hour, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
+
+// Format renders the parsed range for command responses.
+func Format(e *Expression) string {
+	if !e.HasTime {
+		last := e.End.AddDate(0, 0, -1)
+		if last.Equal(e.Start) {
+			return e.Start.Format("Monday, January 02")
+		}
+		return fmt.Sprintf("%s - %s", e.Start.Format("Monday, January 02"), last.Format("Monday, January 02"))
+	}
+	if e.End.IsZero() {
+		return e.Start.Format("Monday, January 02 · " + time.Kitchen)
+	}
+	return fmt.Sprintf("%s - %s", e.Start.Format("Monday, January 02 · "+time.Kitchen), e.End.Format(time.Kitchen))
+}
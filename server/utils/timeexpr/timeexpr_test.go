@@ -0,0 +1,94 @@
+package timeexpr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeExpression(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// A Wednesday
+	now := time.Date(2020, 3, 11, 10, 17, 30, 0, loc)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2020, 3, day, hour, minute, 0, 0, loc)
+	}
+
+	tcs := []struct {
+		expr          string
+		start         time.Time
+		end           time.Time
+		hasTime       bool
+		expectedError string
+	}{
+		{expr: "tomorrow 3pm", start: at(12, 15, 0), hasTime: true},
+		{expr: "Tomorrow 3 PM", start: at(12, 15, 0), hasTime: true},
+		{expr: "next tuesday 10:30-11", start: at(17, 10, 30), end: at(17, 11, 0), hasTime: true},
+		{expr: "in 2 hours for 45m", start: at(11, 12, 17), end: at(11, 13, 2), hasTime: true},
+		{expr: "fri", start: at(13, 0, 0), end: at(14, 0, 0)},
+		{expr: "wed", start: at(11, 0, 0), end: at(12, 0, 0)},
+		{expr: "mon", start: at(16, 0, 0), end: at(17, 0, 0)},
+		{expr: "this week", start: at(9, 0, 0), end: at(16, 0, 0)},
+		{expr: "next week", start: at(16, 0, 0), end: at(23, 0, 0)},
+		{expr: "today to fri", start: at(11, 0, 0), end: at(14, 0, 0)},
+		{expr: "2020-03-20 9am to 5pm", start: at(20, 9, 0), end: at(20, 17, 0), hasTime: true},
+		{expr: "mar 20 9-5", start: at(20, 9, 0), end: at(20, 17, 0), hasTime: true},
+		{expr: "8-9pm", start: at(11, 20, 0), end: at(11, 21, 0), hasTime: true},
+		{expr: "11-1pm", start: at(11, 11, 0), end: at(11, 13, 0), hasTime: true},
+		{expr: "3pm-4", start: at(11, 15, 0), end: at(11, 16, 0), hasTime: true},
+		{expr: "thu noon for an hour", start: at(12, 12, 0), end: at(12, 13, 0), hasTime: true},
+		{expr: "15:00 for 1h30m", start: at(11, 15, 0), end: at(11, 16, 30), hasTime: true},
+		{expr: "tomorrow 3", expectedError: `"3" is ambiguous, please use 3am or 3pm`},
+		{expr: "2-3", expectedError: `"2" is ambiguous, please use 2am or 2pm`},
+		{expr: "3/10", expectedError: `"3/10" is ambiguous, please write dates like 2020-03-10 or "mar 10"`},
+		{expr: "this mon", expectedError: `"this mon" has already passed, did you mean "next mon"?`},
+		{expr: "fri for 1h", expectedError: "please give a start time with the duration"},
+		{expr: "tomorrow 4pm-3pm", expectedError: `"tomorrow 4pm - 3pm" ends before it starts`},
+		{expr: "soon", expectedError: `could not understand "soon". ` + Help},
+		{expr: "in 2 parsecs", expectedError: `"2 parsecs" is not a valid duration, please use a duration like 45m or 1h30m`},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := Parse(tc.expr, now)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.start, e.Start)
+			require.Equal(t, tc.end, e.End)
+			require.Equal(t, tc.hasTime, e.HasTime)
+		})
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	tcs := []struct {
+		expr          string
+		hour, minute  int
+		expectedError string
+	}{
+		{expr: "8:00AM", hour: 8},
+		{expr: "8 am", hour: 8},
+		{expr: "5:30pm", hour: 17, minute: 30},
+		{expr: "17:30", hour: 17, minute: 30},
+		{expr: "5", expectedError: `"5" is ambiguous, please use 5am or 5pm`},
+		{expr: "tomorrow 8am", expectedError: `"tomorrow 8am" is not a time of day, please enter a time like 8:00AM`},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.expr, func(t *testing.T) {
+			hour, minute, err := ParseTimeOfDay(tc.expr)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.hour, hour)
+			require.Equal(t, tc.minute, minute)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tz
 
 import (
+	"sort"
 	"time"
 )
 
@@ -23,3 +24,13 @@ func Microsoft(timeZone string) string {
 
 	return timeZone
 }
+
+// WindowsNames returns the Windows time zone names, sorted.
+func WindowsNames() []string {
+	names := make([]string, 0, len(windowsToIANA))
+	for name := range windowsToIANA {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,29 @@
+function getCookie(name) {
+    const match = document.cookie.match(new RegExp('(?:^|; )' + name + '=([^;]*)'));
+    return match ? decodeURIComponent(match[1]) : '';
+}
+
+// executeCommand runs a slash command as the current user, so that the server
+// receives a trigger ID it can open an interactive dialog with. The request
+// carries the same session cookie and CSRF headers as the host's Client4, so
+// the plugin does not need to bundle its own copy of mattermost-redux.
+export async function executeCommand(siteURL, command, channelId, teamId) {
+    const response = await fetch(`${siteURL}/api/v4/commands/execute`, {
+        method: 'POST',
+        credentials: 'include',
+        headers: {
+            'Content-Type': 'application/json',
+            'X-Requested-With': 'XMLHttpRequest',
+            'X-CSRF-Token': getCookie('MMCSRF'),
+        },
+        body: JSON.stringify({
+            command,
+            channel_id: channelId,
+            team_id: teamId,
+        }),
+    });
+    if (!response.ok) {
+        throw new Error(`Failed to execute ${command}: ${response.status}`);
+    }
+    return response.json();
+}
@@ -1,9 +1,32 @@
 import {id as pluginId} from './manifest';
+import {executeCommand} from './client';
+
+const commandTrigger = 'mscalendar';
 
 export default class Plugin {
-    // eslint-disable-next-line no-unused-vars
     initialize(registry, store) {
         // @see https://developers.mattermost.com/extend/plugins/webapp/reference/
+        registry.registerPostDropdownMenuAction(
+            'Schedule meeting from this post',
+            (postId) => {
+                const state = store.getState();
+                const post = state.entities.posts.posts[postId];
+                if (!post) {
+                    return;
+                }
+
+                const siteURL = state.entities.general.config.SiteURL || window.location.origin;
+                const teamId = state.entities.teams.currentTeamId;
+                executeCommand(siteURL, `/${commandTrigger} schedule ${postId}`, post.channel_id, teamId).catch((err) => {
+                    // eslint-disable-next-line no-console
+                    console.error(err);
+                });
+            },
+            (postId) => {
+                const post = store.getState().entities.posts.posts[postId];
+                return Boolean(post) && !post.type;
+            },
+        );
     }
 }
 